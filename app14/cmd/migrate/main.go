@@ -0,0 +1,81 @@
+// Comando migrate aplica, desfaz e lista as migrações do banco configurado em DB_DRIVER e DB_DSN.
+//
+// Uso:
+//
+//	go run ./cmd/migrate up      # aplica as migrações pendentes
+//	go run ./cmd/migrate down    # desfaz a última migração aplicada
+//	go run ./cmd/migrate status  # lista as migrações e se foram aplicadas
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"app14/internal/config"
+	"app14/internal/database"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "uso: migrate up|down|status")
+		os.Exit(2)
+	}
+
+	// Carregar configuração
+	cfg := config.LoadConfig()
+	if cfg.DatabaseDriver == database.DriverMemory {
+		log.Fatal("O driver memory não usa migrações; defina DB_DRIVER como sqlite ou postgres")
+	}
+
+	db, err := database.Open(cfg.DatabaseDriver, cfg.DatabaseDSN)
+	if err != nil {
+		log.Fatalf("Erro ao abrir o banco de dados: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Erro ao carregar as migrações: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			log.Printf("Aplicada: %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("Erro ao aplicar as migrações: %v", err)
+		}
+		if len(applied) == 0 {
+			log.Print("Nenhuma migração pendente")
+		}
+	case "down":
+		migration, ok, err := migrator.Down()
+		if err != nil {
+			log.Fatalf("Erro ao desfazer a migração: %v", err)
+		}
+		if !ok {
+			log.Print("Nenhuma migração aplicada")
+			return
+		}
+		log.Printf("Desfeita: %04d_%s", migration.Version, migration.Name)
+	case "status":
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatalf("Erro ao consultar as migrações: %v", err)
+		}
+		for _, status := range statuses {
+			applied := "pendente"
+			if status.AppliedAt != nil {
+				applied = "aplicada em " + status.AppliedAt.Local().Format(time.RFC3339)
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, applied)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "comando desconhecido %q; uso: migrate up|down|status\n", os.Args[1])
+		os.Exit(2)
+	}
+}
@@ -20,7 +20,11 @@ func main() {
 	cfg := config.LoadConfig()
 	
 	// Iniciar repositório
-	taskRepo := database.NewInMemoryTaskRepository()
+	taskRepo, closeRepo, err := newTaskRepository(cfg)
+	if err != nil {
+		log.Fatalf("Error opening task repository: %v", err)
+	}
+	defer closeRepo()
 	
 	// Configurar rotas
 	router := api.NewRouter(taskRepo)
@@ -43,13 +47,16 @@ func main() {
 		}
 	}()
 	
-	log.Printf("Server started on port %d", cfg.ServerPort)
+	log.Printf("Server started on port %d (storage: %s)", cfg.ServerPort, cfg.DatabaseDriver)
 	log.Printf("API endpoints:")
 	log.Printf("- GET    /api/tasks")
 	log.Printf("- POST   /api/tasks")
+	log.Printf("- GET    /api/tasks/summary")
 	log.Printf("- GET    /api/tasks/{id}")
 	log.Printf("- PUT    /api/tasks/{id}")
+	log.Printf("- PATCH  /api/tasks/{id}")
 	log.Printf("- DELETE /api/tasks/{id}")
+	log.Printf("- GET    /api/tasks/{id}/history")
 	
 	// Esperar sinal de interrupção
 	<-done
@@ -65,4 +72,33 @@ func main() {
 	}
 	
 	log.Print("Server stopped")
-} 
\ No newline at end of file
+}
+
+// newTaskRepository cria o repositório do driver configurado. Nos bancos SQL, as
+// migrações pendentes são aplicadas antes de a API começar a atender.
+func newTaskRepository(cfg *config.Config) (database.TaskRepository, func() error, error) {
+	if cfg.DatabaseDriver == database.DriverMemory {
+		return database.NewInMemoryTaskRepository(), func() error { return nil }, nil
+	}
+
+	db, err := database.Open(cfg.DatabaseDriver, cfg.DatabaseDSN)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		db.Close()
+		return nil, nil, err
+	}
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+
+	return database.NewSQLTaskRepository(db), db.Close, nil
+}
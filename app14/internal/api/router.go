@@ -49,7 +49,8 @@ func (r *Router) handleTasksRoutes(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-// handleTaskRoutes gerencia as requisições para /api/tasks/{id}
+// handleTaskRoutes gerencia as requisições para /api/tasks/{id}, /api/tasks/{id}/history
+// e /api/tasks/summary
 func (r *Router) handleTaskRoutes(w http.ResponseWriter, req *http.Request) {
 	// Verificar se a rota inclui um ID (ex: /api/tasks/{id})
 	if !strings.HasPrefix(req.URL.Path, "/api/tasks/") {
@@ -57,15 +58,49 @@ func (r *Router) handleTaskRoutes(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if req.URL.Path == "/api/tasks/summary" {
+		r.handleTaskSummaryRoutes(w, req)
+		return
+	}
+
+	if strings.HasSuffix(req.URL.Path, "/history") {
+		r.handleTaskHistoryRoutes(w, req)
+		return
+	}
+
 	switch req.Method {
 	case http.MethodGet:
 		r.taskHandler.GetTask(w, req)
 	case http.MethodPut:
 		r.taskHandler.UpdateTask(w, req)
+	case http.MethodPatch:
+		r.taskHandler.PatchTask(w, req)
 	case http.MethodDelete:
 		r.taskHandler.DeleteTask(w, req)
 	default:
-		w.Header().Set("Allow", "GET, PUT, DELETE")
+		w.Header().Set("Allow", "GET, PUT, PATCH, DELETE")
 		handlers.RespondWithError(w, http.StatusMethodNotAllowed, "Método não permitido")
 	}
-} 
\ No newline at end of file
+}
+
+// handleTaskHistoryRoutes gerencia as requisições para /api/tasks/{id}/history
+func (r *Router) handleTaskHistoryRoutes(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.taskHandler.GetTaskHistory(w, req)
+	default:
+		w.Header().Set("Allow", "GET")
+		handlers.RespondWithError(w, http.StatusMethodNotAllowed, "Método não permitido")
+	}
+}
+
+// handleTaskSummaryRoutes gerencia as requisições para /api/tasks/summary
+func (r *Router) handleTaskSummaryRoutes(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		r.taskHandler.GetTaskSummary(w, req)
+	default:
+		w.Header().Set("Allow", "GET")
+		handlers.RespondWithError(w, http.StatusMethodNotAllowed, "Método não permitido")
+	}
+}
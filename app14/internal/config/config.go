@@ -10,25 +10,33 @@ import (
 type Config struct {
 	ServerPort int
 	APIVersion string
+	// DatabaseDriver seleciona o armazenamento das tarefas: memory (padrão), sqlite ou postgres
+	DatabaseDriver string
+	// DatabaseDSN é o caminho do arquivo SQLite ou a string de conexão PostgreSQL
+	DatabaseDSN string
 }
 
 // Valores padrão
 const (
-	defaultServerPort = 8080
-	defaultAPIVersion = "v1"
+	defaultServerPort     = 8080
+	defaultAPIVersion     = "v1"
+	defaultDatabaseDriver = "memory"
+	defaultDatabaseDSN    = "tasks.db"
 )
 
 // LoadConfig carrega a configuração do ambiente ou usa valores padrão
 func LoadConfig() *Config {
 	return &Config{
-		ServerPort: getEnvAsInt("SERVER_PORT", defaultServerPort),
-		APIVersion: getEnv("API_VERSION", defaultAPIVersion),
+		ServerPort:     getEnvAsInt("SERVER_PORT", defaultServerPort),
+		APIVersion:     getEnv("API_VERSION", defaultAPIVersion),
+		DatabaseDriver: getEnv("DB_DRIVER", defaultDatabaseDriver),
+		DatabaseDSN:    getEnv("DB_DSN", defaultDatabaseDSN),
 	}
 }
 
 // String retorna uma representação string da configuração
 func (c *Config) String() string {
-	return fmt.Sprintf("ServerPort: %d, APIVersion: %s", c.ServerPort, c.APIVersion)
+	return fmt.Sprintf("ServerPort: %d, APIVersion: %s, DatabaseDriver: %s", c.ServerPort, c.APIVersion, c.DatabaseDriver)
 }
 
 // getEnv retorna o valor da variável de ambiente ou o valor padrão
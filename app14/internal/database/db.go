@@ -0,0 +1,120 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq" // Driver PostgreSQL
+	"modernc.org/sqlite"  // Driver SQLite sem CGO
+)
+
+// Drivers de armazenamento suportados
+const (
+	DriverMemory   = "memory"
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+var (
+	ErrUnsupportedDriver = errors.New("driver de banco de dados não suportado")
+)
+
+// sqliteLower é a função do SQLite que converte o texto para minúsculas como strings.ToLower.
+// O LOWER nativo do SQLite só converte letras ASCII, e a busca com acentos divergiria do
+// repositório em memória.
+const sqliteLower = "unicode_lower"
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(sqliteLower, 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch value := args[0].(type) {
+		case string:
+			return strings.ToLower(value), nil
+		case []byte:
+			return strings.ToLower(string(value)), nil
+		default:
+			return value, nil
+		}
+	})
+}
+
+// DB é uma conexão com o banco de dados que conhece o driver em uso
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// Open abre a conexão com o banco SQLite ou PostgreSQL. As migrações não são aplicadas;
+// use NewMigrator para isso.
+func Open(driver, dsn string) (*DB, error) {
+	switch driver {
+	case DriverSQLite:
+		dsn = sqliteDSN(dsn)
+	case DriverPostgres:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDriver, driver)
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// O SQLite aceita apenas um escritor por vez; uma conexão evita erros de banco ocupado
+	if driver == DriverSQLite {
+		conn.SetMaxOpenConns(1)
+	}
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("erro ao conectar ao banco de dados: %w", err)
+	}
+
+	return &DB{DB: conn, driver: driver}, nil
+}
+
+// Driver retorna o driver da conexão
+func (db *DB) Driver() string {
+	return db.driver
+}
+
+// rebind converte os parâmetros "?" da consulta para o formato do driver ($1, $2... no PostgreSQL)
+func (db *DB) rebind(query string) string {
+	if db.driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// lowerFunc retorna a função SQL que converte o texto para minúsculas, inclusive as letras acentuadas
+func (db *DB) lowerFunc() string {
+	if db.driver == DriverSQLite {
+		return sqliteLower
+	}
+	return "LOWER"
+}
+
+// sqliteDSN grava as datas em um formato que o driver lê de volta como time.Time
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "_time_format=") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&_time_format=sqlite"
+	}
+	return dsn + "?_time_format=sqlite"
+}
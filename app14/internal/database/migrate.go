@@ -0,0 +1,208 @@
+package database
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFiles contém as migrações de cada driver, em migrations/<driver>/NNNN_nome.(up|down).sql
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+// Migration é uma alteração versionada do esquema, com o SQL para aplicá-la e desfazê-la
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus indica se uma migração foi aplicada e quando
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator aplica e desfaz as migrações embutidas do driver da conexão, registrando
+// as versões aplicadas na tabela schema_migrations
+type Migrator struct {
+	db         *DB
+	migrations []Migration
+}
+
+// NewMigrator cria um Migrator com as migrações do driver da conexão
+func NewMigrator(db *DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.driver)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up aplica as migrações pendentes em ordem de versão e retorna as aplicadas
+func (m *Migrator) Up() ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.run(migration, migration.up, true); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down desfaz a última migração aplicada. Retorna false se nenhuma migração estava aplicada.
+func (m *Migrator) Down() (Migration, bool, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return Migration{}, false, err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.run(migration, migration.down, false); err != nil {
+			return Migration{}, false, err
+		}
+		return migration, true, nil
+	}
+	return Migration{}, false, nil
+}
+
+// Status retorna todas as migrações conhecidas, com a data de aplicação das já aplicadas
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			appliedAt := appliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// run executa o SQL da migração e atualiza schema_migrations na mesma transação
+func (m *Migrator) run(migration Migration, statements string, up bool) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(statements); err != nil {
+		return fmt.Errorf("erro na migração %04d_%s: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.Exec(m.db.rebind("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)"),
+			migration.Version, migration.Name, time.Now().UTC())
+	} else {
+		_, err = tx.Exec(m.db.rebind("DELETE FROM schema_migrations WHERE version = ?"), migration.Version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// applied cria a tabela schema_migrations, se necessário, e retorna as versões aplicadas
+func (m *Migrator) applied() (map[int]time.Time, error) {
+	if _, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// loadMigrations lê as migrações embutidas do driver, ordenadas por versão. Cada versão
+// precisa dos arquivos up e down.
+func loadMigrations(driver string) ([]Migration, error) {
+	dir := path.Join("migrations", driver)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDriver, driver)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		version, label, found := strings.Cut(base, "_")
+		number, convErr := strconv.Atoi(version)
+		if !ok || !found || convErr != nil || !strings.HasSuffix(name, ".sql") || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("nome de migração inválido: %s", name)
+		}
+
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[number]
+		if !exists {
+			migration = &Migration{Version: number, Name: label}
+			byVersion[number] = migration
+		}
+		if direction == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("migração %04d_%s sem os arquivos up e down", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
@@ -0,0 +1,11 @@
+CREATE TABLE tasks (
+    id           SERIAL PRIMARY KEY,
+    title        TEXT        NOT NULL,
+    description  TEXT        NOT NULL DEFAULT '',
+    status       TEXT        NOT NULL DEFAULT 'pending',
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_tasks_status ON tasks (status);
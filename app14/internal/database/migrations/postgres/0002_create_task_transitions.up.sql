@@ -0,0 +1,10 @@
+CREATE TABLE task_transitions (
+    id          SERIAL PRIMARY KEY,
+    task_id     INTEGER     NOT NULL REFERENCES tasks (id),
+    from_status TEXT        NOT NULL DEFAULT '',
+    to_status   TEXT        NOT NULL,
+    changed_at  TIMESTAMPTZ NOT NULL,
+    changed_by  TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_task_transitions_task_id ON task_transitions (task_id);
@@ -0,0 +1,8 @@
+DROP TABLE task_labels;
+
+DROP INDEX idx_tasks_assignee;
+DROP INDEX idx_tasks_due_date;
+
+ALTER TABLE tasks DROP COLUMN assignee;
+ALTER TABLE tasks DROP COLUMN due_date;
+ALTER TABLE tasks DROP COLUMN priority;
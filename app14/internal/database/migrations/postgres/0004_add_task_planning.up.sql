@@ -0,0 +1,14 @@
+ALTER TABLE tasks ADD COLUMN priority TEXT NOT NULL DEFAULT 'medium';
+ALTER TABLE tasks ADD COLUMN due_date TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN assignee TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_due_date ON tasks (due_date);
+CREATE INDEX idx_tasks_assignee ON tasks (assignee);
+
+CREATE TABLE task_labels (
+    task_id INTEGER NOT NULL REFERENCES tasks (id),
+    label   TEXT    NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+
+CREATE INDEX idx_task_labels_label ON task_labels (label);
@@ -0,0 +1,11 @@
+CREATE TABLE tasks (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    title        TEXT     NOT NULL,
+    description  TEXT     NOT NULL DEFAULT '',
+    status       TEXT     NOT NULL DEFAULT 'pending',
+    created_at   DATETIME NOT NULL,
+    updated_at   DATETIME NOT NULL,
+    completed_at DATETIME
+);
+
+CREATE INDEX idx_tasks_status ON tasks (status);
@@ -0,0 +1,480 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"app14/internal/models"
+)
+
+// taskColumns são as colunas lidas por scanTask, na mesma ordem. Os rótulos ficam na
+// tabela task_labels e são lidos por loadLabels.
+const taskColumns = "id, title, description, status, version, priority, due_date, assignee, created_at, updated_at, completed_at"
+
+// labelsBatchSize limita a quantidade de tarefas por consulta de rótulos
+const labelsBatchSize = 500
+
+// SQLTaskRepository implementa TaskRepository sobre SQLite ou PostgreSQL
+type SQLTaskRepository struct {
+	db *DB
+}
+
+// NewSQLTaskRepository cria uma nova instância de SQLTaskRepository. O esquema deve
+// estar atualizado com as migrações de Migrator.
+func NewSQLTaskRepository(db *DB) *SQLTaskRepository {
+	return &SQLTaskRepository{
+		db: db,
+	}
+}
+
+// List retorna a página de tarefas que atendem aos filtros da consulta, na ordem pedida
+func (r *SQLTaskRepository) List(query TaskQuery) (*TaskPage, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
+	where, args := r.where(query)
+
+	var total int
+	if err := r.db.QueryRow(r.db.rebind("SELECT COUNT(*) FROM tasks"+where), args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	stmt := "SELECT " + taskColumns + " FROM tasks" + where + orderBy(query.Sort)
+	switch {
+	case query.Limit > 0:
+		stmt += " LIMIT ? OFFSET ?"
+		args = append(args, query.Limit, query.Offset)
+	case query.Offset > 0 && r.db.driver == DriverSQLite:
+		// O SQLite só aceita OFFSET junto com LIMIT; -1 significa sem limite
+		stmt += " LIMIT -1 OFFSET ?"
+		args = append(args, query.Offset)
+	case query.Offset > 0:
+		stmt += " OFFSET ?"
+		args = append(args, query.Offset)
+	}
+
+	tasks, err := r.queryTasks(stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskPage{Tasks: tasks, Total: total}, nil
+}
+
+// Summary conta as tarefas que atendem aos filtros da consulta; ordenação e página são ignoradas
+func (r *SQLTaskRepository) Summary(query TaskQuery) (*models.TaskSummary, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
+	where, args := r.where(query)
+	summary := models.NewTaskSummary()
+
+	err := r.countBy("SELECT status, COUNT(*) FROM tasks"+where+" GROUP BY status", args, func(key string, count int) {
+		summary.ByStatus[models.TaskStatus(key)] = count
+		summary.Total += count
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.countBy("SELECT assignee, COUNT(*) FROM tasks"+where+" GROUP BY assignee", args, func(key string, count int) {
+		if key == "" {
+			summary.Unassigned = count
+		} else {
+			summary.ByAssignee[key] = count
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.countBy("SELECT l.label, COUNT(*) FROM task_labels l JOIN tasks ON tasks.id = l.task_id"+where+" GROUP BY l.label", args, func(key string, count int) {
+		summary.ByLabel[key] = count
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// countBy executa uma consulta agrupada que retorna a chave e a contagem de cada grupo
+func (r *SQLTaskRepository) countBy(stmt string, args []interface{}, add func(key string, count int)) error {
+	rows, err := r.db.Query(r.db.rebind(stmt), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		add(key, count)
+	}
+	return rows.Err()
+}
+
+// queryTasks executa a consulta das colunas de taskColumns e carrega os rótulos das tarefas
+func (r *SQLTaskRepository) queryTasks(stmt string, args ...interface{}) ([]*models.Task, error) {
+	rows, err := r.db.Query(r.db.rebind(stmt), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*models.Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	// Liberar a conexão antes de ler os rótulos: o SQLite usa uma só
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadLabels(tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// loadLabels preenche os rótulos das tarefas, em ordem alfabética
+func (r *SQLTaskRepository) loadLabels(tasks []*models.Task) error {
+	byID := make(map[int]*models.Task, len(tasks))
+	for _, task := range tasks {
+		task.Labels = make([]string, 0)
+		byID[task.ID] = task
+	}
+
+	for start := 0; start < len(tasks); start += labelsBatchSize {
+		end := start + labelsBatchSize
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+
+		placeholders := make([]string, 0, end-start)
+		args := make([]interface{}, 0, end-start)
+		for _, task := range tasks[start:end] {
+			placeholders = append(placeholders, "?")
+			args = append(args, task.ID)
+		}
+
+		rows, err := r.db.Query(r.db.rebind("SELECT task_id, label FROM task_labels WHERE task_id IN ("+strings.Join(placeholders, ", ")+") ORDER BY task_id, label"), args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var taskID int
+			var label string
+			if err := rows.Scan(&taskID, &label); err != nil {
+				rows.Close()
+				return err
+			}
+			byID[taskID].Labels = append(byID[taskID].Labels, label)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceLabels grava os rótulos da tarefa na transação, substituindo os anteriores
+func (r *SQLTaskRepository) replaceLabels(tx *sql.Tx, taskID int, labels []string) error {
+	if _, err := tx.Exec(r.db.rebind("DELETE FROM task_labels WHERE task_id = ?"), taskID); err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if _, err := tx.Exec(r.db.rebind("INSERT INTO task_labels (task_id, label) VALUES (?, ?)"), taskID, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// where monta a cláusula WHERE com os filtros da consulta e os seus argumentos
+func (r *SQLTaskRepository) where(query TaskQuery) (string, []interface{}) {
+	conditions := make([]string, 0)
+	args := make([]interface{}, 0)
+
+	if len(query.Statuses) > 0 {
+		placeholders := make([]string, 0, len(query.Statuses))
+		for _, status := range query.Statuses {
+			placeholders = append(placeholders, "?")
+			args = append(args, status)
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if len(query.Assignees) > 0 {
+		placeholders := make([]string, 0, len(query.Assignees))
+		for _, assignee := range query.Assignees {
+			placeholders = append(placeholders, "?")
+			args = append(args, assignee)
+		}
+		conditions = append(conditions, "assignee IN ("+strings.Join(placeholders, ", ")+")")
+	}
+
+	if query.Label != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id AND tl.label = ?)")
+		args = append(args, query.Label)
+	}
+
+	if query.OverdueAt != nil {
+		conditions = append(conditions, "due_date IS NOT NULL AND due_date < ? AND status NOT IN (?, ?)")
+		args = append(args, query.OverdueAt.UTC(), models.StatusCompleted, models.StatusCancelled)
+	}
+
+	if query.Search != "" {
+		pattern := "%" + escapeLike(strings.ToLower(query.Search)) + "%"
+		lower := r.db.lowerFunc()
+		conditions = append(conditions, `(`+lower+`(title) LIKE ? ESCAPE '\' OR `+lower+`(description) LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+
+	for _, bound := range []struct {
+		column string
+		rng    TimeRange
+	}{{"created_at", query.Created}, {"updated_at", query.Updated}} {
+		if bound.rng.From != nil {
+			conditions = append(conditions, bound.column+" >= ?")
+			args = append(args, bound.rng.From.UTC())
+		}
+		if bound.rng.To != nil {
+			conditions = append(conditions, bound.column+" < ?")
+			args = append(args, bound.rng.To.UTC())
+		}
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderBy monta a cláusula ORDER BY, sempre desempatando pelo ID
+func orderBy(fields []SortField) string {
+	terms := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		term := sortFields[field.Field]
+		if field.Desc {
+			term += " DESC"
+		}
+		// Tarefas sem prazo ficam por último nos dois bancos, em qualquer direção
+		if field.Field == SortByDueDate {
+			terms = append(terms, "due_date IS NULL")
+		}
+		terms = append(terms, term)
+	}
+	terms = append(terms, "id")
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
+
+// escapeLike escapa os curingas do LIKE para buscar o texto literalmente
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// GetByID retorna uma tarefa pelo ID
+func (r *SQLTaskRepository) GetByID(id int) (*models.Task, error) {
+	tasks, err := r.queryTasks("SELECT "+taskColumns+" FROM tasks WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, ErrTaskNotFound
+	}
+	return tasks[0], nil
+}
+
+// Create cria uma nova tarefa, preenche o ID gerado pelo banco e registra o status inicial no histórico
+func (r *SQLTaskRepository) Create(task *models.Task, by string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	task.Version = 1
+	task.Labels = models.NormalizeLabels(task.Labels)
+	err = tx.QueryRow(
+		r.db.rebind("INSERT INTO tasks (title, description, status, version, priority, due_date, assignee, created_at, updated_at, completed_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id"),
+		task.Title, task.Description, task.Status, task.Version, task.Priority, utcOrNil(task.DueDate), task.Assignee,
+		task.CreatedAt.UTC(), task.UpdatedAt.UTC(), utcOrNil(task.CompletedAt),
+	).Scan(&task.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.replaceLabels(tx, task.ID, task.Labels); err != nil {
+		return err
+	}
+
+	if err := r.addTransition(tx, task.ID, initialTransition(task, by)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Update atualiza uma tarefa existente e registra a mudança de status no histórico
+func (r *SQLTaskRepository) Update(id int, task *models.Task, by string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// A versão armazenada detecta edições concorrentes, e o status anterior decide se a
+	// transição é permitida; no PostgreSQL, a linha fica bloqueada até o fim da transação
+	var previous models.TaskStatus
+	var version int
+	err = tx.QueryRow(r.db.rebind("SELECT status, version FROM tasks WHERE id = ?"+r.forUpdate()), id).Scan(&previous, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	task.ID = id
+	transition, err := prepareUpdate(task, previous, version, by)
+	if err != nil {
+		return err
+	}
+
+	task.Labels = models.NormalizeLabels(task.Labels)
+	_, err = tx.Exec(
+		r.db.rebind("UPDATE tasks SET title = ?, description = ?, status = ?, version = ?, priority = ?, due_date = ?, assignee = ?, updated_at = ?, completed_at = ? WHERE id = ?"),
+		task.Title, task.Description, task.Status, task.Version, task.Priority, utcOrNil(task.DueDate), task.Assignee,
+		task.UpdatedAt.UTC(), utcOrNil(task.CompletedAt), id,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.replaceLabels(tx, id, task.Labels); err != nil {
+		return err
+	}
+
+	if transition != nil {
+		if err := r.addTransition(tx, id, *transition); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Delete remove uma tarefa, o seu histórico e os seus rótulos
+func (r *SQLTaskRepository) Delete(id int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"task_transitions", "task_labels"} {
+		if _, err := tx.Exec(r.db.rebind("DELETE FROM "+table+" WHERE task_id = ?"), id); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.Exec(r.db.rebind("DELETE FROM tasks WHERE id = ?"), id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTaskNotFound
+	}
+	return tx.Commit()
+}
+
+// History retorna as mudanças de status da tarefa, da mais antiga para a mais recente
+func (r *SQLTaskRepository) History(id int) ([]models.StatusTransition, error) {
+	if _, err := r.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(r.db.rebind("SELECT from_status, to_status, changed_at, changed_by FROM task_transitions WHERE task_id = ? ORDER BY id"), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := make([]models.StatusTransition, 0)
+	for rows.Next() {
+		var transition models.StatusTransition
+		if err := rows.Scan(&transition.From, &transition.To, &transition.At, &transition.By); err != nil {
+			return nil, err
+		}
+		history = append(history, transition)
+	}
+
+	return history, rows.Err()
+}
+
+// addTransition grava uma entrada do histórico de status na transação
+func (r *SQLTaskRepository) addTransition(tx *sql.Tx, taskID int, transition models.StatusTransition) error {
+	_, err := tx.Exec(
+		r.db.rebind("INSERT INTO task_transitions (task_id, from_status, to_status, changed_at, changed_by) VALUES (?, ?, ?, ?, ?)"),
+		taskID, transition.From, transition.To, transition.At.UTC(), transition.By,
+	)
+	return err
+}
+
+// forUpdate retorna a cláusula que bloqueia a linha lida até o fim da transação. O SQLite
+// não a suporta nem precisa dela: as transações de escrita já são serializadas.
+func (r *SQLTaskRepository) forUpdate() string {
+	if r.db.driver == DriverPostgres {
+		return " FOR UPDATE"
+	}
+	return ""
+}
+
+// scanner é implementado por *sql.Row e *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanTask lê uma tarefa com as colunas de taskColumns
+func scanTask(row scanner) (*models.Task, error) {
+	var task models.Task
+	var dueDate, completedAt sql.NullTime
+	if err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.Version, &task.Priority, &dueDate, &task.Assignee,
+		&task.CreatedAt, &task.UpdatedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if dueDate.Valid {
+		task.DueDate = &dueDate.Time
+	}
+	if completedAt.Valid {
+		task.CompletedAt = &completedAt.Time
+	}
+	return &task, nil
+}
+
+// utcOrNil converte a data opcional para UTC, ou nil para gravar NULL
+func utcOrNil(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
@@ -0,0 +1,231 @@
+package database
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"app14/internal/models"
+)
+
+// Campos aceitos na ordenação das tarefas
+const (
+	SortByID        = "id"
+	SortByTitle     = "title"
+	SortByStatus    = "status"
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+	SortByDueDate   = "due_date"
+	SortByPriority  = "priority"
+)
+
+var (
+	ErrInvalidSortField = errors.New("campo de ordenação inválido")
+)
+
+// sortFields associa os campos de ordenação às expressões SQL sobre a tabela tasks. A
+// prioridade é ordenada pela gravidade, e não pelo nome.
+var sortFields = map[string]string{
+	SortByID:        "id",
+	SortByTitle:     "title",
+	SortByStatus:    "status",
+	SortByCreatedAt: "created_at",
+	SortByUpdatedAt: "updated_at",
+	SortByDueDate:   "due_date",
+	SortByPriority:  "CASE priority WHEN 'low' THEN 1 WHEN 'medium' THEN 2 WHEN 'high' THEN 3 WHEN 'urgent' THEN 4 ELSE 0 END",
+}
+
+// IsSortField verifica se o campo pode ser usado na ordenação
+func IsSortField(field string) bool {
+	_, ok := sortFields[field]
+	return ok
+}
+
+// SortField é um critério de ordenação, crescente ou, com Desc, decrescente
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// TimeRange limita uma data ao intervalo [From, To). Limites nil não restringem.
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// TaskQuery descreve a listagem de tarefas: filtros, ordenação e a página desejada
+type TaskQuery struct {
+	// Statuses filtra pelas tarefas com um dos status; vazio aceita todos
+	Statuses []models.TaskStatus
+	// Search busca o texto no título ou na descrição, sem diferenciar maiúsculas
+	Search string
+	// Assignees filtra pelas tarefas de um dos responsáveis; vazio aceita todos
+	Assignees []string
+	// Label filtra pelas tarefas que têm o rótulo
+	Label   string
+	Created TimeRange
+	Updated TimeRange
+	// OverdueAt, quando definido, filtra pelas tarefas vencidas nesse instante (Task.IsOverdue)
+	OverdueAt *time.Time
+	// Sort define a ordenação; o ID crescente desempata e é o padrão. Tarefas sem prazo
+	// ficam por último na ordenação por due_date, em qualquer direção.
+	Sort []SortField
+	// Limit é o tamanho da página; zero retorna todas as tarefas a partir de Offset
+	Limit  int
+	Offset int
+}
+
+// TaskPage é uma página da listagem, com o total de tarefas que atendem aos filtros
+type TaskPage struct {
+	Tasks []*models.Task
+	Total int
+}
+
+// validate verifica os campos de ordenação e os limites da página
+func (q TaskQuery) validate() error {
+	for _, s := range q.Sort {
+		if !IsSortField(s.Field) {
+			return ErrInvalidSortField
+		}
+	}
+	if q.Limit < 0 || q.Offset < 0 {
+		return errors.New("limit e offset não podem ser negativos")
+	}
+	return nil
+}
+
+// matches verifica se a tarefa atende aos filtros da consulta
+func (q TaskQuery) matches(task *models.Task) bool {
+	if len(q.Statuses) > 0 {
+		found := false
+		for _, status := range q.Statuses {
+			if task.Status == status {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(q.Assignees) > 0 {
+		found := false
+		for _, assignee := range q.Assignees {
+			if task.Assignee == assignee {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.Label != "" {
+		found := false
+		for _, label := range task.Labels {
+			if label == q.Label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if q.OverdueAt != nil && !task.IsOverdue(*q.OverdueAt) {
+		return false
+	}
+
+	if q.Search != "" {
+		search := strings.ToLower(q.Search)
+		if !strings.Contains(strings.ToLower(task.Title), search) &&
+			!strings.Contains(strings.ToLower(task.Description), search) {
+			return false
+		}
+	}
+
+	return q.Created.contains(task.CreatedAt) && q.Updated.contains(task.UpdatedAt)
+}
+
+// contains verifica se o instante está no intervalo
+func (r TimeRange) contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+	if r.To != nil && !t.Before(*r.To) {
+		return false
+	}
+	return true
+}
+
+// sortTasks ordena as tarefas pelos critérios da consulta, desempatando pelo ID
+func (q TaskQuery) sortTasks(tasks []*models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, s := range q.Sort {
+			// Tarefas sem prazo ficam por último, também na ordem decrescente
+			if s.Field == SortByDueDate && (tasks[i].DueDate == nil) != (tasks[j].DueDate == nil) {
+				return tasks[j].DueDate == nil
+			}
+			c := compareTasks(tasks[i], tasks[j], s.Field)
+			if c == 0 {
+				continue
+			}
+			if s.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return tasks[i].ID < tasks[j].ID
+	})
+}
+
+// compareTasks compara duas tarefas pelo campo informado, retornando um valor negativo, zero ou positivo
+func compareTasks(a, b *models.Task, field string) int {
+	switch field {
+	case SortByTitle:
+		return strings.Compare(a.Title, b.Title)
+	case SortByStatus:
+		return strings.Compare(string(a.Status), string(b.Status))
+	case SortByCreatedAt:
+		return compareTimes(a.CreatedAt, b.CreatedAt)
+	case SortByUpdatedAt:
+		return compareTimes(a.UpdatedAt, b.UpdatedAt)
+	case SortByDueDate:
+		if a.DueDate == nil || b.DueDate == nil {
+			return 0
+		}
+		return compareTimes(*a.DueDate, *b.DueDate)
+	case SortByPriority:
+		return a.Priority.Rank() - b.Priority.Rank()
+	default:
+		return a.ID - b.ID
+	}
+}
+
+// compareTimes compara dois instantes, retornando -1, 0 ou 1
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// paginate retorna a fatia das tarefas correspondente a Offset e Limit
+func (q TaskQuery) paginate(tasks []*models.Task) []*models.Task {
+	if q.Offset >= len(tasks) {
+		return make([]*models.Task, 0)
+	}
+	tasks = tasks[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(tasks) {
+		tasks = tasks[:q.Limit]
+	}
+	return tasks
+}
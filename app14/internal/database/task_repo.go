@@ -9,44 +9,94 @@ import (
 )
 
 var (
-	ErrTaskNotFound = errors.New("tarefa não encontrada")
+	ErrTaskNotFound    = errors.New("tarefa não encontrada")
+	ErrVersionConflict = errors.New("a tarefa foi alterada por outra requisição")
 )
 
-// TaskRepository define a interface para operações de repositório de tarefas
+// TaskRepository define a interface para operações de repositório de tarefas.
+// Create e Update registram no histórico as mudanças de status, atribuídas a by.
+// Update grava apenas se task.Version for a versão armazenada, retornando
+// ErrVersionConflict caso contrário, e rejeita com *models.TransitionError as
+// mudanças de status não permitidas.
 type TaskRepository interface {
-	GetAll() ([]*models.Task, error)
+	List(query TaskQuery) (*TaskPage, error)
 	GetByID(id int) (*models.Task, error)
-	Create(task *models.Task) error
-	Update(id int, task *models.Task) error
+	Create(task *models.Task, by string) error
+	Update(id int, task *models.Task, by string) error
 	Delete(id int) error
+	History(id int) ([]models.StatusTransition, error)
+	Summary(query TaskQuery) (*models.TaskSummary, error)
 }
 
-// InMemoryTaskRepository implementa TaskRepository usando armazenamento em memória
+// prepareUpdate confere a versão e a mudança de status e atualiza a tarefa antes de
+// gravá-la: a versão é incrementada, UpdatedAt atualizado e CompletedAt definido ao passar
+// para completed ou, quando deixa de estar completed, removido. Retorna a transição a
+// registrar, ou nil se o status não mudou.
+func prepareUpdate(task *models.Task, previous models.TaskStatus, version int, by string) (*models.StatusTransition, error) {
+	if task.Version != version {
+		return nil, ErrVersionConflict
+	}
+	if err := models.ValidateTransition(previous, task.Status); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	task.Version++
+	task.UpdatedAt = now
+
+	if task.Status == models.StatusCompleted && previous != models.StatusCompleted {
+		task.CompletedAt = &now
+	} else if task.Status != models.StatusCompleted {
+		task.CompletedAt = nil
+	}
+
+	if task.Status == previous {
+		return nil, nil
+	}
+	return &models.StatusTransition{From: previous, To: task.Status, At: now, By: by}, nil
+}
+
+// initialTransition é a entrada do histórico que registra o status inicial da tarefa criada
+func initialTransition(task *models.Task, by string) models.StatusTransition {
+	return models.StatusTransition{To: task.Status, At: task.CreatedAt, By: by}
+}
+
+// InMemoryTaskRepository implementa TaskRepository usando armazenamento em memória.
+// As tarefas são guardadas e retornadas como cópias, como em um banco de dados.
 type InMemoryTaskRepository struct {
-	tasks  map[int]*models.Task
-	nextID int
-	mutex  sync.RWMutex
+	tasks   map[int]*models.Task
+	history map[int][]models.StatusTransition
+	nextID  int
+	mutex   sync.RWMutex
 }
 
 // NewInMemoryTaskRepository cria uma nova instância de InMemoryTaskRepository
 func NewInMemoryTaskRepository() *InMemoryTaskRepository {
 	return &InMemoryTaskRepository{
-		tasks:  make(map[int]*models.Task),
-		nextID: 1,
+		tasks:   make(map[int]*models.Task),
+		history: make(map[int][]models.StatusTransition),
+		nextID:  1,
 	}
 }
 
-// GetAll retorna todas as tarefas
-func (r *InMemoryTaskRepository) GetAll() ([]*models.Task, error) {
+// List retorna a página de tarefas que atendem aos filtros da consulta, na ordem pedida
+func (r *InMemoryTaskRepository) List(query TaskQuery) (*TaskPage, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
 	tasks := make([]*models.Task, 0, len(r.tasks))
 	for _, task := range r.tasks {
-		tasks = append(tasks, task)
+		if query.matches(task) {
+			tasks = append(tasks, copyTask(task))
+		}
 	}
+	query.sortTasks(tasks)
 
-	return tasks, nil
+	return &TaskPage{Tasks: query.paginate(tasks), Total: len(tasks)}, nil
 }
 
 // GetByID retorna uma tarefa pelo ID
@@ -59,42 +109,46 @@ func (r *InMemoryTaskRepository) GetByID(id int) (*models.Task, error) {
 		return nil, ErrTaskNotFound
 	}
 
-	return task, nil
+	return copyTask(task), nil
 }
 
 // Create cria uma nova tarefa
-func (r *InMemoryTaskRepository) Create(task *models.Task) error {
+func (r *InMemoryTaskRepository) Create(task *models.Task, by string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
 	task.ID = r.nextID
+	task.Version = 1
+	task.Labels = models.NormalizeLabels(task.Labels)
 	r.nextID++
 
-	r.tasks[task.ID] = task
+	r.tasks[task.ID] = copyTask(task)
+	r.history[task.ID] = []models.StatusTransition{initialTransition(task, by)}
 	return nil
 }
 
 // Update atualiza uma tarefa existente
-func (r *InMemoryTaskRepository) Update(id int, task *models.Task) error {
+func (r *InMemoryTaskRepository) Update(id int, task *models.Task, by string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.tasks[id]; !exists {
+	existing, exists := r.tasks[id]
+	if !exists {
 		return ErrTaskNotFound
 	}
 
-	// Atualizar o timestamp
-	task.UpdatedAt = time.Now()
-
-	// Atualizar completedAt se o status for completado
-	if task.Status == models.StatusCompleted && r.tasks[id].Status != models.StatusCompleted {
-		now := time.Now()
-		task.CompletedAt = &now
-	} else if task.Status != models.StatusCompleted {
-		task.CompletedAt = nil
+	// Conferir a versão e a mudança de status e atualizar os timestamps
+	task.ID = id
+	transition, err := prepareUpdate(task, existing.Status, existing.Version, by)
+	if err != nil {
+		return err
 	}
 
-	r.tasks[id] = task
+	task.Labels = models.NormalizeLabels(task.Labels)
+	r.tasks[id] = copyTask(task)
+	if transition != nil {
+		r.history[id] = append(r.history[id], *transition)
+	}
 	return nil
 }
 
@@ -108,5 +162,53 @@ func (r *InMemoryTaskRepository) Delete(id int) error {
 	}
 
 	delete(r.tasks, id)
+	delete(r.history, id)
 	return nil
-} 
\ No newline at end of file
+}
+
+// Summary conta as tarefas que atendem aos filtros da consulta; ordenação e página são ignoradas
+func (r *InMemoryTaskRepository) Summary(query TaskQuery) (*models.TaskSummary, error) {
+	if err := query.validate(); err != nil {
+		return nil, err
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	summary := models.NewTaskSummary()
+	for _, task := range r.tasks {
+		if query.matches(task) {
+			summary.Add(task)
+		}
+	}
+	return summary, nil
+}
+
+// History retorna as mudanças de status da tarefa, da mais antiga para a mais recente
+func (r *InMemoryTaskRepository) History(id int) ([]models.StatusTransition, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if _, exists := r.tasks[id]; !exists {
+		return nil, ErrTaskNotFound
+	}
+
+	history := make([]models.StatusTransition, len(r.history[id]))
+	copy(history, r.history[id])
+	return history, nil
+}
+
+// copyTask retorna uma cópia da tarefa, para que alterações fora do repositório não afetem o armazenamento
+func copyTask(task *models.Task) *models.Task {
+	copied := *task
+	if task.CompletedAt != nil {
+		completedAt := *task.CompletedAt
+		copied.CompletedAt = &completedAt
+	}
+	if task.DueDate != nil {
+		dueDate := *task.DueDate
+		copied.DueDate = &dueDate
+	}
+	copied.Labels = append(make([]string, 0, len(task.Labels)), task.Labels...)
+	return &copied
+}
@@ -0,0 +1,692 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"app14/internal/models"
+)
+
+// newRepository cria um repositório vazio para um teste do contrato
+type newRepository func(t *testing.T) TaskRepository
+
+func TestInMemoryTaskRepository(t *testing.T) {
+	testTaskRepository(t, func(t *testing.T) TaskRepository {
+		return NewInMemoryTaskRepository()
+	})
+}
+
+func TestSQLiteTaskRepository(t *testing.T) {
+	testTaskRepository(t, func(t *testing.T) TaskRepository {
+		return NewSQLTaskRepository(openTestDB(t, DriverSQLite, filepath.Join(t.TempDir(), "tasks.db")))
+	})
+}
+
+// TestPostgresTaskRepository roda o contrato no PostgreSQL de TEST_POSTGRES_DSN, que deve
+// apontar para um banco descartável: as tabelas são removidas ao final de cada teste
+func TestPostgresTaskRepository(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN não definido")
+	}
+
+	testTaskRepository(t, func(t *testing.T) TaskRepository {
+		return NewSQLTaskRepository(openTestDB(t, DriverPostgres, dsn))
+	})
+}
+
+func TestMigratorUpDownStatus(t *testing.T) {
+	db, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("Erro ao abrir o banco: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Erro ao carregar as migrações: %v", err)
+	}
+
+	statuses, err := migrator.Status()
+	if err != nil {
+		t.Fatalf("Erro ao consultar as migrações: %v", err)
+	}
+	if len(statuses) == 0 || statuses[0].AppliedAt != nil {
+		t.Fatalf("esperava migrações pendentes, obteve %+v", statuses)
+	}
+
+	applied, err := migrator.Up()
+	if err != nil || len(applied) != len(statuses) {
+		t.Fatalf("esperava aplicar %d migrações, aplicou %d: %v", len(statuses), len(applied), err)
+	}
+	if again, err := migrator.Up(); err != nil || len(again) != 0 {
+		t.Fatalf("esperava nenhuma migração pendente, aplicou %d: %v", len(again), err)
+	}
+
+	statuses, err = migrator.Status()
+	if err != nil {
+		t.Fatalf("Erro ao consultar as migrações: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Fatalf("migração %04d_%s não aplicada", status.Version, status.Name)
+		}
+	}
+
+	// Desfazer todas as migrações remove a tabela de tarefas
+	for range applied {
+		if _, ok, err := migrator.Down(); err != nil || !ok {
+			t.Fatalf("Erro ao desfazer a migração: ok=%v, %v", ok, err)
+		}
+	}
+	if _, ok, err := migrator.Down(); err != nil || ok {
+		t.Fatalf("esperava nenhuma migração a desfazer: ok=%v, %v", ok, err)
+	}
+	if _, err := NewSQLTaskRepository(db).List(TaskQuery{}); err == nil {
+		t.Fatal("esperava erro ao consultar a tabela removida")
+	}
+}
+
+// openTestDB abre o banco, aplica as migrações e as desfaz ao final do teste
+func openTestDB(t *testing.T, driver, dsn string) *DB {
+	t.Helper()
+
+	db, err := Open(driver, dsn)
+	if err != nil {
+		t.Fatalf("Erro ao abrir o banco: %v", err)
+	}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Erro ao carregar as migrações: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("Erro ao aplicar as migrações: %v", err)
+	}
+
+	t.Cleanup(func() {
+		for {
+			_, ok, err := migrator.Down()
+			if err != nil {
+				t.Errorf("Erro ao desfazer as migrações: %v", err)
+			}
+			if err != nil || !ok {
+				break
+			}
+		}
+		db.Close()
+	})
+	return db
+}
+
+// testTaskRepository verifica o comportamento esperado de qualquer TaskRepository
+func testTaskRepository(t *testing.T, newRepo newRepository) {
+	t.Run("Create e GetByID", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Escrever testes", Description: "Contrato do repositório"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+		if task.ID == 0 {
+			t.Fatal("esperava o ID gerado na tarefa criada")
+		}
+
+		got, err := repo.GetByID(task.ID)
+		if err != nil {
+			t.Fatalf("Erro ao buscar a tarefa: %v", err)
+		}
+		assertTask(t, got, task)
+		if got.Priority != models.PriorityMedium || got.DueDate != nil || got.Assignee != "" || got.Labels == nil || len(got.Labels) != 0 {
+			t.Fatalf("esperava os campos de planejamento padrão, obteve %+v", got)
+		}
+	})
+
+	t.Run("Campos de planejamento", func(t *testing.T) {
+		repo := newRepo(t)
+		due := baseTime.Add(36 * time.Hour)
+		task := models.NewTask(models.TaskInput{
+			Title:    "Planejar",
+			Priority: models.PriorityHigh,
+			DueDate:  &due,
+			Assignee: " alice ",
+			Labels:   []string{"Sprint", "backend", "sprint"},
+		})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+
+		got, err := repo.GetByID(task.ID)
+		if err != nil {
+			t.Fatalf("Erro ao buscar a tarefa: %v", err)
+		}
+		assertTask(t, got, task)
+		if got.Assignee != "alice" || !equalStrings(got.Labels, []string{"backend", "sprint"}) {
+			t.Fatalf("esperava responsável e rótulos normalizados, obteve %q %v", got.Assignee, got.Labels)
+		}
+
+		// Os rótulos são substituídos, e o prazo e o responsável, removidos
+		got.Apply(models.TaskInput{Title: got.Title, Labels: []string{"frontend"}})
+		if err := repo.Update(task.ID, got, "bob"); err != nil {
+			t.Fatalf("Erro ao atualizar a tarefa: %v", err)
+		}
+		updated, _ := repo.GetByID(task.ID)
+		assertTask(t, updated, got)
+		if updated.Priority != models.PriorityHigh || updated.DueDate != nil || updated.Assignee != "" || !equalStrings(updated.Labels, []string{"frontend"}) {
+			t.Fatalf("tarefa atualizada inesperada: %+v", updated)
+		}
+	})
+
+	t.Run("GetByID inexistente", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.GetByID(42); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound, obteve %v", err)
+		}
+	})
+
+	t.Run("List ordenado pelo ID", func(t *testing.T) {
+		repo := newRepo(t)
+		page, err := repo.List(TaskQuery{})
+		if err != nil || len(page.Tasks) != 0 || page.Total != 0 {
+			t.Fatalf("esperava lista vazia, obteve %+v: %v", page, err)
+		}
+
+		created := make([]*models.Task, 0, 3)
+		for _, title := range []string{"Primeira", "Segunda", "Terceira"} {
+			task := models.NewTask(models.TaskInput{Title: title})
+			if err := repo.Create(task, "alice"); err != nil {
+				t.Fatalf("Erro ao criar a tarefa: %v", err)
+			}
+			created = append(created, task)
+		}
+
+		page, err = repo.List(TaskQuery{})
+		if err != nil {
+			t.Fatalf("Erro ao listar as tarefas: %v", err)
+		}
+		if len(page.Tasks) != len(created) || page.Total != len(created) {
+			t.Fatalf("esperava %d tarefas, obteve %d de %d", len(created), len(page.Tasks), page.Total)
+		}
+		for i := range created {
+			assertTask(t, page.Tasks[i], created[i])
+		}
+	})
+
+	t.Run("List com filtros", func(t *testing.T) {
+		repo := newRepo(t)
+		ids := seedTasks(t, repo)
+
+		day := func(d int) *time.Time {
+			t := baseTime.AddDate(0, 0, d)
+			return &t
+		}
+
+		tests := []struct {
+			name  string
+			query TaskQuery
+			want  []int
+		}{
+			{"status", TaskQuery{Statuses: []models.TaskStatus{models.StatusPending}}, []int{ids[0], ids[3]}},
+			{"vários status", TaskQuery{Statuses: []models.TaskStatus{models.StatusCompleted, models.StatusCancelled}}, []int{ids[2]}},
+			{"busca no título sem diferenciar maiúsculas", TaskQuery{Search: "RELATÓRIO"}, []int{ids[0]}},
+			{"busca na descrição", TaskQuery{Search: "cliente"}, []int{ids[1], ids[3]}},
+			{"busca com curinga literal", TaskQuery{Search: "100%"}, []int{ids[2]}},
+			{"busca acentuada sem diferenciar maiúsculas", TaskQuery{Search: "números"}, []int{ids[0]}},
+			{"criadas a partir de", TaskQuery{Created: TimeRange{From: day(2)}}, []int{ids[2], ids[3]}},
+			{"criadas antes de", TaskQuery{Created: TimeRange{To: day(1)}}, []int{ids[0]}},
+			{"atualizadas no intervalo", TaskQuery{Updated: TimeRange{From: day(4), To: day(6)}}, []int{ids[1], ids[3]}},
+			{"filtros combinados", TaskQuery{Statuses: []models.TaskStatus{models.StatusPending}, Search: "cliente"}, []int{ids[3]}},
+		}
+
+		for _, tt := range tests {
+			page, err := repo.List(tt.query)
+			if err != nil {
+				t.Fatalf("%s: erro ao listar as tarefas: %v", tt.name, err)
+			}
+			if got := taskIDs(page.Tasks); !equalIDs(got, tt.want) || page.Total != len(tt.want) {
+				t.Errorf("%s: esperava %v, obteve %v (total %d)", tt.name, tt.want, got, page.Total)
+			}
+		}
+	})
+
+	t.Run("List com filtros de planejamento", func(t *testing.T) {
+		repo := newRepo(t)
+		ids := seedTasks(t, repo)
+
+		at := func(hours int) *time.Time {
+			t := baseTime.Add(time.Duration(hours) * time.Hour)
+			return &t
+		}
+
+		tests := []struct {
+			name  string
+			query TaskQuery
+			want  []int
+		}{
+			{"responsável", TaskQuery{Assignees: []string{"alice"}}, []int{ids[0], ids[2]}},
+			{"vários responsáveis", TaskQuery{Assignees: []string{"alice", "bob"}}, []int{ids[0], ids[1], ids[2]}},
+			{"rótulo", TaskQuery{Label: "financeiro"}, []int{ids[0], ids[3]}},
+			{"vencidas", TaskQuery{OverdueAt: at(72)}, []int{ids[0], ids[1]}},
+			{"vencidas antes do segundo prazo", TaskQuery{OverdueAt: at(36)}, []int{ids[1]}},
+			{"vencidas de um responsável", TaskQuery{OverdueAt: at(72), Assignees: []string{"alice"}}, []int{ids[0]}},
+		}
+
+		for _, tt := range tests {
+			page, err := repo.List(tt.query)
+			if err != nil {
+				t.Fatalf("%s: erro ao listar as tarefas: %v", tt.name, err)
+			}
+			if got := taskIDs(page.Tasks); !equalIDs(got, tt.want) || page.Total != len(tt.want) {
+				t.Errorf("%s: esperava %v, obteve %v (total %d)", tt.name, tt.want, got, page.Total)
+			}
+		}
+
+		page, _ := repo.List(TaskQuery{Label: "financeiro"})
+		if len(page.Tasks) == 0 || !equalStrings(page.Tasks[0].Labels, []string{"financeiro", "relatório"}) {
+			t.Fatalf("esperava os rótulos carregados na listagem, obteve %+v", page.Tasks)
+		}
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		repo := newRepo(t)
+		seedTasks(t, repo)
+
+		summary, err := repo.Summary(TaskQuery{})
+		if err != nil {
+			t.Fatalf("Erro ao resumir as tarefas: %v", err)
+		}
+		want := &models.TaskSummary{
+			Total: 4,
+			ByStatus: map[models.TaskStatus]int{
+				models.StatusPending: 2, models.StatusInProgress: 1, models.StatusCompleted: 1, models.StatusCancelled: 0,
+			},
+			ByAssignee: map[string]int{"alice": 2, "bob": 1},
+			Unassigned: 1,
+			ByLabel:    map[string]int{"financeiro": 2, "relatório": 1, "suporte": 1, "comercial": 1},
+		}
+		if !reflect.DeepEqual(summary, want) {
+			t.Fatalf("esperava %+v, obteve %+v", want, summary)
+		}
+
+		if _, err := repo.Summary(TaskQuery{Sort: []SortField{{Field: "senha"}}}); err != ErrInvalidSortField {
+			t.Errorf("esperava ErrInvalidSortField, obteve %v", err)
+		}
+
+		summary, err = repo.Summary(TaskQuery{Assignees: []string{"alice"}, Sort: []SortField{{Field: SortByTitle}}, Limit: 1})
+		if err != nil {
+			t.Fatalf("Erro ao resumir as tarefas: %v", err)
+		}
+		want = &models.TaskSummary{
+			Total: 2,
+			ByStatus: map[models.TaskStatus]int{
+				models.StatusPending: 1, models.StatusInProgress: 0, models.StatusCompleted: 1, models.StatusCancelled: 0,
+			},
+			ByAssignee: map[string]int{"alice": 2},
+			ByLabel:    map[string]int{"financeiro": 1, "relatório": 1},
+		}
+		if !reflect.DeepEqual(summary, want) {
+			t.Fatalf("esperava %+v, obteve %+v", want, summary)
+		}
+	})
+
+	t.Run("List ordenado", func(t *testing.T) {
+		repo := newRepo(t)
+		ids := seedTasks(t, repo)
+
+		tests := []struct {
+			name string
+			sort []SortField
+			want []int
+		}{
+			{"padrão pelo ID", nil, []int{ids[0], ids[1], ids[2], ids[3]}},
+			{"criação decrescente", []SortField{{Field: SortByCreatedAt, Desc: true}}, []int{ids[3], ids[2], ids[1], ids[0]}},
+			{"título", []SortField{{Field: SortByTitle}}, []int{ids[1], ids[2], ids[3], ids[0]}},
+			{"status e atualização decrescente", []SortField{{Field: SortByStatus}, {Field: SortByUpdatedAt, Desc: true}}, []int{ids[2], ids[1], ids[3], ids[0]}},
+			{"prioridade decrescente", []SortField{{Field: SortByPriority, Desc: true}}, []int{ids[1], ids[0], ids[3], ids[2]}},
+			{"prazo, sem prazo por último", []SortField{{Field: SortByDueDate}}, []int{ids[1], ids[2], ids[0], ids[3]}},
+			{"prazo decrescente, sem prazo por último", []SortField{{Field: SortByDueDate, Desc: true}}, []int{ids[0], ids[1], ids[2], ids[3]}},
+		}
+
+		for _, tt := range tests {
+			page, err := repo.List(TaskQuery{Sort: tt.sort})
+			if err != nil {
+				t.Fatalf("%s: erro ao listar as tarefas: %v", tt.name, err)
+			}
+			if got := taskIDs(page.Tasks); !equalIDs(got, tt.want) {
+				t.Errorf("%s: esperava %v, obteve %v", tt.name, tt.want, got)
+			}
+		}
+
+		if _, err := repo.List(TaskQuery{Sort: []SortField{{Field: "description"}}}); !errors.Is(err, ErrInvalidSortField) {
+			t.Fatalf("esperava ErrInvalidSortField, obteve %v", err)
+		}
+	})
+
+	t.Run("List paginado", func(t *testing.T) {
+		repo := newRepo(t)
+		ids := seedTasks(t, repo)
+
+		tests := []struct {
+			name          string
+			limit, offset int
+			want          []int
+		}{
+			{"primeira página", 3, 0, []int{ids[0], ids[1], ids[2]}},
+			{"última página", 3, 3, []int{ids[3]}},
+			{"além do fim", 3, 10, []int{}},
+			{"sem limite a partir do offset", 0, 2, []int{ids[2], ids[3]}},
+		}
+
+		for _, tt := range tests {
+			page, err := repo.List(TaskQuery{Limit: tt.limit, Offset: tt.offset})
+			if err != nil {
+				t.Fatalf("%s: erro ao listar as tarefas: %v", tt.name, err)
+			}
+			if got := taskIDs(page.Tasks); !equalIDs(got, tt.want) || page.Total != len(ids) {
+				t.Errorf("%s: esperava %v de %d, obteve %v de %d", tt.name, tt.want, len(ids), got, page.Total)
+			}
+		}
+	})
+
+	t.Run("Update define e remove CompletedAt", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Concluir"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+
+		stored, _ := repo.GetByID(task.ID)
+		stored.Title = "Concluída"
+		stored.Status = models.StatusCompleted
+		if err := repo.Update(task.ID, stored, "alice"); err != nil {
+			t.Fatalf("Erro ao atualizar a tarefa: %v", err)
+		}
+		if stored.CompletedAt == nil || stored.UpdatedAt.Before(task.UpdatedAt) {
+			t.Fatalf("esperava CompletedAt e UpdatedAt atualizados, obteve %+v", stored)
+		}
+
+		got, err := repo.GetByID(task.ID)
+		if err != nil {
+			t.Fatalf("Erro ao buscar a tarefa: %v", err)
+		}
+		assertTask(t, got, stored)
+
+		// Uma nova atualização da tarefa concluída mantém a data de conclusão
+		completedAt := *got.CompletedAt
+		got.Description = "Com descrição"
+		if err := repo.Update(task.ID, got, "alice"); err != nil {
+			t.Fatalf("Erro ao atualizar a tarefa: %v", err)
+		}
+		if got, _ = repo.GetByID(task.ID); got.CompletedAt == nil || !sameTime(*got.CompletedAt, completedAt) {
+			t.Fatalf("esperava CompletedAt %v, obteve %v", completedAt, got.CompletedAt)
+		}
+
+		got.Status = models.StatusInProgress
+		if err := repo.Update(task.ID, got, "alice"); err != nil {
+			t.Fatalf("Erro ao atualizar a tarefa: %v", err)
+		}
+		if got, _ = repo.GetByID(task.ID); got.CompletedAt != nil || got.Status != models.StatusInProgress {
+			t.Fatalf("esperava a tarefa reaberta sem CompletedAt, obteve %+v", got)
+		}
+	})
+
+	t.Run("Update inexistente", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Fantasma"})
+		if err := repo.Update(42, task, "alice"); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound, obteve %v", err)
+		}
+	})
+
+	t.Run("Alterações fora do repositório não são gravadas", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Original"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+
+		task.Title = "Alterada após criar"
+		got, _ := repo.GetByID(task.ID)
+		got.Title = "Alterada após buscar"
+
+		if got, _ = repo.GetByID(task.ID); got.Title != "Original" {
+			t.Fatalf("esperava o título Original, obteve %q", got.Title)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Remover"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+
+		if err := repo.Delete(task.ID); err != nil {
+			t.Fatalf("Erro ao remover a tarefa: %v", err)
+		}
+		if _, err := repo.GetByID(task.ID); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound após remover, obteve %v", err)
+		}
+		if err := repo.Delete(task.ID); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound ao remover de novo, obteve %v", err)
+		}
+		if _, err := repo.History(task.ID); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound no histórico após remover, obteve %v", err)
+		}
+	})
+
+	t.Run("Transições de status e histórico", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Acompanhar"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+
+		steps := []struct {
+			status models.TaskStatus
+			by     string
+		}{
+			{models.StatusInProgress, "bob"},
+			{models.StatusInProgress, "bob"}, // Sem mudança de status, nada é registrado
+			{models.StatusCancelled, "carol"},
+		}
+		for _, step := range steps {
+			stored, _ := repo.GetByID(task.ID)
+			stored.Status = step.status
+			if err := repo.Update(task.ID, stored, step.by); err != nil {
+				t.Fatalf("Erro ao mudar para %s: %v", step.status, err)
+			}
+		}
+
+		// Uma tarefa cancelada não pode ser concluída, e a tentativa não altera nada
+		stored, _ := repo.GetByID(task.ID)
+		stored.Title = "Alterada"
+		stored.Status = models.StatusCompleted
+		var transitionErr *models.TransitionError
+		if err := repo.Update(task.ID, stored, "dave"); !errors.As(err, &transitionErr) {
+			t.Fatalf("esperava TransitionError, obteve %v", err)
+		}
+		if transitionErr.From != models.StatusCancelled || transitionErr.To != models.StatusCompleted {
+			t.Fatalf("transição rejeitada inesperada: %+v", transitionErr)
+		}
+		if got, _ := repo.GetByID(task.ID); got.Status != models.StatusCancelled || got.Title != "Acompanhar" {
+			t.Fatalf("esperava a tarefa inalterada, obteve %+v", got)
+		}
+
+		history, err := repo.History(task.ID)
+		if err != nil {
+			t.Fatalf("Erro ao buscar o histórico: %v", err)
+		}
+		want := []models.StatusTransition{
+			{To: models.StatusPending, By: "alice"},
+			{From: models.StatusPending, To: models.StatusInProgress, By: "bob"},
+			{From: models.StatusInProgress, To: models.StatusCancelled, By: "carol"},
+		}
+		if len(history) != len(want) {
+			t.Fatalf("esperava %d entradas no histórico, obteve %+v", len(want), history)
+		}
+		for i := range want {
+			if history[i].From != want[i].From || history[i].To != want[i].To || history[i].By != want[i].By {
+				t.Fatalf("entrada %d: esperava %+v, obteve %+v", i, want[i], history[i])
+			}
+			if i > 0 && history[i].At.Before(history[i-1].At) {
+				t.Fatalf("histórico fora de ordem: %+v", history)
+			}
+		}
+		if !sameTime(history[0].At, task.CreatedAt) {
+			t.Fatalf("esperava a criação em %v, obteve %v", task.CreatedAt, history[0].At)
+		}
+	})
+
+	t.Run("Update com versão desatualizada", func(t *testing.T) {
+		repo := newRepo(t)
+		task := models.NewTask(models.TaskInput{Title: "Disputada"})
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+		if task.Version != 1 {
+			t.Fatalf("esperava a versão 1 na criação, obteve %d", task.Version)
+		}
+
+		// Dois editores leem a mesma versão; só o primeiro a gravar vence
+		first, _ := repo.GetByID(task.ID)
+		second, _ := repo.GetByID(task.ID)
+
+		first.Title = "Primeiro editor"
+		if err := repo.Update(task.ID, first, "alice"); err != nil {
+			t.Fatalf("Erro ao atualizar a tarefa: %v", err)
+		}
+		if first.Version != 2 {
+			t.Fatalf("esperava a versão 2 após atualizar, obteve %d", first.Version)
+		}
+
+		second.Title = "Segundo editor"
+		second.Status = models.StatusInProgress
+		if err := repo.Update(task.ID, second, "bob"); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("esperava ErrVersionConflict, obteve %v", err)
+		}
+
+		got, _ := repo.GetByID(task.ID)
+		assertTask(t, got, first)
+		if history, _ := repo.History(task.ID); len(history) != 1 {
+			t.Fatalf("esperava apenas a criação no histórico, obteve %+v", history)
+		}
+	})
+
+	t.Run("History inexistente", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.History(42); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("esperava ErrTaskNotFound, obteve %v", err)
+		}
+	})
+}
+
+// baseTime é a data de referência das tarefas criadas por seedTasks
+var baseTime = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// seedTasks cria quatro tarefas com datas fixas, a partir de baseTime, e retorna os IDs
+// na ordem de criação
+func seedTasks(t *testing.T, repo TaskRepository) []int {
+	t.Helper()
+
+	seeds := []struct {
+		title, description string
+		status             models.TaskStatus
+		created, updated   int // Dias após baseTime
+		priority           models.TaskPriority
+		due                int // Dias após baseTime; zero para tarefas sem prazo
+		assignee           string
+		labels             []string
+	}{
+		{"Relatório mensal", "Fechar os NÚMEROS", models.StatusPending, 0, 3, models.PriorityHigh, 2, "alice", []string{"Financeiro", "relatório"}},
+		{"Atender chamado", "Cliente sem acesso", models.StatusInProgress, 1, 5, models.PriorityUrgent, 1, "bob", []string{"suporte"}},
+		{"Backup", "Cobertura de 100% dos dados", models.StatusCompleted, 2, 2, models.PriorityLow, 1, "alice", nil},
+		{"Proposta", "Enviar ao cliente", models.StatusPending, 3, 4, models.PriorityMedium, 0, "", []string{"comercial", "financeiro"}},
+	}
+
+	ids := make([]int, 0, len(seeds))
+	for _, seed := range seeds {
+		task := &models.Task{
+			Title:       seed.title,
+			Description: seed.description,
+			Status:      seed.status,
+			Priority:    seed.priority,
+			Assignee:    seed.assignee,
+			Labels:      seed.labels,
+			CreatedAt:   baseTime.AddDate(0, 0, seed.created),
+			UpdatedAt:   baseTime.AddDate(0, 0, seed.updated),
+		}
+		if seed.due != 0 {
+			due := baseTime.AddDate(0, 0, seed.due)
+			task.DueDate = &due
+		}
+		if err := repo.Create(task, "alice"); err != nil {
+			t.Fatalf("Erro ao criar a tarefa: %v", err)
+		}
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// taskIDs retorna os IDs das tarefas, na ordem recebida
+func taskIDs(tasks []*models.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// equalStrings compara duas listas de textos, incluindo a ordem
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalIDs compara duas listas de IDs, incluindo a ordem
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// assertTask compara as tarefas campo a campo; as datas com a precisão do PostgreSQL
+func assertTask(t *testing.T, got, want *models.Task) {
+	t.Helper()
+
+	if got.ID != want.ID || got.Title != want.Title || got.Description != want.Description || got.Status != want.Status || got.Version != want.Version ||
+		got.Priority != want.Priority || got.Assignee != want.Assignee || !equalStrings(got.Labels, want.Labels) {
+		t.Fatalf("esperava %+v, obteve %+v", want, got)
+	}
+	if (got.DueDate == nil) != (want.DueDate == nil) || (got.DueDate != nil && !sameTime(*got.DueDate, *want.DueDate)) {
+		t.Fatalf("DueDate diferente: esperava %v, obteve %v", want.DueDate, got.DueDate)
+	}
+	if !sameTime(got.CreatedAt, want.CreatedAt) || !sameTime(got.UpdatedAt, want.UpdatedAt) {
+		t.Fatalf("datas diferentes: esperava %v/%v, obteve %v/%v", want.CreatedAt, want.UpdatedAt, got.CreatedAt, got.UpdatedAt)
+	}
+	if (got.CompletedAt == nil) != (want.CompletedAt == nil) ||
+		(got.CompletedAt != nil && !sameTime(*got.CompletedAt, *want.CompletedAt)) {
+		t.Fatalf("CompletedAt diferente: esperava %v, obteve %v", want.CompletedAt, got.CompletedAt)
+	}
+}
+
+// sameTime compara instantes com tolerância de um microssegundo, a precisão do PostgreSQL
+func sameTime(a, b time.Time) bool {
+	diff := a.Sub(b)
+	return diff < time.Microsecond && diff > -time.Microsecond
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"app14/internal/models"
+)
+
+// taskETag retorna o ETag forte da tarefa, derivado da sua versão
+func taskETag(task *models.Task) string {
+	return `"` + strconv.Itoa(task.Version) + `"`
+}
+
+// setTaskETag envia o ETag da versão atual da tarefa
+func setTaskETag(w http.ResponseWriter, task *models.Task) {
+	w.Header().Set("ETag", taskETag(task))
+}
+
+// checkIfMatch exige o cabeçalho If-Match e verifica se ele corresponde à versão atual da
+// tarefa, respondendo 428 sem ele e 412 se estiver desatualizado. Retorna false quando já
+// respondeu. ETags fracos (W/) nunca correspondem, como manda a comparação forte da RFC 9110.
+func checkIfMatch(w http.ResponseWriter, r *http.Request, task *models.Task) bool {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		RespondWithError(w, http.StatusPreconditionRequired, "Cabeçalho If-Match obrigatório; use o ETag retornado por GET /api/tasks/{id}")
+		return false
+	}
+
+	if header == "*" {
+		return true
+	}
+
+	current := taskETag(task)
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimSpace(tag) == current {
+			return true
+		}
+	}
+
+	setTaskETag(w, task)
+	RespondWithError(w, http.StatusPreconditionFailed, "A tarefa foi alterada desde a versão informada em If-Match")
+	return false
+}
@@ -0,0 +1,26 @@
+package handlers
+
+// mergePatch aplica o patch ao documento JSON de target seguindo a RFC 7396 (JSON Merge
+// Patch): membros com null são removidos, objetos são mesclados recursivamente e qualquer
+// outro valor substitui o anterior. Os documentos são os valores de encoding/json.
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+
+	return targetObject
+}
@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +13,22 @@ import (
 	"app14/internal/models"
 )
 
+// actorHeader identifica quem faz a requisição, registrado no histórico de status.
+// A API não tem autenticação; sem o cabeçalho, a mudança é atribuída a anonymousActor.
+const (
+	actorHeader    = "X-User"
+	anonymousActor = "anonymous"
+)
+
+// mergePatchContentType é o tipo de mídia do JSON Merge Patch aceito por PATCH
+const mergePatchContentType = "application/merge-patch+json"
+
+// TaskHistory é a resposta do histórico de status de uma tarefa
+type TaskHistory struct {
+	TaskID int                       `json:"task_id"`
+	Items  []models.StatusTransition `json:"items"`
+}
+
 // TaskHandler contém os handlers para a API de tarefas
 type TaskHandler struct {
 	repo database.TaskRepository
@@ -22,17 +41,50 @@ func NewTaskHandler(repo database.TaskRepository) *TaskHandler {
 	}
 }
 
-// GetTasks retorna todas as tarefas
+// GetTasks retorna uma página das tarefas, com filtros e ordenação pelos parâmetros da URL.
+// O corpo é a lista das tarefas da página; o total e os links das outras páginas vão nos
+// cabeçalhos X-Total-Count e Link.
 func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
-	tasks, err := h.repo.GetAll()
+	query, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.repo.List(query)
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	tasks := page.Tasks
+	if tasks == nil {
+		tasks = make([]*models.Task, 0)
+	}
+
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+	w.Header().Set("Link", pageLinks(r.URL, query, page.Total))
 	RespondWithJSON(w, http.StatusOK, tasks)
 }
 
+// GetTaskSummary retorna as contagens de tarefas por status, responsável e rótulo,
+// com os mesmos filtros da listagem
+func (h *TaskHandler) GetTaskSummary(w http.ResponseWriter, r *http.Request) {
+	query, err := parseTaskQuery(r.URL.Query())
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	summary, err := h.repo.Summary(query)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, summary)
+}
+
 // GetTask retorna uma tarefa específica pelo ID
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	id, err := getTaskIDFromURL(r.URL.Path)
@@ -51,6 +103,7 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setTaskETag(w, task)
 	RespondWithJSON(w, http.StatusOK, task)
 }
 
@@ -68,20 +121,91 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task := models.NewTask(input)
-	if err := h.repo.Create(task); err != nil {
+	if err := h.repo.Create(task, requestActor(r)); err != nil {
 		RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	setTaskETag(w, task)
 	RespondWithJSON(w, http.StatusCreated, task)
 }
 
-// UpdateTask atualiza uma tarefa existente
+// UpdateTask substitui os campos editáveis de uma tarefa existente. Exige If-Match com o ETag da versão atual.
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	existingTask, ok := h.loadForUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	// Decodificar os dados da requisição
+	var input models.TaskInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Dados inválidos")
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Atualizar os campos da tarefa
+	existingTask.Apply(input)
+
+	h.saveTask(w, r, existingTask)
+}
+
+// PatchTask altera parte de uma tarefa com um JSON Merge Patch (RFC 7396) aplicado aos
+// campos editáveis. Exige If-Match com o ETag da versão atual.
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" && !isMergePatchContentType(contentType) {
+		w.Header().Set("Accept-Patch", mergePatchContentType)
+		RespondWithError(w, http.StatusUnsupportedMediaType, "Use Content-Type "+mergePatchContentType)
+		return
+	}
+
+	existingTask, ok := h.loadForUpdate(w, r)
+	if !ok {
+		return
+	}
+
+	var patch interface{}
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Dados inválidos")
+		return
+	}
+	if _, isObject := patch.(map[string]interface{}); !isObject {
+		RespondWithError(w, http.StatusBadRequest, "O patch deve ser um objeto JSON")
+		return
+	}
+
+	// Aplicar o patch ao documento com os campos editáveis da tarefa
+	input, err := patchTaskInput(existingTask, patch)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.Status == "" || input.Priority == "" {
+		RespondWithError(w, http.StatusBadRequest, "o status e a prioridade da tarefa não podem ser removidos")
+		return
+	}
+	if err := input.Validate(); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	existingTask.Apply(input)
+
+	h.saveTask(w, r, existingTask)
+}
+
+// loadForUpdate busca a tarefa da URL e verifica o If-Match da requisição.
+// Retorna false quando já respondeu com o erro.
+func (h *TaskHandler) loadForUpdate(w http.ResponseWriter, r *http.Request) (*models.Task, bool) {
 	id, err := getTaskIDFromURL(r.URL.Path)
 	if err != nil {
 		RespondWithError(w, http.StatusBadRequest, "ID inválido")
-		return
+		return nil, false
 	}
 
 	// Verificar se a tarefa existe
@@ -89,38 +213,101 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		if err == database.ErrTaskNotFound {
 			RespondWithError(w, http.StatusNotFound, "Tarefa não encontrada")
-			return
+			return nil, false
 		}
 		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+
+	if !checkIfMatch(w, r, existingTask) {
+		return nil, false
+	}
+	return existingTask, true
+}
+
+// saveTask grava a tarefa alterada e responde com a nova versão. O repositório rejeita
+// mudanças de status não permitidas (409) e gravações sobre uma versão já alterada por
+// outra requisição depois do If-Match (412).
+func (h *TaskHandler) saveTask(w http.ResponseWriter, r *http.Request, task *models.Task) {
+	if err := h.repo.Update(task.ID, task, requestActor(r)); err != nil {
+		var transitionErr *models.TransitionError
+		switch {
+		case errors.As(err, &transitionErr):
+			RespondWithError(w, http.StatusConflict, err.Error())
+		case err == database.ErrVersionConflict:
+			RespondWithError(w, http.StatusPreconditionFailed, "A tarefa foi alterada desde a versão informada em If-Match")
+		case err == database.ErrTaskNotFound:
+			RespondWithError(w, http.StatusNotFound, "Tarefa não encontrada")
+		default:
+			RespondWithError(w, http.StatusInternalServerError, err.Error())
+		}
 		return
 	}
 
-	// Decodificar os dados da requisição
+	setTaskETag(w, task)
+	RespondWithJSON(w, http.StatusOK, task)
+}
+
+// patchTaskInput aplica o merge patch aos campos editáveis da tarefa. Campos desconhecidos
+// ou somente leitura, como id e version, são rejeitados.
+func patchTaskInput(task *models.Task, patch interface{}) (models.TaskInput, error) {
 	var input models.TaskInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		RespondWithError(w, http.StatusBadRequest, "Dados inválidos")
-		return
+
+	current, err := json.Marshal(models.TaskInput{
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      task.Status,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		Assignee:    task.Assignee,
+		Labels:      task.Labels,
+	})
+	if err != nil {
+		return input, err
+	}
+	var document interface{}
+	if err := json.Unmarshal(current, &document); err != nil {
+		return input, err
 	}
 
-	if err := input.Validate(); err != nil {
-		RespondWithError(w, http.StatusBadRequest, err.Error())
-		return
+	patched, err := json.Marshal(mergePatch(document, patch))
+	if err != nil {
+		return input, err
 	}
 
-	// Atualizar os campos da tarefa
-	existingTask.Title = input.Title
-	existingTask.Description = input.Description
-	if input.Status != "" {
-		existingTask.Status = input.Status
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&input); err != nil {
+		return input, errors.New("patch inválido: apenas title, description, status, priority, due_date, assignee e labels podem ser alterados, com os tipos corretos")
 	}
+	return input, nil
+}
+
+// isMergePatchContentType verifica se o tipo do corpo é JSON Merge Patch ou JSON
+func isMergePatchContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == mergePatchContentType || mediaType == "application/json")
+}
 
-	// Salvar as alterações
-	if err := h.repo.Update(id, existingTask); err != nil {
+// GetTaskHistory retorna as mudanças de status de uma tarefa, da mais antiga para a mais recente
+func (h *TaskHandler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := getTaskIDFromURL(strings.TrimSuffix(r.URL.Path, "/history"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "ID inválido")
+		return
+	}
+
+	history, err := h.repo.History(id)
+	if err != nil {
+		if err == database.ErrTaskNotFound {
+			RespondWithError(w, http.StatusNotFound, "Tarefa não encontrada")
+			return
+		}
 		RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	RespondWithJSON(w, http.StatusOK, existingTask)
+	RespondWithJSON(w, http.StatusOK, TaskHistory{TaskID: id, Items: history})
 }
 
 // DeleteTask deleta uma tarefa
@@ -143,6 +330,14 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// requestActor retorna quem faz a requisição, pelo cabeçalho actorHeader
+func requestActor(r *http.Request) string {
+	if actor := strings.TrimSpace(r.Header.Get(actorHeader)); actor != "" {
+		return actor
+	}
+	return anonymousActor
+}
+
 // getTaskIDFromURL extrai o ID da tarefa da URL
 func getTaskIDFromURL(path string) (int, error) {
 	parts := strings.Split(path, "/")
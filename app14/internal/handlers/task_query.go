@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"app14/internal/database"
+	"app14/internal/models"
+)
+
+// Limites da paginação da listagem de tarefas
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// dateLayout é o formato das datas sem horário aceitas nos filtros, interpretadas em UTC
+const dateLayout = "2006-01-02"
+
+// totalCountHeader informa na listagem o total de tarefas que atendem aos filtros
+const totalCountHeader = "X-Total-Count"
+
+// parseTaskQuery converte os parâmetros da URL na consulta ao repositório:
+//
+//	status=pending,in_progress            um ou mais status
+//	q=texto                               busca no título e na descrição
+//	assignee=alice,bob                    um ou mais responsáveis
+//	label=bug                             tarefas com o rótulo
+//	overdue=true                          tarefas vencidas e não concluídas nem canceladas
+//	created_from, created_to              intervalo da criação
+//	updated_from, updated_to              intervalo da última atualização
+//	sort=priority,-due_date               campos, com "-" para ordem decrescente
+//	limit=50&offset=0                     página, com limit de 1 a 100
+//
+// As datas aceitam RFC 3339 ou AAAA-MM-DD. O início é inclusivo; o fim com data sem
+// horário inclui o dia inteiro, e com horário é exclusivo.
+func parseTaskQuery(values url.Values) (database.TaskQuery, error) {
+	query := database.TaskQuery{
+		Search: strings.TrimSpace(values.Get("q")),
+		Label:  strings.ToLower(strings.TrimSpace(values.Get("label"))),
+		Limit:  defaultPageLimit,
+	}
+
+	if raw := values.Get("assignee"); raw != "" {
+		for _, assignee := range strings.Split(raw, ",") {
+			if assignee = strings.TrimSpace(assignee); assignee != "" {
+				query.Assignees = append(query.Assignees, assignee)
+			}
+		}
+	}
+
+	if raw := values.Get("overdue"); raw != "" {
+		overdue, err := strconv.ParseBool(raw)
+		if err != nil {
+			return query, errors.New("overdue deve ser true ou false")
+		}
+		if overdue {
+			now := time.Now()
+			query.OverdueAt = &now
+		}
+	}
+
+	if raw := values.Get("status"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			status := models.TaskStatus(strings.TrimSpace(s))
+			if !status.IsValid() {
+				return query, fmt.Errorf("status inválido: %q", s)
+			}
+			query.Statuses = append(query.Statuses, status)
+		}
+	}
+
+	var err error
+	if query.Created, err = parseTimeRange(values, "created"); err != nil {
+		return query, err
+	}
+	if query.Updated, err = parseTimeRange(values, "updated"); err != nil {
+		return query, err
+	}
+
+	if raw := values.Get("sort"); raw != "" {
+		for _, s := range strings.Split(raw, ",") {
+			field := database.SortField{Field: strings.TrimSpace(s)}
+			if strings.HasPrefix(field.Field, "-") {
+				field.Field = field.Field[1:]
+				field.Desc = true
+			}
+			if !database.IsSortField(field.Field) {
+				return query, fmt.Errorf("campo de ordenação inválido: %q", s)
+			}
+			query.Sort = append(query.Sort, field)
+		}
+	}
+
+	if raw := values.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return query, fmt.Errorf("limit deve ser um número entre 1 e %d", maxPageLimit)
+		}
+		query.Limit = limit
+	}
+
+	if raw := values.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return query, errors.New("offset deve ser um número maior ou igual a zero")
+		}
+		query.Offset = offset
+	}
+
+	return query, nil
+}
+
+// parseTimeRange lê os parâmetros <prefix>_from e <prefix>_to
+func parseTimeRange(values url.Values, prefix string) (database.TimeRange, error) {
+	var rng database.TimeRange
+
+	if raw := values.Get(prefix + "_from"); raw != "" {
+		from, _, err := parseTime(raw)
+		if err != nil {
+			return rng, fmt.Errorf("%s_from inválido: use RFC 3339 ou AAAA-MM-DD", prefix)
+		}
+		rng.From = &from
+	}
+
+	if raw := values.Get(prefix + "_to"); raw != "" {
+		to, dateOnly, err := parseTime(raw)
+		if err != nil {
+			return rng, fmt.Errorf("%s_to inválido: use RFC 3339 ou AAAA-MM-DD", prefix)
+		}
+		// Uma data sem horário inclui o dia inteiro
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		rng.To = &to
+	}
+
+	if rng.From != nil && rng.To != nil && !rng.From.Before(*rng.To) {
+		return rng, fmt.Errorf("%s_from deve ser anterior a %s_to", prefix, prefix)
+	}
+
+	return rng, nil
+}
+
+// parseTime lê uma data RFC 3339 ou AAAA-MM-DD e indica se ela veio sem horário
+func parseTime(raw string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, raw); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	return t, false, err
+}
+
+// pageLinks monta o cabeçalho Link da listagem, com as páginas first, prev, next e last.
+// Os demais parâmetros da URL são mantidos; apenas limit e offset mudam.
+func pageLinks(u *url.URL, query database.TaskQuery, total int) string {
+	link := func(offset int, rel string) string {
+		values := u.Query()
+		values.Set("limit", strconv.Itoa(query.Limit))
+		values.Set("offset", strconv.Itoa(offset))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, u.Path, values.Encode(), rel)
+	}
+
+	links := []string{link(0, "first")}
+	if query.Offset > 0 {
+		prev := query.Offset - query.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		links = append(links, link(prev, "prev"))
+	}
+	if query.Offset+query.Limit < total {
+		links = append(links, link(query.Offset+query.Limit, "next"))
+	}
+	if total > 0 {
+		links = append(links, link((total-1)/query.Limit*query.Limit, "last"))
+	}
+	return strings.Join(links, ", ")
+}
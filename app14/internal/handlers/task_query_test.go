@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"app14/internal/database"
+	"app14/internal/models"
+)
+
+func TestParseTaskQuery(t *testing.T) {
+	values, _ := url.ParseQuery("status=pending,in_progress&q=+relatório+&created_from=2024-03-01&created_to=2024-03-31" +
+		"&updated_to=2024-03-10T15:00:00-03:00&sort=created_at,-updated_at&limit=10&offset=20")
+
+	query, err := parseTaskQuery(values)
+	if err != nil {
+		t.Fatalf("Erro ao ler os parâmetros: %v", err)
+	}
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC) // O dia final é inclusivo
+	updatedTo := time.Date(2024, 3, 10, 18, 0, 0, 0, time.UTC)
+
+	want := database.TaskQuery{
+		Statuses: []models.TaskStatus{models.StatusPending, models.StatusInProgress},
+		Search:   "relatório",
+		Created:  database.TimeRange{From: &from, To: &to},
+		Sort:     []database.SortField{{Field: "created_at"}, {Field: "updated_at", Desc: true}},
+		Limit:    10,
+		Offset:   20,
+	}
+	if query.Updated.From != nil || query.Updated.To == nil || !query.Updated.To.Equal(updatedTo) {
+		t.Fatalf("esperava updated_to %v, obteve %+v", updatedTo, query.Updated)
+	}
+	query.Updated = database.TimeRange{}
+	if !query.Created.From.Equal(from) || !query.Created.To.Equal(to) {
+		t.Fatalf("esperava o intervalo de criação %v a %v, obteve %v a %v", from, to, query.Created.From, query.Created.To)
+	}
+	query.Created, want.Created = database.TimeRange{}, database.TimeRange{}
+	if !reflect.DeepEqual(query, want) {
+		t.Fatalf("esperava %+v, obteve %+v", want, query)
+	}
+}
+
+func TestParseTaskQueryDefaults(t *testing.T) {
+	query, err := parseTaskQuery(url.Values{})
+	if err != nil {
+		t.Fatalf("Erro ao ler os parâmetros: %v", err)
+	}
+	if !reflect.DeepEqual(query, database.TaskQuery{Limit: defaultPageLimit}) {
+		t.Fatalf("esperava apenas o limite padrão, obteve %+v", query)
+	}
+}
+
+func TestParseTaskQueryPlanning(t *testing.T) {
+	values, _ := url.ParseQuery("assignee=alice,+bob,&label=+Bug+&overdue=true&sort=priority,-due_date")
+
+	before := time.Now()
+	query, err := parseTaskQuery(values)
+	if err != nil {
+		t.Fatalf("Erro ao ler os parâmetros: %v", err)
+	}
+	if query.OverdueAt == nil || query.OverdueAt.Before(before) {
+		t.Fatalf("esperava OverdueAt no instante atual, obteve %v", query.OverdueAt)
+	}
+	query.OverdueAt = nil
+
+	want := database.TaskQuery{
+		Assignees: []string{"alice", "bob"},
+		Label:     "bug",
+		Sort:      []database.SortField{{Field: "priority"}, {Field: "due_date", Desc: true}},
+		Limit:     defaultPageLimit,
+	}
+	if !reflect.DeepEqual(query, want) {
+		t.Fatalf("esperava %+v, obteve %+v", want, query)
+	}
+
+	values, _ = url.ParseQuery("overdue=false")
+	if query, err := parseTaskQuery(values); err != nil || query.OverdueAt != nil {
+		t.Fatalf("overdue=false não deveria filtrar: %+v, %v", query, err)
+	}
+}
+
+func TestParseTaskQueryInvalid(t *testing.T) {
+	tests := []string{
+		"status=done",
+		"sort=description",
+		"sort=-",
+		"limit=0",
+		"limit=101",
+		"limit=dez",
+		"offset=-1",
+		"overdue=talvez",
+		"created_from=01/03/2024",
+		"updated_to=ontem",
+		"created_from=2024-03-02&created_to=2024-03-01",
+	}
+
+	for _, raw := range tests {
+		values, _ := url.ParseQuery(raw)
+		if _, err := parseTaskQuery(values); err == nil {
+			t.Errorf("%s: esperava erro", raw)
+		}
+	}
+}
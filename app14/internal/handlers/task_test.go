@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"app14/internal/database"
+	"app14/internal/models"
+)
+
+// serve executa a requisição no handler, com os cabeçalhos informados em pares nome, valor
+func serve(handler http.HandlerFunc, method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	handler(rec, req)
+	return rec
+}
+
+// decodeTask lê a tarefa do corpo da resposta
+func decodeTask(t *testing.T, rec *httptest.ResponseRecorder) models.Task {
+	t.Helper()
+	var task models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &task); err != nil {
+		t.Fatalf("Resposta JSON inválida: %v: %s", err, rec.Body)
+	}
+	return task
+}
+
+func TestUpdateRequiresCurrentETag(t *testing.T) {
+	h := NewTaskHandler(database.NewInMemoryTaskRepository())
+
+	rec := serve(h.CreateTask, http.MethodPost, "/api/tasks", `{"title":"Planejar","description":"Sprint"}`)
+	if rec.Code != http.StatusCreated || rec.Header().Get("ETag") != `"1"` {
+		t.Fatalf("esperava 201 com ETag \"1\", obteve %d %q: %s", rec.Code, rec.Header().Get("ETag"), rec.Body)
+	}
+
+	rec = serve(h.GetTask, http.MethodGet, "/api/tasks/1", "")
+	etag := rec.Header().Get("ETag")
+	if rec.Code != http.StatusOK || etag != `"1"` {
+		t.Fatalf("esperava 200 com ETag \"1\", obteve %d %q", rec.Code, etag)
+	}
+
+	// Sem If-Match, PUT e PATCH são recusados
+	if rec = serve(h.UpdateTask, http.MethodPut, "/api/tasks/1", `{"title":"Sem versão"}`); rec.Code != http.StatusPreconditionRequired {
+		t.Fatalf("esperava 428 no PUT sem If-Match, obteve %d", rec.Code)
+	}
+	if rec = serve(h.PatchTask, http.MethodPatch, "/api/tasks/1", `{"title":"Sem versão"}`); rec.Code != http.StatusPreconditionRequired {
+		t.Fatalf("esperava 428 no PATCH sem If-Match, obteve %d", rec.Code)
+	}
+
+	// O primeiro editor grava com o ETag lido; o segundo, com o mesmo ETag, recebe 412
+	rec = serve(h.PatchTask, http.MethodPatch, "/api/tasks/1", `{"status":"in_progress","description":null}`,
+		"If-Match", etag, "Content-Type", "application/merge-patch+json")
+	if rec.Code != http.StatusOK || rec.Header().Get("ETag") != `"2"` {
+		t.Fatalf("esperava 200 com ETag \"2\", obteve %d %q: %s", rec.Code, rec.Header().Get("ETag"), rec.Body)
+	}
+	patched := decodeTask(t, rec)
+	if patched.Title != "Planejar" || patched.Description != "" || patched.Status != models.StatusInProgress || patched.Version != 2 {
+		t.Fatalf("tarefa após o patch inesperada: %+v", patched)
+	}
+
+	rec = serve(h.UpdateTask, http.MethodPut, "/api/tasks/1", `{"title":"Segundo editor"}`, "If-Match", etag)
+	if rec.Code != http.StatusPreconditionFailed || rec.Header().Get("ETag") != `"2"` {
+		t.Fatalf("esperava 412 com o ETag atual, obteve %d %q", rec.Code, rec.Header().Get("ETag"))
+	}
+
+	// Com o ETag atual, o PUT é aceito
+	rec = serve(h.UpdateTask, http.MethodPut, "/api/tasks/1", `{"title":"Segundo editor"}`, "If-Match", `"1", "2"`)
+	if rec.Code != http.StatusOK || decodeTask(t, rec).Title != "Segundo editor" {
+		t.Fatalf("esperava 200 no PUT com o ETag atual, obteve %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestPatchTaskInvalid(t *testing.T) {
+	h := NewTaskHandler(database.NewInMemoryTaskRepository())
+	serve(h.CreateTask, http.MethodPost, "/api/tasks", `{"title":"Planejar"}`)
+
+	tests := []struct {
+		name, body, contentType string
+		status                  int
+	}{
+		{"campo somente leitura", `{"version":5}`, "application/merge-patch+json", http.StatusBadRequest},
+		{"tipo errado", `{"title":5}`, "application/merge-patch+json", http.StatusBadRequest},
+		{"título removido", `{"title":null}`, "application/merge-patch+json", http.StatusBadRequest},
+		{"status removido", `{"status":null}`, "application/merge-patch+json", http.StatusBadRequest},
+		{"patch que não é objeto", `["title"]`, "application/merge-patch+json", http.StatusBadRequest},
+		{"tipo de mídia", `{"title":"Outro"}`, "text/plain", http.StatusUnsupportedMediaType},
+		{"cancelar", `{"status":"cancelled"}`, "application/json", http.StatusOK},
+		{"transição proibida após cancelar", `{"status":"completed"}`, "application/json", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		etag := serve(h.GetTask, http.MethodGet, "/api/tasks/1", "").Header().Get("ETag")
+		rec := serve(h.PatchTask, http.MethodPatch, "/api/tasks/1", tt.body, "If-Match", etag, "Content-Type", tt.contentType)
+		if rec.Code != tt.status {
+			t.Errorf("%s: esperava %d, obteve %d: %s", tt.name, tt.status, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestGetTasksPagination(t *testing.T) {
+	h := NewTaskHandler(database.NewInMemoryTaskRepository())
+	for _, title := range []string{"Um", "Dois", "Três", "Quatro", "Cinco"} {
+		if rec := serve(h.CreateTask, http.MethodPost, "/api/tasks", `{"title":"`+title+`"}`); rec.Code != http.StatusCreated {
+			t.Fatalf("esperava 201 ao criar a tarefa, obteve %d: %s", rec.Code, rec.Body)
+		}
+	}
+
+	// O corpo continua sendo a lista das tarefas; o total e as páginas vão nos cabeçalhos
+	rec := serve(h.GetTasks, http.MethodGet, "/api/tasks?status=pending&limit=2&offset=2", "")
+	if rec.Code != http.StatusOK || rec.Header().Get("X-Total-Count") != "5" {
+		t.Fatalf("esperava 200 com X-Total-Count 5, obteve %d %q: %s", rec.Code, rec.Header().Get("X-Total-Count"), rec.Body)
+	}
+	var tasks []models.Task
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("esperava uma lista JSON: %v: %s", err, rec.Body)
+	}
+	if len(tasks) != 2 || tasks[0].ID != 3 || tasks[1].ID != 4 {
+		t.Fatalf("esperava as tarefas 3 e 4, obteve %+v", tasks)
+	}
+
+	want := `</api/tasks?limit=2&offset=0&status=pending>; rel="first", ` +
+		`</api/tasks?limit=2&offset=0&status=pending>; rel="prev", ` +
+		`</api/tasks?limit=2&offset=4&status=pending>; rel="next", ` +
+		`</api/tasks?limit=2&offset=4&status=pending>; rel="last"`
+	if link := rec.Header().Get("Link"); link != want {
+		t.Errorf("esperava Link %s, obteve %s", want, link)
+	}
+
+	// Sem resultados, a lista vem vazia, e não null
+	rec = serve(h.GetTasks, http.MethodGet, "/api/tasks?status=completed", "")
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != "[]" || rec.Header().Get("X-Total-Count") != "0" {
+		t.Fatalf("esperava lista vazia com total 0, obteve %d %q: %s", rec.Code, rec.Header().Get("X-Total-Count"), rec.Body)
+	}
+}
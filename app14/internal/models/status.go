@@ -0,0 +1,63 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// allowedTransitions define para quais status uma tarefa pode ir a partir de cada status.
+// Tarefas concluídas podem ser reabertas para in_progress e canceladas para pending,
+// mas uma tarefa cancelada não pode ser concluída sem antes ser reaberta.
+var allowedTransitions = map[TaskStatus][]TaskStatus{
+	StatusPending:    {StatusInProgress, StatusCompleted, StatusCancelled},
+	StatusInProgress: {StatusPending, StatusCompleted, StatusCancelled},
+	StatusCompleted:  {StatusInProgress},
+	StatusCancelled:  {StatusPending},
+}
+
+// CanTransitionTo verifica se a tarefa pode passar deste status para next. Manter o
+// mesmo status não é uma transição e é sempre permitido.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range allowedTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError indica uma mudança de status não permitida
+type TransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	allowed := make([]string, 0, len(allowedTransitions[e.From]))
+	for _, status := range allowedTransitions[e.From] {
+		allowed = append(allowed, string(status))
+	}
+	return fmt.Sprintf("não é possível mudar o status de %s para %s; a partir de %s são permitidos: %s",
+		e.From, e.To, e.From, strings.Join(allowed, ", "))
+}
+
+// ValidateTransition retorna um *TransitionError se a mudança de from para to não for permitida
+func ValidateTransition(from, to TaskStatus) error {
+	if !from.CanTransitionTo(to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// StatusTransition registra uma mudança de status no histórico da tarefa. Na criação,
+// From fica vazio e To é o status inicial.
+type StatusTransition struct {
+	From TaskStatus `json:"from,omitempty"`
+	To   TaskStatus `json:"to"`
+	At   time.Time  `json:"at"`
+	By   string     `json:"by"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to TaskStatus
+		allowed  bool
+	}{
+		{StatusPending, StatusPending, true},
+		{StatusPending, StatusInProgress, true},
+		{StatusPending, StatusCompleted, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusInProgress, StatusPending, true},
+		{StatusInProgress, StatusCompleted, true},
+		{StatusInProgress, StatusCancelled, true},
+		{StatusCompleted, StatusInProgress, true},
+		{StatusCompleted, StatusPending, false},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusCancelled, StatusPending, true},
+		{StatusCancelled, StatusInProgress, false},
+		{StatusCancelled, StatusCompleted, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.allowed {
+			t.Errorf("%s → %s: esperava %v, obteve %v", tt.from, tt.to, tt.allowed, got)
+		}
+
+		err := ValidateTransition(tt.from, tt.to)
+		var transitionErr *TransitionError
+		if tt.allowed != (err == nil) || (err != nil && !errors.As(err, &transitionErr)) {
+			t.Errorf("%s → %s: erro inesperado %v", tt.from, tt.to, err)
+		}
+	}
+}
@@ -2,35 +2,121 @@ package models
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Status da tarefa como tipo enum
 type TaskStatus string
 
 const (
-	StatusPending   TaskStatus = "pending"
+	StatusPending    TaskStatus = "pending"
 	StatusInProgress TaskStatus = "in_progress"
 	StatusCompleted  TaskStatus = "completed"
 	StatusCancelled  TaskStatus = "cancelled"
 )
 
+// IsValid verifica se o status é um dos status conhecidos
+func (s TaskStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusInProgress, StatusCompleted, StatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Prioridade da tarefa como tipo enum
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "low"
+	PriorityMedium TaskPriority = "medium"
+	PriorityHigh   TaskPriority = "high"
+	PriorityUrgent TaskPriority = "urgent"
+)
+
+// Limites dos campos de planejamento
+const (
+	MaxAssigneeLength = 100
+	MaxLabelLength    = 50
+	MaxLabels         = 20
+)
+
+// IsValid verifica se a prioridade é uma das prioridades conhecidas
+func (p TaskPriority) IsValid() bool {
+	return p.Rank() > 0
+}
+
+// Rank retorna a ordem da prioridade, de 1 (low) a 4 (urgent), ou 0 se for desconhecida
+func (p TaskPriority) Rank() int {
+	switch p {
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	case PriorityUrgent:
+		return 4
+	default:
+		return 0
+	}
+}
+
 // Task representa uma tarefa no sistema
 type Task struct {
 	ID          int        `json:"id"`
 	Title       string     `json:"title"`
 	Description string     `json:"description"`
 	Status      TaskStatus `json:"status"`
+	// Version começa em 1 e é incrementada a cada atualização; é o ETag da tarefa
+	Version  int          `json:"version"`
+	Priority TaskPriority `json:"priority"`
+	DueDate  *time.Time   `json:"due_date,omitempty"`
+	// Assignee é o responsável pela tarefa; vazio quando não atribuída
+	Assignee string `json:"assignee,omitempty"`
+	// Labels é um conjunto de rótulos normalizados por NormalizeLabels
+	Labels      []string   `json:"labels"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 }
 
+// IsOverdue verifica se a tarefa passou do prazo em now sem ter sido concluída ou cancelada
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.DueDate != nil && t.DueDate.Before(now) &&
+		t.Status != StatusCompleted && t.Status != StatusCancelled
+}
+
+// Apply copia os campos editáveis de input para a tarefa, substituindo-os. Status e
+// prioridade vazios mantêm os valores atuais.
+func (t *Task) Apply(input TaskInput) {
+	t.Title = input.Title
+	t.Description = input.Description
+	if input.Status != "" {
+		t.Status = input.Status
+	}
+	if input.Priority != "" {
+		t.Priority = input.Priority
+	}
+	t.DueDate = input.DueDate
+	t.Assignee = strings.TrimSpace(input.Assignee)
+	t.Labels = NormalizeLabels(input.Labels)
+}
+
 // TaskInput representa os dados de entrada para criação/atualização de uma tarefa
 type TaskInput struct {
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      TaskStatus `json:"status,omitempty"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status,omitempty"`
+	Priority    TaskPriority `json:"priority,omitempty"`
+	DueDate     *time.Time   `json:"due_date,omitempty"`
+	Assignee    string       `json:"assignee,omitempty"`
+	Labels      []string     `json:"labels,omitempty"`
 }
 
 // Validate valida os dados da tarefa
@@ -39,30 +125,99 @@ func (t *TaskInput) Validate() error {
 		return errors.New("o título da tarefa é obrigatório")
 	}
 
-	if t.Status != "" && 
-	   t.Status != StatusPending && 
-	   t.Status != StatusInProgress && 
-	   t.Status != StatusCompleted && 
-	   t.Status != StatusCancelled {
+	if t.Status != "" && !t.Status.IsValid() {
 		return errors.New("status inválido")
 	}
 
+	if t.Priority != "" && !t.Priority.IsValid() {
+		return errors.New("prioridade inválida: use low, medium, high ou urgent")
+	}
+
+	if utf8.RuneCountInString(strings.TrimSpace(t.Assignee)) > MaxAssigneeLength {
+		return fmt.Errorf("o responsável deve ter no máximo %d caracteres", MaxAssigneeLength)
+	}
+
+	for _, label := range t.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return errors.New("os rótulos não podem ser vazios")
+		}
+		if utf8.RuneCountInString(label) > MaxLabelLength {
+			return fmt.Errorf("os rótulos devem ter no máximo %d caracteres", MaxLabelLength)
+		}
+	}
+	if len(NormalizeLabels(t.Labels)) > MaxLabels {
+		return fmt.Errorf("a tarefa pode ter no máximo %d rótulos", MaxLabels)
+	}
+
 	return nil
 }
 
+// NormalizeLabels transforma os rótulos em um conjunto: sem espaços nas pontas, em
+// minúsculas, sem repetições e em ordem alfabética. Nunca retorna nil.
+func NormalizeLabels(labels []string) []string {
+	seen := make(map[string]bool, len(labels))
+	normalized := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		normalized = append(normalized, label)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
 // NewTask cria uma nova instância de Task a partir de TaskInput
 func NewTask(input TaskInput) *Task {
 	now := time.Now()
-	status := input.Status
-	if status == "" {
-		status = StatusPending
+	task := &Task{
+		Status:    StatusPending,
+		Priority:  PriorityMedium,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	task.Apply(input)
+
+	return task
+}
+
+// TaskSummary conta as tarefas agrupadas por status, responsável e rótulo. Todos os
+// status aparecem, mesmo sem tarefas; tarefas sem responsável são contadas em Unassigned.
+type TaskSummary struct {
+	Total      int                `json:"total"`
+	ByStatus   map[TaskStatus]int `json:"by_status"`
+	ByAssignee map[string]int     `json:"by_assignee"`
+	Unassigned int                `json:"unassigned"`
+	ByLabel    map[string]int     `json:"by_label"`
+}
+
+// NewTaskSummary cria um resumo vazio, com todos os status zerados
+func NewTaskSummary() *TaskSummary {
+	return &TaskSummary{
+		ByStatus: map[TaskStatus]int{
+			StatusPending:    0,
+			StatusInProgress: 0,
+			StatusCompleted:  0,
+			StatusCancelled:  0,
+		},
+		ByAssignee: make(map[string]int),
+		ByLabel:    make(map[string]int),
 	}
+}
 
-	return &Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      status,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+// Add conta a tarefa no resumo
+func (s *TaskSummary) Add(task *Task) {
+	s.Total++
+	s.ByStatus[task.Status]++
+	if task.Assignee == "" {
+		s.Unassigned++
+	} else {
+		s.ByAssignee[task.Assignee]++
 	}
-} 
\ No newline at end of file
+	for _, label := range task.Labels {
+		s.ByLabel[label]++
+	}
+}
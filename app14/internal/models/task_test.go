@@ -0,0 +1,78 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeLabels(t *testing.T) {
+	got := NormalizeLabels([]string{" Bug ", "backend", "bug", "", "API"})
+	if want := []string{"api", "backend", "bug"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("esperava %v, obteve %v", want, got)
+	}
+	if got := NormalizeLabels(nil); got == nil || len(got) != 0 {
+		t.Fatalf("esperava uma lista vazia, obteve %#v", got)
+	}
+}
+
+func TestTaskInputValidatePlanning(t *testing.T) {
+	tooMany := make([]string, MaxLabels+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("x", i+1)
+	}
+
+	tests := []struct {
+		name  string
+		input TaskInput
+		valid bool
+	}{
+		{"campos válidos", TaskInput{Title: "T", Priority: PriorityUrgent, Assignee: "alice", Labels: []string{"bug"}}, true},
+		{"rótulos repetidos contam uma vez", TaskInput{Title: "T", Labels: append(tooMany[:MaxLabels:MaxLabels], "X")}, true},
+		{"prioridade desconhecida", TaskInput{Title: "T", Priority: "critical"}, false},
+		{"responsável longo", TaskInput{Title: "T", Assignee: strings.Repeat("a", MaxAssigneeLength+1)}, false},
+		{"rótulo vazio", TaskInput{Title: "T", Labels: []string{" "}}, false},
+		{"rótulo longo", TaskInput{Title: "T", Labels: []string{strings.Repeat("l", MaxLabelLength+1)}}, false},
+		{"rótulos demais", TaskInput{Title: "T", Labels: tooMany}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.input.Validate(); (err == nil) != tt.valid {
+			t.Errorf("%s: esperava válido=%v, obteve erro %v", tt.name, tt.valid, err)
+		}
+	}
+}
+
+func TestTaskIsOverdue(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		task    Task
+		overdue bool
+	}{
+		{"sem prazo", Task{Status: StatusPending}, false},
+		{"prazo vencido", Task{Status: StatusInProgress, DueDate: &past}, true},
+		{"prazo futuro", Task{Status: StatusPending, DueDate: &future}, false},
+		{"concluída", Task{Status: StatusCompleted, DueDate: &past}, false},
+		{"cancelada", Task{Status: StatusCancelled, DueDate: &past}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.task.IsOverdue(now); got != tt.overdue {
+			t.Errorf("%s: esperava %v, obteve %v", tt.name, tt.overdue, got)
+		}
+	}
+}
+
+func TestNewTaskDefaults(t *testing.T) {
+	task := NewTask(TaskInput{Title: "T", Assignee: "  bob ", Labels: []string{"Ops"}})
+	if task.Status != StatusPending || task.Priority != PriorityMedium {
+		t.Fatalf("esperava pending/medium, obteve %s/%s", task.Status, task.Priority)
+	}
+	if task.Assignee != "bob" || !reflect.DeepEqual(task.Labels, []string{"ops"}) {
+		t.Fatalf("esperava responsável e rótulos normalizados, obteve %q %v", task.Assignee, task.Labels)
+	}
+}
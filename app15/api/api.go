@@ -0,0 +1,10 @@
+// Package api publica a especificação OpenAPI 3 da API do blog. A especificação
+// é a fonte do cliente Go gerado no pacote client e é servida em /api/openapi.json.
+package api
+
+import _ "embed"
+
+// Spec contém o documento OpenAPI da API em JSON
+//
+//go:embed openapi.json
+var Spec []byte
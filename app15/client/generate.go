@@ -0,0 +1,15 @@
+// Package client é o cliente Go da API do blog, gerado a partir da especificação
+// OpenAPI em api/openapi.json. Não edite client.gen.go: altere a especificação e
+// execute go generate ./client.
+//
+// Exemplo:
+//
+//	c, err := client.NewClientWithResponses("http://localhost:8080",
+//		client.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+//			req.Header.Set("Authorization", "Bearer "+token)
+//			return nil
+//		}))
+//	resp, err := c.GetPostWithResponse(ctx, postID)
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.3.0 -config oapi-codegen.yaml ../api/openapi.json
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateCommand lê o comando da diretiva go:generate de generate.go
+func generateCommand(t *testing.T) []string {
+	t.Helper()
+
+	source, err := os.ReadFile("generate.go")
+	if err != nil {
+		t.Fatalf("Erro ao ler generate.go: %v", err)
+	}
+	for _, line := range strings.Split(string(source), "\n") {
+		if directive, ok := strings.CutPrefix(line, "//go:generate "); ok {
+			return strings.Fields(directive)
+		}
+	}
+	t.Fatal("diretiva go:generate não encontrada em generate.go")
+	return nil
+}
+
+// O cliente gerado corresponde à especificação atual: falha quando api/openapi.json
+// foi alterada sem executar go generate ./client
+func TestClientMatchesSpec(t *testing.T) {
+	command := generateCommand(t)
+
+	// Sem acesso ao gerador (por exemplo, sem rede), a verificação não tem como ser feita
+	for _, arg := range command {
+		if pkg, version, ok := strings.Cut(arg, "@"); ok {
+			module, _, _ := strings.Cut(pkg, "/cmd/")
+			if out, err := exec.Command("go", "mod", "download", module+"@"+version).CombinedOutput(); err != nil {
+				t.Skipf("gerador %s indisponível: %v\n%s", arg, err, out)
+			}
+		}
+	}
+
+	// O gerador roda em um diretório temporário, com uma cópia da configuração,
+	// para não sobrescrever o client.gen.go do repositório
+	dir := t.TempDir()
+	config, err := os.ReadFile("oapi-codegen.yaml")
+	if err != nil {
+		t.Fatalf("Erro ao ler a configuração do gerador: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "oapi-codegen.yaml"), config, 0o644); err != nil {
+		t.Fatalf("Erro ao copiar a configuração do gerador: %v", err)
+	}
+	for i, arg := range command {
+		if strings.HasSuffix(arg, ".json") {
+			if command[i], err = filepath.Abs(arg); err != nil {
+				t.Fatalf("Erro ao resolver o caminho da especificação: %v", err)
+			}
+		}
+	}
+
+	// Já baixado e verificado, o gerador é executado a partir do cache de módulos
+	modCache, err := exec.Command("go", "env", "GOMODCACHE").Output()
+	if err != nil {
+		t.Fatalf("Erro ao localizar o cache de módulos: %v", err)
+	}
+	proxy := "file://" + filepath.ToSlash(filepath.Join(strings.TrimSpace(string(modCache)), "cache", "download"))
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOPROXY="+proxy, "GOSUMDB=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Erro ao gerar o cliente: %v\n%s", err, out)
+	}
+
+	generated, err := os.ReadFile(filepath.Join(dir, "client.gen.go"))
+	if err != nil {
+		t.Fatalf("Erro ao ler o cliente gerado: %v", err)
+	}
+	current, err := os.ReadFile("client.gen.go")
+	if err != nil {
+		t.Fatalf("Erro ao ler client.gen.go: %v", err)
+	}
+	if !bytes.Equal(generated, current) {
+		t.Error("client.gen.go está desatualizado em relação a api/openapi.json; execute go generate ./client")
+	}
+}
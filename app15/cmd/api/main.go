@@ -8,25 +8,36 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
-	httpAdapter "app15/internal/adapters/http"
+	httpAdapter "github.com/caiquemiranda/go-apps/app15/internal/adapters/http"
+	"github.com/caiquemiranda/go-apps/app15/internal/config"
 )
 
 func main() {
-	// Inicializar o router
-	router := httpAdapter.SetupRouter()
-	
-	// Definir porta do servidor
-	port := "8080"
-	if envPort := os.Getenv("PORT"); envPort != "" {
-		port = envPort
+	// Carregar a configuração de flags, variáveis de ambiente e .env
+	cfg, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Configuração inválida: %v\n", err)
 	}
 
+	// Contexto das tarefas em segundo plano, cancelado no desligamento
+	appCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	// Inicializar o router
+	router, err := httpAdapter.SetupRouter(appCtx, cfg)
+	if err != nil {
+		log.Fatalf("Erro ao configurar a aplicação: %v\n", err)
+	}
+	
 	// Configurar o servidor HTTP
+	port := cfg.Port
 	server := &http.Server{
-		Addr:    ":" + port,
-		Handler: router,
+		Addr:         ":" + port,
+		Handler:      router,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
 	// Iniciar o servidor em uma goroutine
@@ -43,9 +54,10 @@ func main() {
 	<-quit
 
 	fmt.Println("Desligando o servidor...")
+	stop()
 	
 	// Criar contexto com timeout para desligamento gracioso
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	
 	// Tentar desligar o servidor de forma graciosa
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// AuditRepository implementa a trilha de auditoria em memória
+type AuditRepository struct {
+	entries []*domain.AuditEntry
+	mu      sync.RWMutex
+}
+
+// NewAuditRepository cria uma nova instância da trilha de auditoria em memória
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{
+		entries: make([]*domain.AuditEntry, 0),
+	}
+}
+
+// Create registra uma nova entrada na trilha de auditoria
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+// ReassignUser troca fromID por toID no autor das entradas e no alvo das ações sobre
+// usuários. As entradas alteradas são substituídas por cópias, pois List entrega as originais.
+func (r *AuditRepository) ReassignUser(ctx context.Context, fromID, toID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, entry := range r.entries {
+		if entry.ActorID != fromID && (entry.TargetType != "user" || entry.TargetID != fromID) {
+			continue
+		}
+		copied := *entry
+		if copied.ActorID == fromID {
+			copied.ActorID = toID
+		}
+		if copied.TargetType == "user" && copied.TargetID == fromID {
+			copied.TargetID = toID
+		}
+		r.entries[i] = &copied
+	}
+
+	return nil
+}
+
+// List retorna uma lista paginada de entradas, da mais recente para a mais antiga
+func (r *AuditRepository) List(ctx context.Context, page, pageSize int) ([]*domain.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]*domain.AuditEntry, len(r.entries))
+	copy(entries, r.entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	// Calcular índices de paginação
+	startIndex := (page - 1) * pageSize
+	endIndex := startIndex + pageSize
+
+	if startIndex >= len(entries) {
+		return []*domain.AuditEntry{}, nil
+	}
+
+	if endIndex > len(entries) {
+		endIndex = len(entries)
+	}
+
+	return entries[startIndex:endIndex], nil
+}
@@ -0,0 +1,248 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de comentários
+var (
+	ErrCommentNotFound = errors.New("comentário não encontrado")
+)
+
+// CommentRepository implementa o repositório de comentários em memória
+type CommentRepository struct {
+	comments map[string]*domain.Comment
+	outbox   *OutboxRepository
+	mu       sync.RWMutex
+}
+
+// NewCommentRepository cria uma nova instância do repositório de comentários em memória,
+// que grava os eventos dos comentários na caixa de saída informada
+func NewCommentRepository(outbox *OutboxRepository) *CommentRepository {
+	return &CommentRepository{
+		comments: make(map[string]*domain.Comment),
+		outbox:   outbox,
+	}
+}
+
+// Create adiciona um novo comentário ao repositório, registrando os eventos na caixa
+// de saída antes de liberar o bloqueio
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment, events ...*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.comments[comment.ID] = storedComment(comment)
+	r.outbox.addAll(events)
+
+	return nil
+}
+
+// deleteByPost remove os comentários do post, quando ele é removido, e retorna os seus IDs
+func (r *CommentRepository) deleteByPost(postID string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0)
+	for id, comment := range r.comments {
+		if comment.PostID == postID {
+			delete(r.comments, id)
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// GetByID busca um comentário pelo ID
+func (r *CommentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comment, exists := r.comments[id]
+	if !exists {
+		return nil, ErrCommentNotFound
+	}
+
+	return copyComment(comment), nil
+}
+
+// Update atualiza os dados de um comentário
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Verificar se comentário existe
+	if _, exists := r.comments[comment.ID]; !exists {
+		return ErrCommentNotFound
+	}
+
+	r.comments[comment.ID] = storedComment(comment)
+
+	return nil
+}
+
+// Delete remove um comentário do repositório
+func (r *CommentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Verificar se comentário existe
+	if _, exists := r.comments[id]; !exists {
+		return ErrCommentNotFound
+	}
+
+	delete(r.comments, id)
+
+	return nil
+}
+
+// ReassignAuthor transfere a autoria de todos os comentários de fromID para toID
+func (r *CommentRepository) ReassignAuthor(ctx context.Context, fromID, toID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, comment := range r.comments {
+		if comment.AuthorID == fromID {
+			comment.AuthorID = toID
+		}
+	}
+
+	return nil
+}
+
+// ListByPost retorna uma página de comentários de um post, em ordem cronológica
+func (r *CommentRepository) ListByPost(ctx context.Context, postID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comments := make([]*domain.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.PostID == postID {
+			comments = append(comments, copyComment(comment))
+		}
+	}
+
+	return paginateComments(comments, req), nil
+}
+
+// ListByAuthor retorna uma página de comentários de um autor específico, em ordem cronológica
+func (r *CommentRepository) ListByAuthor(ctx context.Context, authorID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comments := make([]*domain.Comment, 0)
+	for _, comment := range r.comments {
+		if comment.AuthorID == authorID {
+			comments = append(comments, copyComment(comment))
+		}
+	}
+
+	return paginateComments(comments, req), nil
+}
+
+// ListThread retorna a árvore paginada de respostas a partir de parentID
+func (r *CommentRepository) ListThread(ctx context.Context, postID, parentID string, depth int, req domain.PageRequest) (*domain.Page[*domain.CommentThread], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	children := make(map[string][]*domain.Comment)
+	for _, comment := range r.comments {
+		if comment.PostID == postID {
+			children[comment.ParentID] = append(children[comment.ParentID], copyComment(comment))
+		}
+	}
+
+	page := paginateComments(children[parentID], req)
+
+	return &domain.Page[*domain.CommentThread]{
+		Items:      buildThread(children, page.Items, depth, req.Limit),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+		Total:      page.Total,
+	}, nil
+}
+
+// Remove apaga o comentário sem respostas ou o mantém como lápide, sob o mesmo bloqueio
+func (r *CommentRepository) Remove(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comment, exists := r.comments[id]
+	if !exists {
+		return ErrCommentNotFound
+	}
+
+	for _, reply := range r.comments {
+		if reply.ParentID == id {
+			comment.Tombstone()
+			comment.UpdatedAt = at
+			return nil
+		}
+	}
+
+	delete(r.comments, id)
+
+	return nil
+}
+
+// buildThread monta recursivamente a árvore de respostas dos comentários informados.
+// Os níveis aninhados trazem sempre a primeira página de respostas.
+func buildThread(children map[string][]*domain.Comment, replies []*domain.Comment, depth, limit int) []*domain.CommentThread {
+	threads := make([]*domain.CommentThread, 0, len(replies))
+	for _, comment := range replies {
+		thread := &domain.CommentThread{
+			Comment:    comment,
+			ReplyCount: len(children[comment.ID]),
+			Replies:    []*domain.CommentThread{},
+		}
+		if depth > 0 {
+			nested := paginateComments(children[comment.ID], domain.PageRequest{Limit: limit})
+			thread.Replies = buildThread(children, nested.Items, depth-1, limit)
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads
+}
+
+// copyComment copia o comentário, para que quem o recebe não altere o registro armazenado
+// fora do bloqueio do repositório: as alterações só são gravadas por Update
+func copyComment(comment *domain.Comment) *domain.Comment {
+	copied := *comment
+	if comment.Author != nil {
+		author := *comment.Author
+		copied.Author = &author
+	}
+	return &copied
+}
+
+// storedComment copia o comentário para armazenamento, sem o autor e as curtidas, que
+// não são gravados com o comentário
+func storedComment(comment *domain.Comment) *domain.Comment {
+	stored := copyComment(comment)
+	stored.Author = nil
+	stored.Likes = 0
+	return stored
+}
+
+// paginateComments ordena os comentários por data de criação crescente e aplica a paginação
+func paginateComments(comments []*domain.Comment, req domain.PageRequest) *domain.Page[*domain.Comment] {
+	sort.Slice(comments, func(i, j int) bool {
+		if comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].ID < comments[j].ID
+		}
+		return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+	})
+
+	return paginate(comments, req, func(comment *domain.Comment, cursor *domain.Cursor) bool {
+		return cursor.OldestFirst(comment.CreatedAt, comment.ID)
+	}, func(comment *domain.Comment) domain.Cursor {
+		return domain.Cursor{Time: comment.CreatedAt, ID: comment.ID}
+	})
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// LoginAttemptRepository implementa o histórico de logins e os bloqueios em memória
+type LoginAttemptRepository struct {
+	attempts []*domain.LoginAttempt
+	lockouts map[string]*domain.Lockout
+	mu       sync.RWMutex
+}
+
+// NewLoginAttemptRepository cria uma nova instância do repositório de tentativas de login em memória
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts: make([]*domain.LoginAttempt, 0),
+		lockouts: make(map[string]*domain.Lockout),
+	}
+}
+
+// Create registra uma tentativa de login
+func (r *LoginAttemptRepository) Create(ctx context.Context, attempt *domain.LoginAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attempts = append(r.attempts, attempt)
+
+	return nil
+}
+
+// ListByUser retorna uma lista paginada das tentativas de login do usuário, da mais recente para a mais antiga
+func (r *LoginAttemptRepository) ListByUser(ctx context.Context, userID string, page, pageSize int) ([]*domain.LoginAttempt, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attempts := make([]*domain.LoginAttempt, 0)
+	for _, attempt := range r.attempts {
+		if attempt.UserID == userID {
+			attempts = append(attempts, attempt)
+		}
+	}
+
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if attempts[i].CreatedAt.Equal(attempts[j].CreatedAt) {
+			return attempts[i].ID < attempts[j].ID
+		}
+		return attempts[i].CreatedAt.After(attempts[j].CreatedAt)
+	})
+
+	// Calcular índices de paginação
+	startIndex := (page - 1) * pageSize
+	endIndex := startIndex + pageSize
+
+	if startIndex >= len(attempts) {
+		return []*domain.LoginAttempt{}, nil
+	}
+
+	if endIndex > len(attempts) {
+		endIndex = len(attempts)
+	}
+
+	return attempts[startIndex:endIndex], nil
+}
+
+// GetLockout retorna uma cópia do estado de bloqueio da chave
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, key string) (*domain.Lockout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lockout, exists := r.lockouts[key]
+	if !exists {
+		return domain.NewLockout(key), nil
+	}
+
+	copied := *lockout
+	return &copied, nil
+}
+
+// RegisterFailure contabiliza uma falha na chave, com a leitura e a gravação sob o mesmo bloqueio
+func (r *LoginAttemptRepository) RegisterFailure(ctx context.Context, key string, policy domain.BackoffPolicy, now time.Time) (*domain.Lockout, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lockout, exists := r.lockouts[key]
+	if !exists {
+		lockout = domain.NewLockout(key)
+		r.lockouts[key] = lockout
+	}
+	lockout.RegisterFailure(policy, now)
+
+	copied := *lockout
+	return &copied, nil
+}
+
+// DeleteLockout esquece as falhas da chave
+func (r *LoginAttemptRepository) DeleteLockout(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.lockouts, key)
+
+	return nil
+}
+
+// DeleteByUser remove as tentativas de login do usuário e as feitas com o seu email
+func (r *LoginAttemptRepository) DeleteByUser(ctx context.Context, userID, email string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	email = domain.NormalizeEmail(email)
+	kept := make([]*domain.LoginAttempt, 0, len(r.attempts))
+	for _, attempt := range r.attempts {
+		if attempt.UserID != userID && attempt.Email != email {
+			kept = append(kept, attempt)
+		}
+	}
+	r.attempts = kept
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de notificações
+var (
+	ErrNotificationNotFound = errors.New("notificação não encontrada")
+)
+
+// NotificationRepository implementa as caixas de notificações em memória
+type NotificationRepository struct {
+	notifications map[string]*domain.Notification
+	mu            sync.RWMutex
+}
+
+// NewNotificationRepository cria uma nova instância do repositório de notificações em memória
+func NewNotificationRepository() *NotificationRepository {
+	return &NotificationRepository{
+		notifications: make(map[string]*domain.Notification),
+	}
+}
+
+// Create registra uma notificação, ignorando repetições do mesmo evento para o mesmo usuário
+func (r *NotificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.notifications {
+		if existing.UserID == notification.UserID && existing.EventID == notification.EventID {
+			return nil
+		}
+	}
+
+	r.notifications[notification.ID] = notification
+
+	return nil
+}
+
+// ListByUser retorna uma lista paginada das notificações do usuário, da mais recente para a mais antiga
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]*domain.Notification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	notifications := make([]*domain.Notification, 0)
+	for _, notification := range r.notifications {
+		if notification.UserID == userID && (!unreadOnly || !notification.IsRead()) {
+			copied := *notification
+			notifications = append(notifications, &copied)
+		}
+	}
+
+	sort.Slice(notifications, func(i, j int) bool {
+		if notifications[i].CreatedAt.Equal(notifications[j].CreatedAt) {
+			return notifications[i].ID < notifications[j].ID
+		}
+		return notifications[i].CreatedAt.After(notifications[j].CreatedAt)
+	})
+
+	// Calcular índices de paginação
+	startIndex := (page - 1) * pageSize
+	endIndex := startIndex + pageSize
+
+	if startIndex >= len(notifications) {
+		return []*domain.Notification{}, nil
+	}
+
+	if endIndex > len(notifications) {
+		endIndex = len(notifications)
+	}
+
+	return notifications[startIndex:endIndex], nil
+}
+
+// CountUnread retorna a quantidade de notificações não lidas do usuário
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, notification := range r.notifications {
+		if notification.UserID == userID && !notification.IsRead() {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// MarkRead marca como lida uma notificação do usuário
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	notification, exists := r.notifications[id]
+	if !exists || notification.UserID != userID {
+		return ErrNotificationNotFound
+	}
+
+	if !notification.IsRead() {
+		notification.ReadAt = &at
+	}
+
+	return nil
+}
+
+// MarkAllRead marca como lidas todas as notificações do usuário
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, notification := range r.notifications {
+		if notification.UserID == userID && !notification.IsRead() {
+			readAt := at
+			notification.ReadAt = &readAt
+		}
+	}
+
+	return nil
+}
+
+// DeleteByUser remove as notificações recebidas e as causadas pelo usuário
+func (r *NotificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, notification := range r.notifications {
+		if notification.UserID == userID || notification.ActorID == userID {
+			delete(r.notifications, id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de eventos pendentes
+var (
+	ErrEventNotFound = errors.New("evento não encontrado")
+)
+
+// outboxEntry guarda um evento com o estado da sua entrega
+type outboxEntry struct {
+	event       *domain.Event
+	deliveredAt *time.Time
+	attempts    int
+	lastError   string
+}
+
+// OutboxRepository implementa a caixa de saída de eventos em memória
+type OutboxRepository struct {
+	entries map[string]*outboxEntry
+	mu      sync.RWMutex
+}
+
+// NewOutboxRepository cria uma nova instância da caixa de saída de eventos em memória
+func NewOutboxRepository() *OutboxRepository {
+	return &OutboxRepository{
+		entries: make(map[string]*outboxEntry),
+	}
+}
+
+// addAll registra os eventos gravados junto com uma entidade por outro repositório.
+// Os eventos são copiados, para que quem os criou não altere os registros armazenados.
+func (r *OutboxRepository) addAll(events []*domain.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		copied := *event
+		r.entries[event.ID] = &outboxEntry{event: &copied}
+	}
+}
+
+// ListPending retorna os eventos não entregues, do mais antigo para o mais recente
+func (r *OutboxRepository) ListPending(ctx context.Context, maxAttempts, limit int) ([]*domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*domain.Event, 0)
+	for _, entry := range r.entries {
+		if entry.deliveredAt == nil && entry.attempts < maxAttempts {
+			events = append(events, entry.event)
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].OccurredAt.Equal(events[j].OccurredAt) {
+			return events[i].ID < events[j].ID
+		}
+		return events[i].OccurredAt.Before(events[j].OccurredAt)
+	})
+
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+// MarkDelivered marca o evento como entregue
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.entries[id]
+	if !exists {
+		return ErrEventNotFound
+	}
+
+	entry.deliveredAt = &at
+
+	return nil
+}
+
+// MarkFailed registra uma falha de entrega do evento
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.entries[id]
+	if !exists {
+		return ErrEventNotFound
+	}
+
+	entry.attempts++
+	entry.lastError = reason
+
+	return nil
+}
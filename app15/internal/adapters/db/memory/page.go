@@ -0,0 +1,29 @@
+package memory
+
+import (
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// paginate entrega a página solicitada de itens já ordenados. after informa se o item
+// vem depois do cursor na ordenação e cursor gera a posição de um item.
+func paginate[T any](items []T, req domain.PageRequest, after func(T, *domain.Cursor) bool, cursor func(T) domain.Cursor) *domain.Page[T] {
+	startIndex := 0
+	if req.After != nil {
+		for startIndex < len(items) && !after(items[startIndex], req.After) {
+			startIndex++
+		}
+	}
+
+	// Um item além do limite indica que há uma próxima página
+	endIndex := startIndex + req.Limit + 1
+	if endIndex > len(items) {
+		endIndex = len(items)
+	}
+
+	page := domain.NewPage(items[startIndex:endIndex], req.Limit, cursor)
+	if req.WithTotal {
+		page.SetTotal(len(items))
+	}
+
+	return page
+}
@@ -0,0 +1,310 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/db/textsearch"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de posts
+var (
+	ErrPostNotFound = errors.New("post não encontrado")
+)
+
+// PostRepository implementa o repositório de posts em memória
+type PostRepository struct {
+	posts     map[string]*domain.Post
+	index     *textsearch.Index
+	comments  *CommentRepository
+	revisions *RevisionRepository
+	reactions *ReactionRepository
+	outbox    *OutboxRepository
+	mu        sync.RWMutex
+}
+
+// NewPostRepository cria uma nova instância do repositório de posts em memória. Os
+// repositórios informados guardam o que pertence aos posts: comentários, revisões e
+// curtidas, removidos junto com o post, e os eventos gravados na caixa de saída.
+// As curtidas também ordenam a listagem por popularidade.
+func NewPostRepository(comments *CommentRepository, revisions *RevisionRepository, reactions *ReactionRepository, outbox *OutboxRepository) *PostRepository {
+	return &PostRepository{
+		posts:     make(map[string]*domain.Post),
+		index:     textsearch.NewIndex(),
+		comments:  comments,
+		revisions: revisions,
+		reactions: reactions,
+		outbox:    outbox,
+	}
+}
+
+// Create adiciona um novo post ao repositório, registrando os eventos na caixa de saída
+// antes de liberar o bloqueio
+func (r *PostRepository) Create(ctx context.Context, post *domain.Post, events ...*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.posts[post.ID] = storedPost(post)
+	r.indexPost(post)
+	r.outbox.addAll(events)
+
+	return nil
+}
+
+// GetByID busca um post pelo ID
+func (r *PostRepository) GetByID(ctx context.Context, id string) (*domain.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	post, exists := r.posts[id]
+	if !exists {
+		return nil, ErrPostNotFound
+	}
+
+	return copyPost(post), nil
+}
+
+// Update atualiza os dados de um post, registrando os eventos na caixa de saída
+func (r *PostRepository) Update(ctx context.Context, post *domain.Post, events ...*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Verificar se post existe
+	if _, exists := r.posts[post.ID]; !exists {
+		return ErrPostNotFound
+	}
+
+	r.posts[post.ID] = storedPost(post)
+	r.indexPost(post)
+	r.outbox.addAll(events)
+
+	return nil
+}
+
+// Delete remove um post do repositório junto com os seus comentários, curtidas e
+// revisões, antes de liberar o bloqueio
+func (r *PostRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Verificar se post existe
+	if _, exists := r.posts[id]; !exists {
+		return ErrPostNotFound
+	}
+
+	delete(r.posts, id)
+	r.index.Remove(id)
+
+	commentIDs := r.comments.deleteByPost(id)
+	r.reactions.deleteTargets(domain.ReactionTargetPost, id)
+	r.reactions.deleteTargets(domain.ReactionTargetComment, commentIDs...)
+	r.revisions.deleteByPost(id)
+
+	return nil
+}
+
+// ReassignAuthor transfere a autoria de todos os posts de fromID para toID
+func (r *PostRepository) ReassignAuthor(ctx context.Context, fromID, toID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, post := range r.posts {
+		if post.AuthorID == fromID {
+			post.AuthorID = toID
+		}
+	}
+
+	return nil
+}
+
+// List retorna uma página de posts com o status informado, do mais recente para o mais antigo
+func (r *PostRepository) List(ctx context.Context, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*domain.Post, 0, len(r.posts))
+	for _, post := range r.posts {
+		if status == "" || post.Status == status {
+			posts = append(posts, copyPost(post))
+		}
+	}
+
+	return paginatePosts(posts, req), nil
+}
+
+// ListByAuthor retorna uma página de posts de um autor específico com o status informado
+func (r *PostRepository) ListByAuthor(ctx context.Context, authorID string, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*domain.Post, 0)
+	for _, post := range r.posts {
+		if post.AuthorID == authorID && (status == "" || post.Status == status) {
+			posts = append(posts, copyPost(post))
+		}
+	}
+
+	return paginatePosts(posts, req), nil
+}
+
+// ListPublishedEntries retorna o ID e a última alteração dos posts publicados mais recentes
+func (r *PostRepository) ListPublishedEntries(ctx context.Context, limit int) ([]domain.PostEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*domain.Post, 0)
+	for _, post := range r.posts {
+		if post.Status == domain.PostStatusPublished {
+			posts = append(posts, post)
+		}
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreatedAt.Equal(posts[j].CreatedAt) {
+			return posts[i].ID < posts[j].ID
+		}
+		return posts[i].CreatedAt.After(posts[j].CreatedAt)
+	})
+
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+
+	entries := make([]domain.PostEntry, len(posts))
+	for i, post := range posts {
+		entries[i] = domain.PostEntry{ID: post.ID, UpdatedAt: post.UpdatedAt}
+	}
+
+	return entries, nil
+}
+
+// ListScheduledDue retorna os posts agendados com data de publicação vencida, da mais antiga para a mais recente
+func (r *PostRepository) ListScheduledDue(ctx context.Context, now time.Time) ([]*domain.Post, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*domain.Post, 0)
+	for _, post := range r.posts {
+		if post.Status == domain.PostStatusScheduled && post.PublishAt != nil && !post.PublishAt.After(now) {
+			posts = append(posts, copyPost(post))
+		}
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].PublishAt.Equal(*posts[j].PublishAt) {
+			return posts[i].ID < posts[j].ID
+		}
+		return posts[i].PublishAt.Before(*posts[j].PublishAt)
+	})
+
+	return posts, nil
+}
+
+// Search busca posts por termos e tags usando o índice invertido
+func (r *PostRepository) Search(ctx context.Context, query string, tags []string, req domain.PageRequest) (*domain.Page[*domain.PostSearchResult], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags = domain.NormalizeTags(tags)
+	terms := domain.Tokenize(query)
+
+	results := make([]*domain.PostSearchResult, 0)
+	if len(terms) == 0 {
+		for _, post := range r.posts {
+			if post.IsPublished() && post.HasTags(tags) {
+				results = append(results, &domain.PostSearchResult{Post: copyPost(post)})
+			}
+		}
+	} else {
+		for id, score := range r.index.Score(terms, 0) {
+			if post := r.posts[id]; post.HasTags(tags) {
+				results = append(results, &domain.PostSearchResult{Post: copyPost(post), Score: score})
+			}
+		}
+	}
+
+	return textsearch.RankAndPaginate(results, req), nil
+}
+
+// indexPost mantém no índice de busca apenas os posts publicados
+func (r *PostRepository) indexPost(post *domain.Post) {
+	if post.IsPublished() {
+		r.index.Put(post.ID, post.Title, post.Content)
+	} else {
+		r.index.Remove(post.ID)
+	}
+}
+
+// copyPost copia o post, para que quem o recebe não altere o registro armazenado fora
+// do bloqueio do repositório: as alterações só são gravadas por Update
+func copyPost(post *domain.Post) *domain.Post {
+	copied := *post
+	copied.Tags = append([]string{}, post.Tags...)
+	if post.PublishAt != nil {
+		publishAt := *post.PublishAt
+		copied.PublishAt = &publishAt
+	}
+	if post.Author != nil {
+		author := *post.Author
+		copied.Author = &author
+	}
+	return &copied
+}
+
+// storedPost copia o post para armazenamento, sem o autor e as curtidas, que não são
+// gravados com o post
+func storedPost(post *domain.Post) *domain.Post {
+	stored := copyPost(post)
+	stored.Author = nil
+	stored.Likes = 0
+	return stored
+}
+
+// ListPopular retorna uma página dos posts publicados, do mais popular para o menos popular no instante rankedAt
+func (r *PostRepository) ListPopular(ctx context.Context, rankedAt time.Time, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	posts := make([]*domain.Post, 0, len(r.posts))
+	scores := make(map[string]float64, len(r.posts))
+	for _, post := range r.posts {
+		if post.Status == domain.PostStatusPublished {
+			copied := copyPost(post)
+			copied.Likes = r.reactions.countOf(domain.ReactionTargetPost, post.ID)
+			scores[post.ID] = domain.PopularityScore(copied.Likes, copied.PublishedAt(), rankedAt)
+			posts = append(posts, copied)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		if scores[posts[i].ID] == scores[posts[j].ID] {
+			return posts[i].ID < posts[j].ID
+		}
+		return scores[posts[i].ID] > scores[posts[j].ID]
+	})
+
+	return paginate(posts, req, func(post *domain.Post, cursor *domain.Cursor) bool {
+		return cursor.HighestScoreFirst(scores[post.ID], post.ID)
+	}, func(post *domain.Post) domain.Cursor {
+		return domain.Cursor{Time: rankedAt, Score: scores[post.ID], ID: post.ID}
+	}), nil
+}
+
+// paginatePosts ordena os posts por data de criação decrescente e aplica a paginação
+func paginatePosts(posts []*domain.Post, req domain.PageRequest) *domain.Page[*domain.Post] {
+	sort.Slice(posts, func(i, j int) bool {
+		if posts[i].CreatedAt.Equal(posts[j].CreatedAt) {
+			return posts[i].ID < posts[j].ID
+		}
+		return posts[i].CreatedAt.After(posts[j].CreatedAt)
+	})
+
+	return paginate(posts, req, func(post *domain.Post, cursor *domain.Cursor) bool {
+		return cursor.NewestFirst(post.CreatedAt, post.ID)
+	}, func(post *domain.Post) domain.Cursor {
+		return domain.Cursor{Time: post.CreatedAt, ID: post.ID}
+	})
+}
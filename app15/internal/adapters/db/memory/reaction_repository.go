@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// reactionTarget identifica um conteúdo que recebeu reações
+type reactionTarget struct {
+	targetType domain.ReactionTarget
+	targetID   string
+}
+
+// reactionKey identifica a reação de um usuário a um conteúdo
+type reactionKey struct {
+	reactionTarget
+	userID string
+}
+
+// ReactionRepository implementa o repositório de reações em memória. As contagens
+// são mantidas junto com as reações, sob a mesma trava.
+type ReactionRepository struct {
+	reactions map[reactionKey]*domain.Reaction
+	counts    map[reactionTarget]int
+	mu        sync.RWMutex
+}
+
+// NewReactionRepository cria uma nova instância do repositório de reações em memória
+func NewReactionRepository() *ReactionRepository {
+	return &ReactionRepository{
+		reactions: make(map[reactionKey]*domain.Reaction),
+		counts:    make(map[reactionTarget]int),
+	}
+}
+
+// Add registra a reação; retorna false se o usuário já havia reagido ao conteúdo
+func (r *ReactionRepository) Add(ctx context.Context, reaction *domain.Reaction) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := reactionTarget{targetType: reaction.TargetType, targetID: reaction.TargetID}
+	key := reactionKey{reactionTarget: target, userID: reaction.UserID}
+	if _, exists := r.reactions[key]; exists {
+		return false, nil
+	}
+
+	r.reactions[key] = reaction
+	r.counts[target]++
+
+	return true, nil
+}
+
+// Remove apaga a reação do usuário ao conteúdo; retorna false se ela não existia
+func (r *ReactionRepository) Remove(ctx context.Context, userID string, targetType domain.ReactionTarget, targetID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	target := reactionTarget{targetType: targetType, targetID: targetID}
+	key := reactionKey{reactionTarget: target, userID: userID}
+	if _, exists := r.reactions[key]; !exists {
+		return false, nil
+	}
+
+	delete(r.reactions, key)
+	if r.counts[target]--; r.counts[target] == 0 {
+		delete(r.counts, target)
+	}
+
+	return true, nil
+}
+
+// deleteTargets remove as reações dos conteúdos informados, quando eles são removidos
+func (r *ReactionRepository) deleteTargets(targetType domain.ReactionTarget, targetIDs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := make(map[reactionTarget]bool, len(targetIDs))
+	for _, id := range targetIDs {
+		target := reactionTarget{targetType: targetType, targetID: id}
+		removed[target] = true
+		delete(r.counts, target)
+	}
+
+	for key := range r.reactions {
+		if removed[key.reactionTarget] {
+			delete(r.reactions, key)
+		}
+	}
+}
+
+// countOf retorna a contagem de reações do conteúdo, para as listagens de outros repositórios
+func (r *ReactionRepository) countOf(targetType domain.ReactionTarget, targetID string) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.counts[reactionTarget{targetType: targetType, targetID: targetID}]
+}
+
+// CountByTargets conta as reações de cada conteúdo informado
+func (r *ReactionRepository) CountByTargets(ctx context.Context, targetType domain.ReactionTarget, targetIDs []string) (map[string]int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	counts := make(map[string]int, len(targetIDs))
+	for _, id := range targetIDs {
+		if count := r.counts[reactionTarget{targetType: targetType, targetID: id}]; count > 0 {
+			counts[id] = count
+		}
+	}
+
+	return counts, nil
+}
+
+// DeleteByUser remove todas as reações do usuário, descontando-as das contagens
+func (r *ReactionRepository) DeleteByUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.reactions {
+		if key.userID != userID {
+			continue
+		}
+		delete(r.reactions, key)
+		if r.counts[key.reactionTarget]--; r.counts[key.reactionTarget] == 0 {
+			delete(r.counts, key.reactionTarget)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories/repositorytest"
+)
+
+func TestRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		outbox := NewOutboxRepository()
+		reactions := NewReactionRepository()
+		comments := NewCommentRepository(outbox)
+		revisions := NewRevisionRepository()
+		return repositorytest.Repositories{
+			Users:         NewUserRepository(),
+			Posts:         NewPostRepository(comments, revisions, reactions, outbox),
+			Comments:      comments,
+			Tokens:        NewTokenRepository(),
+			Audit:         NewAuditRepository(),
+			Revisions:     revisions,
+			Outbox:        outbox,
+			Notifications: NewNotificationRepository(),
+			LoginAttempts: NewLoginAttemptRepository(),
+			Reactions:     reactions,
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de revisões
+var (
+	ErrRevisionNotFound = errors.New("revisão não encontrada")
+	ErrRevisionExists   = errors.New("revisão já existe")
+)
+
+// RevisionRepository implementa o histórico de revisões de posts em memória
+type RevisionRepository struct {
+	revisions map[string][]*domain.PostRevision // revisões por post, em ordem de versão
+	mu        sync.RWMutex
+}
+
+// NewRevisionRepository cria uma nova instância do repositório de revisões em memória
+func NewRevisionRepository() *RevisionRepository {
+	return &RevisionRepository{
+		revisions: make(map[string][]*domain.PostRevision),
+	}
+}
+
+// Create registra uma nova revisão
+func (r *RevisionRepository) Create(ctx context.Context, revision *domain.PostRevision) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revisions := r.revisions[revision.PostID]
+	for _, existing := range revisions {
+		if existing.Version == revision.Version {
+			return ErrRevisionExists
+		}
+	}
+
+	// Mantém as revisões ordenadas por versão
+	i := len(revisions)
+	for i > 0 && revisions[i-1].Version > revision.Version {
+		i--
+	}
+	revisions = append(revisions, nil)
+	copy(revisions[i+1:], revisions[i:])
+	revisions[i] = copyRevision(revision)
+	r.revisions[revision.PostID] = revisions
+
+	return nil
+}
+
+// deleteByPost remove o histórico de revisões do post, quando ele é removido
+func (r *RevisionRepository) deleteByPost(postID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.revisions, postID)
+}
+
+// GetByVersion busca uma revisão de um post pelo número da versão
+func (r *RevisionRepository) GetByVersion(ctx context.Context, postID string, version int) (*domain.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, revision := range r.revisions[postID] {
+		if revision.Version == version {
+			return copyRevision(revision), nil
+		}
+	}
+
+	return nil, ErrRevisionNotFound
+}
+
+// GetLatest busca a revisão mais recente de um post
+func (r *RevisionRepository) GetLatest(ctx context.Context, postID string) (*domain.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[postID]
+	if len(revisions) == 0 {
+		return nil, ErrRevisionNotFound
+	}
+
+	return copyRevision(revisions[len(revisions)-1]), nil
+}
+
+// ListByPost retorna uma lista paginada das revisões de um post, da mais recente para a mais antiga
+func (r *RevisionRepository) ListByPost(ctx context.Context, postID string, page, pageSize int) ([]*domain.PostRevision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := r.revisions[postID]
+	ordered := make([]*domain.PostRevision, len(revisions))
+	for i, revision := range revisions {
+		ordered[len(revisions)-1-i] = copyRevision(revision)
+	}
+
+	// Calcular índices de paginação
+	startIndex := (page - 1) * pageSize
+	endIndex := startIndex + pageSize
+
+	if startIndex >= len(ordered) {
+		return []*domain.PostRevision{}, nil
+	}
+
+	if endIndex > len(ordered) {
+		endIndex = len(ordered)
+	}
+
+	return ordered[startIndex:endIndex], nil
+}
+
+// ReassignEditor transfere para toID todas as revisões editadas por fromID
+func (r *RevisionRepository) ReassignEditor(ctx context.Context, fromID, toID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, revisions := range r.revisions {
+		for _, revision := range revisions {
+			if revision.EditorID == fromID {
+				revision.EditorID = toID
+			}
+		}
+	}
+
+	return nil
+}
+
+// copyRevision copia a revisão, para que quem a recebe não altere o registro armazenado
+func copyRevision(revision *domain.PostRevision) *domain.PostRevision {
+	copied := *revision
+	copied.Tags = append([]string{}, revision.Tags...)
+	return &copied
+}
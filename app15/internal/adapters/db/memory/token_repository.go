@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Erros específicos do repositório de tokens
+var (
+	ErrTokenNotFound = errors.New("token não encontrado")
+)
+
+// TokenRepository implementa o repositório de tokens de renovação em memória
+type TokenRepository struct {
+	tokens map[string]*domain.RefreshToken
+	mu     sync.RWMutex
+}
+
+// NewTokenRepository cria uma nova instância do repositório de tokens em memória
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{
+		tokens: make(map[string]*domain.RefreshToken),
+	}
+}
+
+// Create adiciona um novo token ao repositório
+func (r *TokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *token
+	r.tokens[token.ID] = &stored
+
+	return nil
+}
+
+// GetByHash busca um token pelo hash do seu valor
+func (r *TokenRepository) GetByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.TokenHash == hash {
+			found := *token
+			return &found, nil
+		}
+	}
+
+	return nil, ErrTokenNotFound
+}
+
+// Revoke revoga um único token
+func (r *TokenRepository) Revoke(ctx context.Context, id, replacedBy string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.tokens[id]
+	if !exists {
+		return ErrTokenNotFound
+	}
+
+	if token.RevokedAt != nil {
+		return repositories.ErrTokenAlreadyRevoked
+	}
+
+	now := time.Now()
+	token.RevokedAt = &now
+	token.ReplacedBy = replacedBy
+
+	return nil
+}
+
+// RevokeFamily revoga todos os tokens ativos de uma família
+func (r *TokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// RevokeByUser revoga todos os tokens ativos do usuário
+func (r *TokenRepository) RevokeByUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+
+	return nil
+}
+
+// IsFamilyActive informa se a família possui algum token não revogado
+func (r *TokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
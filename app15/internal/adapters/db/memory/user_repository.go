@@ -3,9 +3,11 @@ package memory
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
 
-	"app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
 )
 
 // Erros específicos do repositório
@@ -34,6 +36,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	defer r.mu.Unlock()
 
 	// Verificar se email já existe
+	user = storedUser(user)
 	for _, u := range r.users {
 		if u.Email == user.Email {
 			return ErrEmailAlreadyExists
@@ -59,7 +62,24 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User,
 		return nil, ErrUserNotFound
 	}
 
-	return user, nil
+	return copyUser(user), nil
+}
+
+// GetByIDs busca os usuários com os IDs informados, ignorando os inexistentes
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := make([]*domain.User, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if user, exists := r.users[id]; exists && !seen[id] {
+			seen[id] = true
+			users = append(users, copyUser(user))
+		}
+	}
+
+	return users, nil
 }
 
 // GetByEmail busca um usuário pelo email
@@ -67,9 +87,10 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	email = domain.NormalizeEmail(email)
 	for _, user := range r.users {
 		if user.Email == email {
-			return user, nil
+			return copyUser(user), nil
 		}
 	}
 
@@ -83,7 +104,7 @@ func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*d
 
 	for _, user := range r.users {
 		if user.Username == username {
-			return user, nil
+			return copyUser(user), nil
 		}
 	}
 
@@ -102,6 +123,7 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	}
 
 	// Verificar se o novo email já está em uso por outro usuário
+	user = storedUser(user)
 	for id, u := range r.users {
 		if u.Email == user.Email && id != user.ID {
 			return ErrEmailAlreadyExists
@@ -117,6 +139,39 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
+// UpdateRole grava o papel do usuário, recusando rebaixar o último administrador
+func (r *UserRepository) UpdateRole(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.users[user.ID]
+	if !exists {
+		return ErrUserNotFound
+	}
+
+	if stored.Role == domain.RoleAdmin && user.Role != domain.RoleAdmin && r.countAdmins() == 1 {
+		return repositories.ErrLastAdmin
+	}
+
+	updated := copyUser(stored)
+	updated.Role = user.Role
+	updated.UpdatedAt = user.UpdatedAt
+	r.users[user.ID] = updated
+
+	return nil
+}
+
+// countAdmins conta os administradores; deve ser chamado com o bloqueio obtido
+func (r *UserRepository) countAdmins() int {
+	count := 0
+	for _, user := range r.users {
+		if user.Role == domain.RoleAdmin {
+			count++
+		}
+	}
+	return count
+}
+
 // Delete remove um usuário do repositório
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
@@ -134,29 +189,41 @@ func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// List retorna uma lista paginada de usuários
-func (r *UserRepository) List(ctx context.Context, page, pageSize int) ([]*domain.User, error) {
+// List retorna uma página de usuários, em ordem de cadastro
+func (r *UserRepository) List(ctx context.Context, req domain.PageRequest) (*domain.Page[*domain.User], error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// Calcular índices de paginação
-	startIndex := (page - 1) * pageSize
-	endIndex := startIndex + pageSize
-
-	// Copiar todos os usuários para um slice
+	// Copiar todos os usuários para um slice, em ordem de cadastro
 	users := make([]*domain.User, 0, len(r.users))
 	for _, user := range r.users {
-		users = append(users, user)
-	}
-
-	// Aplicar paginação
-	if startIndex >= len(users) {
-		return []*domain.User{}, nil
+		users = append(users, copyUser(user))
 	}
+	sort.Slice(users, func(i, j int) bool {
+		if users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].ID < users[j].ID
+		}
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+
+	return paginate(users, req, func(user *domain.User, cursor *domain.Cursor) bool {
+		return cursor.OldestFirst(user.CreatedAt, user.ID)
+	}, func(user *domain.User) domain.Cursor {
+		return domain.Cursor{Time: user.CreatedAt, ID: user.ID}
+	}), nil
+}
 
-	if endIndex > len(users) {
-		endIndex = len(users)
-	}
+// copyUser copia o usuário, para que quem o recebe não altere o registro armazenado
+// fora do bloqueio do repositório: as alterações só são gravadas por Update
+func copyUser(user *domain.User) *domain.User {
+	copied := *user
+	return &copied
+}
 
-	return users[startIndex:endIndex], nil
-} 
\ No newline at end of file
+// storedUser prepara a cópia gravada do usuário, com o email padronizado como no banco
+// de dados, para que as buscas por email não diferenciem maiúsculas de minúsculas
+func storedUser(user *domain.User) *domain.User {
+	stored := copyUser(user)
+	stored.Email = domain.NormalizeEmail(stored.Email)
+	return stored
+}
@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+const auditColumns = `id, actor_id, action, target_type, target_id, details, created_at`
+
+// AuditRepository implementa a trilha de auditoria sobre database/sql
+type AuditRepository struct {
+	db *DB
+}
+
+// NewAuditRepository cria uma nova instância da trilha de auditoria em banco de dados
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create registra uma nova entrada na trilha de auditoria
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO audit_log (`+auditColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		entry.ID, entry.ActorID, entry.Action, entry.TargetType, entry.TargetID, entry.Details, entry.CreatedAt.UTC())
+	return err
+}
+
+// ReassignUser troca fromID por toID no autor das entradas e no alvo das ações sobre usuários
+func (r *AuditRepository) ReassignUser(ctx context.Context, fromID, toID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, r.db.rebind(`UPDATE audit_log SET actor_id = ? WHERE actor_id = ?`), toID, fromID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, r.db.rebind(`UPDATE audit_log SET target_id = ? WHERE target_type = 'user' AND target_id = ?`), toID, fromID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// List retorna uma lista paginada de entradas, da mais recente para a mais antiga
+func (r *AuditRepository) List(ctx context.Context, page, pageSize int) ([]*domain.AuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT `+auditColumns+` FROM audit_log ORDER BY created_at DESC, id LIMIT ? OFFSET ?`),
+		pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*domain.AuditEntry, 0)
+	for rows.Next() {
+		var entry domain.AuditEntry
+		err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &entry.TargetType, &entry.TargetID, &entry.Details, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,251 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de comentários
+var (
+	ErrCommentNotFound = errors.New("comentário não encontrado")
+)
+
+const commentColumns = `id, content, post_id, parent_id, depth, deleted, author_id, created_at, updated_at`
+
+// CommentRepository implementa o repositório de comentários sobre database/sql
+type CommentRepository struct {
+	db *DB
+}
+
+// NewCommentRepository cria uma nova instância do repositório de comentários em banco de dados
+func NewCommentRepository(db *DB) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+// Create adiciona um novo comentário ao repositório, com os eventos na mesma transação
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment, events ...*domain.Event) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, r.db.rebind(`INSERT INTO comments (`+commentColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		comment.ID, comment.Content, comment.PostID, comment.ParentID, comment.Depth, comment.Deleted, comment.AuthorID,
+		comment.CreatedAt.UTC(), comment.UpdatedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	if err := addEvents(ctx, r.db, tx, events); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID busca um comentário pelo ID
+func (r *CommentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	comment, err := scanComment(r.db.QueryRowContext(ctx, r.db.rebind(`SELECT `+commentColumns+` FROM comments WHERE id = ?`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	}
+	return comment, err
+}
+
+// Update atualiza os dados de um comentário
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE comments SET content = ?, deleted = ?, author_id = ?, updated_at = ? WHERE id = ?`),
+		comment.Content, comment.Deleted, comment.AuthorID, comment.UpdatedAt.UTC(), comment.ID)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, ErrCommentNotFound)
+}
+
+// Delete remove um comentário do repositório
+func (r *CommentRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM comments WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, ErrCommentNotFound)
+}
+
+// ReassignAuthor transfere a autoria de todos os comentários de fromID para toID
+func (r *CommentRepository) ReassignAuthor(ctx context.Context, fromID, toID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE comments SET author_id = ? WHERE author_id = ?`), toID, fromID)
+	return err
+}
+
+// ListByPost retorna uma página de comentários de um post, em ordem cronológica
+func (r *CommentRepository) ListByPost(ctx context.Context, postID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error) {
+	q := pageQuery{table: `comments`, columns: commentColumns}
+	q.filter(`post_id = ?`, postID)
+	return fetchPage(ctx, r.db, q, req, r.list, commentCursor)
+}
+
+// ListByAuthor retorna uma página de comentários de um autor específico, em ordem cronológica
+func (r *CommentRepository) ListByAuthor(ctx context.Context, authorID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error) {
+	q := pageQuery{table: `comments`, columns: commentColumns}
+	q.filter(`author_id = ?`, authorID)
+	return fetchPage(ctx, r.db, q, req, r.list, commentCursor)
+}
+
+// ListThread retorna a árvore paginada de respostas a partir de parentID.
+// Cada nível da árvore é carregado com uma única consulta.
+func (r *CommentRepository) ListThread(ctx context.Context, postID, parentID string, depth int, req domain.PageRequest) (*domain.Page[*domain.CommentThread], error) {
+	q := pageQuery{table: `comments`, columns: commentColumns}
+	q.filter(`post_id = ? AND parent_id = ?`, postID, parentID)
+	page, err := fetchPage(ctx, r.db, q, req, r.list, commentCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := make([]*domain.CommentThread, 0, len(page.Items))
+	level := make(map[string]*domain.CommentThread, len(page.Items))
+	for _, comment := range page.Items {
+		thread := &domain.CommentThread{Comment: comment, Replies: []*domain.CommentThread{}}
+		roots = append(roots, thread)
+		level[comment.ID] = thread
+	}
+
+	for len(level) > 0 {
+		ids := make([]interface{}, 0, len(level))
+		for id := range level {
+			ids = append(ids, id)
+		}
+
+		if err := r.loadReplyCounts(ctx, level, ids); err != nil {
+			return nil, err
+		}
+
+		if depth == 0 {
+			break
+		}
+		depth--
+
+		// Primeira página de respostas de cada comentário do nível atual
+		replies, err := r.list(ctx, `SELECT `+commentColumns+` FROM (
+				SELECT `+commentColumns+`, ROW_NUMBER() OVER (PARTITION BY parent_id ORDER BY created_at, id) AS position
+				FROM comments WHERE parent_id IN (`+placeholders(len(ids))+`)
+			) replies WHERE position <= ? ORDER BY created_at, id`,
+			append(ids, req.Limit)...)
+		if err != nil {
+			return nil, err
+		}
+
+		next := make(map[string]*domain.CommentThread, len(replies))
+		for _, reply := range replies {
+			thread := &domain.CommentThread{Comment: reply, Replies: []*domain.CommentThread{}}
+			parent := level[reply.ParentID]
+			parent.Replies = append(parent.Replies, thread)
+			next[reply.ID] = thread
+		}
+		level = next
+	}
+
+	return &domain.Page[*domain.CommentThread]{
+		Items:      roots,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+		Total:      page.Total,
+	}, nil
+}
+
+// Remove apaga o comentário apenas se nenhuma resposta existir no momento da remoção, com
+// uma única instrução condicional; caso contrário, o transforma em lápide na mesma transação
+func (r *CommentRepository) Remove(ctx context.Context, id string, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, r.db.rebind(`DELETE FROM comments
+		WHERE id = ? AND NOT EXISTS (SELECT 1 FROM comments reply WHERE reply.parent_id = ?)`), id, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		result, err = tx.ExecContext(ctx, r.db.rebind(`UPDATE comments SET content = '', deleted = ?, updated_at = ? WHERE id = ?`),
+			true, at.UTC(), id)
+		if err != nil {
+			return err
+		}
+		if err := checkAffected(result, ErrCommentNotFound); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// loadReplyCounts preenche o total de respostas diretas dos comentários informados
+func (r *CommentRepository) loadReplyCounts(ctx context.Context, threads map[string]*domain.CommentThread, ids []interface{}) error {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT parent_id, COUNT(*) FROM comments WHERE parent_id IN (`+placeholders(len(ids))+`) GROUP BY parent_id`), ids...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			parentID string
+			count    int
+		)
+		if err := rows.Scan(&parentID, &count); err != nil {
+			return err
+		}
+		threads[parentID].ReplyCount = count
+	}
+
+	return rows.Err()
+}
+
+// list executa uma consulta que retorna vários comentários
+func (r *CommentRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Comment, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.Comment, 0)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// commentCursor gera a posição de um comentário nas listagens em ordem cronológica
+func commentCursor(comment *domain.Comment) domain.Cursor {
+	return domain.Cursor{Time: comment.CreatedAt, ID: comment.ID}
+}
+
+// scanComment lê um comentário de uma linha do resultado
+func scanComment(s scanner) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := s.Scan(&comment.ID, &comment.Content, &comment.PostID, &comment.ParentID, &comment.Depth, &comment.Deleted,
+		&comment.AuthorID, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
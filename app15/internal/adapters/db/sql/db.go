@@ -0,0 +1,139 @@
+package sql
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	// Drivers suportados pelo adaptador
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+// Drivers de banco de dados suportados
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// DB agrupa a conexão com o banco e o dialeto SQL em uso
+type DB struct {
+	*sql.DB
+	driver string
+}
+
+// Open abre a conexão com o banco do driver informado e aplica as migrações pendentes
+func Open(driver, dsn string) (*DB, error) {
+	switch driver {
+	case DriverSQLite:
+		dsn = sqliteDSN(dsn)
+	case DriverPostgres:
+	default:
+		return nil, fmt.Errorf("driver de banco de dados não suportado: %s", driver)
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// O SQLite serializa escritas; uma única conexão evita erros de banco bloqueado
+	if driver == DriverSQLite {
+		conn.SetMaxOpenConns(1)
+	}
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	db := &DB{DB: conn, driver: driver}
+	if err := db.Migrate(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := db.backfillSearchText(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// backfillSearchText gera o texto de busca de posts gravados antes da migração 0004
+func (db *DB) backfillSearchText() error {
+	rows, err := db.Query(`SELECT ` + postColumns + ` FROM posts WHERE search_text = ''`)
+	if err != nil {
+		return err
+	}
+
+	posts := make([]*domain.Post, 0)
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		posts = append(posts, post)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		if _, err := db.Exec(db.rebind(`UPDATE posts SET search_text = ? WHERE id = ?`), searchText(post), post.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Driver retorna o nome do driver em uso
+func (db *DB) Driver() string {
+	return db.driver
+}
+
+// rebind converte os placeholders "?" para o formato do driver em uso
+func (db *DB) rebind(query string) string {
+	if db.driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// unixSeconds retorna a expressão SQL com os segundos inteiros desde a época Unix da coluna de data
+func (db *DB) unixSeconds(column string) string {
+	if db.driver == DriverPostgres {
+		return `CAST(FLOOR(EXTRACT(EPOCH FROM ` + column + `)) AS BIGINT)`
+	}
+	return `CAST(strftime('%s', ` + column + `) AS INTEGER)`
+}
+
+// sqliteDSN garante o formato de data que permite ordenar e ler timestamps de volta
+func sqliteDSN(dsn string) string {
+	if strings.Contains(dsn, "_time_format=") {
+		return dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&_time_format=sqlite"
+	}
+	return dsn + "?_time_format=sqlite"
+}
@@ -0,0 +1,129 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+const loginAttemptColumns = `id, user_id, email, ip, user_agent, success, created_at`
+
+// LoginAttemptRepository implementa o histórico de logins e os bloqueios sobre database/sql
+type LoginAttemptRepository struct {
+	db *DB
+}
+
+// NewLoginAttemptRepository cria uma nova instância do repositório de tentativas de login em banco de dados
+func NewLoginAttemptRepository(db *DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// Create registra uma tentativa de login
+func (r *LoginAttemptRepository) Create(ctx context.Context, attempt *domain.LoginAttempt) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO login_attempts (`+loginAttemptColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		attempt.ID, attempt.UserID, attempt.Email, attempt.IP, attempt.UserAgent, attempt.Success, attempt.CreatedAt.UTC())
+	return err
+}
+
+// ListByUser retorna uma lista paginada das tentativas de login do usuário, da mais recente para a mais antiga
+func (r *LoginAttemptRepository) ListByUser(ctx context.Context, userID string, page, pageSize int) ([]*domain.LoginAttempt, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT `+loginAttemptColumns+` FROM login_attempts
+		WHERE user_id = ? ORDER BY created_at DESC, id LIMIT ? OFFSET ?`), userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := make([]*domain.LoginAttempt, 0)
+	for rows.Next() {
+		var attempt domain.LoginAttempt
+		err := rows.Scan(&attempt.ID, &attempt.UserID, &attempt.Email, &attempt.IP, &attempt.UserAgent, &attempt.Success, &attempt.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, &attempt)
+	}
+
+	return attempts, rows.Err()
+}
+
+// GetLockout retorna o estado de bloqueio da chave
+func (r *LoginAttemptRepository) GetLockout(ctx context.Context, key string) (*domain.Lockout, error) {
+	lockout, err := scanLockout(key, r.db.QueryRowContext(ctx, r.db.rebind(`SELECT failures, locked_until, updated_at FROM login_lockouts WHERE lockout_key = ?`), key))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.NewLockout(key), nil
+	}
+	return lockout, err
+}
+
+// RegisterFailure contabiliza uma falha na chave em uma transação. A linha é criada antes
+// de ser lida, o que faz do INSERT a primeira escrita da transação: no SQLite ele obtém o
+// bloqueio de escrita do banco, e no PostgreSQL a leitura com FOR UPDATE bloqueia a linha
+// até o commit. Falhas simultâneas na mesma chave são assim serializadas.
+func (r *LoginAttemptRepository) RegisterFailure(ctx context.Context, key string, policy domain.BackoffPolicy, now time.Time) (*domain.Lockout, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, r.db.rebind(`INSERT INTO login_lockouts (lockout_key, failures, locked_until, updated_at)
+		VALUES (?, 0, NULL, ?) ON CONFLICT (lockout_key) DO NOTHING`), key, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT failures, locked_until, updated_at FROM login_lockouts WHERE lockout_key = ?`
+	if r.db.driver == DriverPostgres {
+		query += ` FOR UPDATE`
+	}
+	lockout, err := scanLockout(key, tx.QueryRowContext(ctx, r.db.rebind(query), key))
+	if err != nil {
+		return nil, err
+	}
+
+	lockout.RegisterFailure(policy, now)
+
+	_, err = tx.ExecContext(ctx, r.db.rebind(`UPDATE login_lockouts SET failures = ?, locked_until = ?, updated_at = ? WHERE lockout_key = ?`),
+		lockout.Failures, nullTime(lockout.LockedUntil), lockout.UpdatedAt.UTC(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return lockout, nil
+}
+
+// scanLockout lê o estado de bloqueio da chave de uma linha do resultado
+func scanLockout(key string, s scanner) (*domain.Lockout, error) {
+	var (
+		lockout     = domain.Lockout{Key: key}
+		lockedUntil sql.NullTime
+	)
+	if err := s.Scan(&lockout.Failures, &lockedUntil, &lockout.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if lockedUntil.Valid {
+		lockout.LockedUntil = &lockedUntil.Time
+	}
+	return &lockout, nil
+}
+
+// DeleteLockout esquece as falhas da chave
+func (r *LoginAttemptRepository) DeleteLockout(ctx context.Context, key string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM login_lockouts WHERE lockout_key = ?`), key)
+	return err
+}
+
+// DeleteByUser remove as tentativas de login do usuário e as feitas com o seu email
+func (r *LoginAttemptRepository) DeleteByUser(ctx context.Context, userID, email string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM login_attempts WHERE user_id = ? OR email = ?`),
+		userID, domain.NormalizeEmail(email))
+	return err
+}
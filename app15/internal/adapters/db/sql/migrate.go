@@ -0,0 +1,176 @@
+package sql
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// preconditions são verificações feitas na transação de uma migração, antes dos seus
+// comandos; um erro interrompe a migração, que é tentada de novo na próxima inicialização
+var preconditions = map[int]func(tx *sql.Tx) error{
+	11: checkEmailCollisions,
+}
+
+// migration representa um arquivo de migração versionado
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// Migrate aplica, em ordem, as migrações embutidas que ainda não foram executadas
+func (db *DB) Migrate() error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("erro ao criar tabela de migrações: %w", err)
+	}
+
+	applied := make(map[int]bool)
+	rows, err := db.Query(`SELECT version FROM schema_migrations`)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return err
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if applied[m.version] {
+			continue
+		}
+		if err := db.applyMigration(m); err != nil {
+			return fmt.Errorf("erro ao aplicar migração %s: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration executa uma migração e registra sua versão na mesma transação
+func (db *DB) applyMigration(m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if check, ok := preconditions[m.version]; ok {
+		if err := check(tx); err != nil {
+			return err
+		}
+	}
+
+	for _, stmt := range strings.Split(m.sql, ";") {
+		if strings.TrimSpace(stmt) == "" {
+			continue
+		}
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(db.rebind(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`),
+		m.version, m.name, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkEmailCollisions impede a normalização dos emails enquanto houver contas cujos emails
+// só diferem por maiúsculas ou espaços, listando-os para que sejam resolvidos manualmente
+func checkEmailCollisions(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT email FROM users
+		WHERE LOWER(TRIM(email)) IN (
+			SELECT LOWER(TRIM(email)) FROM users GROUP BY LOWER(TRIM(email)) HAVING COUNT(*) > 1
+		)
+		ORDER BY LOWER(TRIM(email)), email`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var conflicts []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return err
+		}
+		conflicts = append(conflicts, email)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("emails que só diferem por maiúsculas ou espaços pertencem a contas distintas: %s; altere ou remova as contas duplicadas antes de migrar",
+			strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// loadMigrations lê as migrações embutidas, nomeadas como NNNN_descricao.sql
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]migration, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("nome de migração inválido: %s", name)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("nome de migração inválido: %s", name)
+		}
+
+		content, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, err
+		}
+
+		migrations = append(migrations, migration{
+			version: version,
+			name:    strings.TrimSuffix(name, ".sql"),
+			sql:     string(content),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+
+	return migrations, nil
+}
@@ -0,0 +1,59 @@
+package sql
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A normalização dos emails falha, listando-os, enquanto houver contas cujos emails só
+// diferem por maiúsculas, e é aplicada depois que o conflito é resolvido
+func TestNormalizeEmailsMigrationRejectsCollisions(t *testing.T) {
+	db, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "blog.db"))
+	if err != nil {
+		t.Fatalf("Erro ao abrir banco SQLite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Volta ao estado anterior à migração 0011, com emails gravados como foram digitados
+	now := time.Now().UTC()
+	for _, user := range [][3]string{
+		{"user-1", "alice", "Alice@Exemplo.com"},
+		{"user-2", "alice2", "alice@exemplo.com"},
+		{"user-3", "bob", " Bob@Exemplo.com"},
+	} {
+		if _, err := db.Exec(db.rebind(`INSERT INTO users (id, username, email, password, role, created_at, updated_at) VALUES (?, ?, ?, 'x', 'reader', ?, ?)`),
+			user[0], user[1], user[2], now, now); err != nil {
+			t.Fatalf("Erro ao gravar o usuário: %v", err)
+		}
+	}
+	if _, err := db.Exec(`DELETE FROM schema_migrations WHERE version = 11`); err != nil {
+		t.Fatalf("Erro ao desfazer o registro da migração: %v", err)
+	}
+
+	err = db.Migrate()
+	if err == nil || !strings.Contains(err.Error(), "Alice@Exemplo.com, alice@exemplo.com") {
+		t.Fatalf("esperava a migração interrompida listando os emails em conflito, obteve %v", err)
+	}
+	if strings.Contains(err.Error(), "Bob") {
+		t.Errorf("esperava apenas os emails em conflito listados, obteve %v", err)
+	}
+
+	if _, err := db.Exec(`UPDATE users SET email = 'alice2@exemplo.com' WHERE id = 'user-2'`); err != nil {
+		t.Fatalf("Erro ao resolver o conflito: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("esperava a migração aplicada após resolver o conflito: %v", err)
+	}
+
+	for id, want := range map[string]string{"user-1": "alice@exemplo.com", "user-3": "bob@exemplo.com"} {
+		var email string
+		if err := db.QueryRow(db.rebind(`SELECT email FROM users WHERE id = ?`), id).Scan(&email); err != nil {
+			t.Fatalf("Erro ao ler o email: %v", err)
+		}
+		if email != want {
+			t.Errorf("esperava o email %s normalizado para %s, obteve %s", id, want, email)
+		}
+	}
+}
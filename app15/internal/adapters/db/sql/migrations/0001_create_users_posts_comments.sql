@@ -0,0 +1,32 @@
+CREATE TABLE users (
+	id TEXT PRIMARY KEY,
+	username TEXT NOT NULL UNIQUE,
+	email TEXT NOT NULL UNIQUE,
+	password TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE posts (
+	id TEXT PRIMARY KEY,
+	title TEXT NOT NULL,
+	content TEXT NOT NULL,
+	author_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_posts_author_id ON posts (author_id);
+CREATE INDEX idx_posts_created_at ON posts (created_at);
+
+CREATE TABLE comments (
+	id TEXT PRIMARY KEY,
+	content TEXT NOT NULL,
+	post_id TEXT NOT NULL,
+	author_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_comments_post_id ON comments (post_id);
+CREATE INDEX idx_comments_author_id ON comments (author_id);
@@ -0,0 +1,12 @@
+CREATE TABLE refresh_tokens (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	family_id TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	revoked_at TIMESTAMP NULL,
+	replaced_by TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'author';
+
+CREATE TABLE audit_log (
+	id TEXT PRIMARY KEY,
+	actor_id TEXT NOT NULL,
+	action TEXT NOT NULL,
+	target_type TEXT NOT NULL,
+	target_id TEXT NOT NULL,
+	details TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log (created_at);
@@ -0,0 +1,9 @@
+ALTER TABLE posts ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE post_tags (
+	post_id TEXT NOT NULL,
+	tag TEXT NOT NULL,
+	PRIMARY KEY (post_id, tag)
+);
+
+CREATE INDEX idx_post_tags_tag ON post_tags (tag);
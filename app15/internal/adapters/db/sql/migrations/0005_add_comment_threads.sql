@@ -0,0 +1,5 @@
+ALTER TABLE comments ADD COLUMN parent_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN depth INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN deleted BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_comments_parent_id ON comments (post_id, parent_id);
@@ -0,0 +1,18 @@
+ALTER TABLE posts ADD COLUMN status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE posts ADD COLUMN publish_at TIMESTAMP NULL;
+
+UPDATE posts SET publish_at = created_at;
+
+CREATE INDEX idx_posts_status_publish_at ON posts (status, publish_at);
+
+CREATE TABLE post_revisions (
+	id TEXT PRIMARY KEY,
+	post_id TEXT NOT NULL,
+	version INTEGER NOT NULL,
+	title TEXT NOT NULL,
+	content TEXT NOT NULL,
+	tags TEXT NOT NULL DEFAULT '',
+	editor_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	UNIQUE (post_id, version)
+);
@@ -0,0 +1,27 @@
+CREATE TABLE event_outbox (
+	id TEXT PRIMARY KEY,
+	type TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	occurred_at TIMESTAMP NOT NULL,
+	delivered_at TIMESTAMP NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox (delivered_at, occurred_at);
+
+CREATE TABLE notifications (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL,
+	event_id TEXT NOT NULL,
+	type TEXT NOT NULL,
+	actor_id TEXT NOT NULL DEFAULT '',
+	post_id TEXT NOT NULL,
+	comment_id TEXT NOT NULL DEFAULT '',
+	message TEXT NOT NULL,
+	read_at TIMESTAMP NULL,
+	created_at TIMESTAMP NOT NULL,
+	UNIQUE (user_id, event_id)
+);
+
+CREATE INDEX idx_notifications_user_created_at ON notifications (user_id, created_at);
@@ -0,0 +1,18 @@
+CREATE TABLE login_attempts (
+	id TEXT PRIMARY KEY,
+	user_id TEXT NOT NULL DEFAULT '',
+	email TEXT NOT NULL,
+	ip TEXT NOT NULL,
+	user_agent TEXT NOT NULL DEFAULT '',
+	success BOOLEAN NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_login_attempts_user_created_at ON login_attempts (user_id, created_at);
+
+CREATE TABLE login_lockouts (
+	lockout_key TEXT PRIMARY KEY,
+	failures INTEGER NOT NULL,
+	locked_until TIMESTAMP NULL,
+	updated_at TIMESTAMP NOT NULL
+);
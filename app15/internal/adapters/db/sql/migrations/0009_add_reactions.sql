@@ -0,0 +1,7 @@
+CREATE TABLE reactions (
+	target_type TEXT NOT NULL,
+	target_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (target_type, target_id, user_id)
+);
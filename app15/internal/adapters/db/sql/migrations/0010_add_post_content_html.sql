@@ -0,0 +1,3 @@
+-- Posts gravados antes desta migração ficam sem HTML e são renderizados na leitura
+ALTER TABLE posts ADD COLUMN content_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN excerpt TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+-- Os emails passam a ser gravados em minúsculas, como nas buscas por email. Contas cujos
+-- emails só diferem por maiúsculas ou espaços impedem a migração (ver checkEmailCollisions).
+UPDATE users SET email = LOWER(TRIM(email))
+WHERE email <> LOWER(TRIM(email));
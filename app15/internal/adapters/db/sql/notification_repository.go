@@ -0,0 +1,103 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de notificações
+var (
+	ErrNotificationNotFound = errors.New("notificação não encontrada")
+)
+
+const notificationColumns = `id, user_id, event_id, type, actor_id, post_id, comment_id, message, read_at, created_at`
+
+// NotificationRepository implementa as caixas de notificações sobre database/sql
+type NotificationRepository struct {
+	db *DB
+}
+
+// NewNotificationRepository cria uma nova instância do repositório de notificações em banco de dados
+func NewNotificationRepository(db *DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create registra uma notificação, ignorando repetições do mesmo evento para o mesmo usuário
+func (r *NotificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO notifications (`+notificationColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (user_id, event_id) DO NOTHING`),
+		notification.ID, notification.UserID, notification.EventID, string(notification.Type), notification.ActorID,
+		notification.PostID, notification.CommentID, notification.Message, nullTime(notification.ReadAt), notification.CreatedAt.UTC())
+	return err
+}
+
+// ListByUser retorna uma lista paginada das notificações do usuário, da mais recente para a mais antiga
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]*domain.Notification, error) {
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id = ?`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC, id LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]*domain.Notification, 0)
+	for rows.Next() {
+		var (
+			notification domain.Notification
+			eventType    string
+			readAt       sql.NullTime
+		)
+		err := rows.Scan(&notification.ID, &notification.UserID, &notification.EventID, &eventType, &notification.ActorID,
+			&notification.PostID, &notification.CommentID, &notification.Message, &readAt, &notification.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		notification.Type = domain.EventType(eventType)
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+// CountUnread retorna a quantidade de notificações não lidas do usuário
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL`), userID).
+		Scan(&count)
+	return count, err
+}
+
+// MarkRead marca como lida uma notificação do usuário
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE notifications SET read_at = COALESCE(read_at, ?) WHERE id = ? AND user_id = ?`),
+		at.UTC(), id, userID)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, ErrNotificationNotFound)
+}
+
+// MarkAllRead marca como lidas todas as notificações do usuário
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID string, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`),
+		at.UTC(), userID)
+	return err
+}
+
+// DeleteByUser remove as notificações recebidas e as causadas pelo usuário
+func (r *NotificationRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM notifications WHERE user_id = ? OR actor_id = ?`), userID, userID)
+	return err
+}
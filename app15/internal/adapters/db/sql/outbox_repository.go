@@ -0,0 +1,95 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de eventos pendentes
+var (
+	ErrEventNotFound = errors.New("evento não encontrado")
+)
+
+// OutboxRepository implementa a caixa de saída de eventos sobre database/sql.
+// O evento é guardado em JSON para que novos campos não exijam migrações.
+type OutboxRepository struct {
+	db *DB
+}
+
+// NewOutboxRepository cria uma nova instância da caixa de saída de eventos em banco de dados
+func NewOutboxRepository(db *DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// execer executa comandos no banco ou em uma transação
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// addEvents grava os eventos na caixa de saída por exec, que pode ser a transação
+// em que os outros repositórios gravam a entidade que originou os eventos
+func addEvents(ctx context.Context, db *DB, exec execer, events []*domain.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = exec.ExecContext(ctx, db.rebind(`INSERT INTO event_outbox (id, type, payload, occurred_at) VALUES (?, ?, ?, ?)`),
+			event.ID, string(event.Type), string(payload), event.OccurredAt.UTC())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListPending retorna os eventos não entregues, do mais antigo para o mais recente
+func (r *OutboxRepository) ListPending(ctx context.Context, maxAttempts, limit int) ([]*domain.Event, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT payload FROM event_outbox
+		WHERE delivered_at IS NULL AND attempts < ? ORDER BY occurred_at, id LIMIT ?`), maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*domain.Event, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, err
+		}
+
+		var event domain.Event
+		if err := json.Unmarshal([]byte(payload), &event); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// MarkDelivered marca o evento como entregue
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE event_outbox SET delivered_at = ? WHERE id = ?`), at.UTC(), id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, ErrEventNotFound)
+}
+
+// MarkFailed registra uma falha de entrega do evento
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, reason string) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE event_outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?`), reason, id)
+	if err != nil {
+		return err
+	}
+	return checkAffected(result, ErrEventNotFound)
+}
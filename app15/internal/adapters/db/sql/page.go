@@ -0,0 +1,90 @@
+package sql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// pageQuery descreve uma listagem paginada por cursor sobre uma tabela com as colunas
+// created_at e id, que definem a ordem dos itens e a posição do cursor
+type pageQuery struct {
+	table       string
+	columns     string
+	where       []string
+	args        []interface{}
+	newestFirst bool
+}
+
+// filter acrescenta uma condição à listagem
+func (q *pageQuery) filter(condition string, args ...interface{}) {
+	q.where = append(q.where, condition)
+	q.args = append(q.args, args...)
+}
+
+// selectPage monta a consulta da página solicitada, com um item além do limite
+// para indicar se há uma próxima página
+func (q pageQuery) selectPage(req domain.PageRequest) (string, []interface{}) {
+	where := append([]string{}, q.where...)
+	args := append([]interface{}{}, q.args...)
+
+	order := `created_at, id`
+	if q.newestFirst {
+		order = `created_at DESC, id`
+	}
+
+	if req.After != nil {
+		if q.newestFirst {
+			where = append(where, `(created_at < ? OR (created_at = ? AND id > ?))`)
+		} else {
+			where = append(where, `(created_at > ? OR (created_at = ? AND id > ?))`)
+		}
+		args = append(args, req.After.Time.UTC(), req.After.Time.UTC(), req.After.ID)
+	}
+
+	return `SELECT ` + q.columns + ` FROM ` + q.table + whereClause(where) + ` ORDER BY ` + order + ` LIMIT ?`,
+		append(args, req.Limit+1)
+}
+
+// count conta todos os itens da listagem, ignorando o cursor
+func (q pageQuery) count(ctx context.Context, db *DB) (int, error) {
+	var total int
+	err := db.QueryRowContext(ctx, db.rebind(`SELECT COUNT(*) FROM `+q.table+whereClause(q.where)), q.args...).Scan(&total)
+	return total, err
+}
+
+// fetchPage executa a listagem com a função de leitura do repositório e monta a página
+func fetchPage[T any](
+	ctx context.Context,
+	db *DB,
+	q pageQuery,
+	req domain.PageRequest,
+	list func(ctx context.Context, query string, args ...interface{}) ([]T, error),
+	cursor func(T) domain.Cursor,
+) (*domain.Page[T], error) {
+	query, args := q.selectPage(req)
+	items, err := list(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(items, req.Limit, cursor)
+	if req.WithTotal {
+		total, err := q.count(ctx, db)
+		if err != nil {
+			return nil, err
+		}
+		page.SetTotal(total)
+	}
+
+	return page, nil
+}
+
+// whereClause junta as condições em uma cláusula WHERE, vazia se não houver condições
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(conditions, ` AND `)
+}
@@ -0,0 +1,442 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/db/textsearch"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de posts
+var (
+	ErrPostNotFound = errors.New("post não encontrado")
+)
+
+const postColumns = `id, title, content, content_html, excerpt, author_id, status, publish_at, created_at, updated_at`
+
+// PostRepository implementa o repositório de posts sobre database/sql
+type PostRepository struct {
+	db *DB
+}
+
+// NewPostRepository cria uma nova instância do repositório de posts em banco de dados
+func NewPostRepository(db *DB) *PostRepository {
+	return &PostRepository{db: db}
+}
+
+// Create adiciona um novo post ao repositório, com os eventos na mesma transação
+func (r *PostRepository) Create(ctx context.Context, post *domain.Post, events ...*domain.Event) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, r.db.rebind(`INSERT INTO posts (`+postColumns+`, search_text) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		post.ID, post.Title, post.Content, post.ContentHTML, post.Excerpt, post.AuthorID, string(post.Status), nullTime(post.PublishAt),
+		post.CreatedAt.UTC(), post.UpdatedAt.UTC(), searchText(post))
+	if err != nil {
+		return err
+	}
+
+	if err := r.replaceTags(ctx, tx, post); err != nil {
+		return err
+	}
+
+	if err := addEvents(ctx, r.db, tx, events); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByID busca um post pelo ID
+func (r *PostRepository) GetByID(ctx context.Context, id string) (*domain.Post, error) {
+	post, err := scanPost(r.db.QueryRowContext(ctx, r.db.rebind(`SELECT `+postColumns+` FROM posts WHERE id = ?`), id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPostNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadTags(ctx, []*domain.Post{post}); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// Update atualiza os dados de um post, com os eventos na mesma transação
+func (r *PostRepository) Update(ctx context.Context, post *domain.Post, events ...*domain.Event) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, r.db.rebind(`UPDATE posts SET title = ?, content = ?, content_html = ?, excerpt = ?, author_id = ?, status = ?, publish_at = ?, updated_at = ?, search_text = ? WHERE id = ?`),
+		post.Title, post.Content, post.ContentHTML, post.Excerpt, post.AuthorID, string(post.Status), nullTime(post.PublishAt), post.UpdatedAt.UTC(), searchText(post), post.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAffected(result, ErrPostNotFound); err != nil {
+		return err
+	}
+
+	if err := r.replaceTags(ctx, tx, post); err != nil {
+		return err
+	}
+
+	if err := addEvents(ctx, r.db, tx, events); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Delete remove um post do repositório e, na mesma transação, os seus comentários,
+// curtidas, revisões e tags
+func (r *PostRepository) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, r.db.rebind(`DELETE FROM posts WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAffected(result, ErrPostNotFound); err != nil {
+		return err
+	}
+
+	// As curtidas nos comentários são removidas antes dos comentários que as identificam
+	_, err = tx.ExecContext(ctx, r.db.rebind(`DELETE FROM reactions WHERE (target_type = ? AND target_id = ?)
+		OR (target_type = ? AND target_id IN (SELECT id FROM comments WHERE post_id = ?))`),
+		string(domain.ReactionTargetPost), id, string(domain.ReactionTargetComment), id)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{`comments`, `post_revisions`, `post_tags`} {
+		if _, err := tx.ExecContext(ctx, r.db.rebind(`DELETE FROM `+table+` WHERE post_id = ?`), id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReassignAuthor transfere a autoria de todos os posts de fromID para toID
+func (r *PostRepository) ReassignAuthor(ctx context.Context, fromID, toID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE posts SET author_id = ? WHERE author_id = ?`), toID, fromID)
+	return err
+}
+
+// List retorna uma página de posts com o status informado, do mais recente para o mais antigo
+func (r *PostRepository) List(ctx context.Context, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	q := pageQuery{table: `posts`, columns: postColumns, newestFirst: true}
+	if status != "" {
+		q.filter(`status = ?`, string(status))
+	}
+	return fetchPage(ctx, r.db, q, req, r.list, postCursor)
+}
+
+// ListByAuthor retorna uma página de posts de um autor específico com o status informado
+func (r *PostRepository) ListByAuthor(ctx context.Context, authorID string, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	q := pageQuery{table: `posts`, columns: postColumns, newestFirst: true}
+	q.filter(`author_id = ?`, authorID)
+	if status != "" {
+		q.filter(`status = ?`, string(status))
+	}
+	return fetchPage(ctx, r.db, q, req, r.list, postCursor)
+}
+
+// ListPublishedEntries retorna o ID e a última alteração dos posts publicados mais recentes
+func (r *PostRepository) ListPublishedEntries(ctx context.Context, limit int) ([]domain.PostEntry, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT id, updated_at FROM posts WHERE status = ?
+		ORDER BY created_at DESC, id LIMIT ?`), string(domain.PostStatusPublished), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.PostEntry, 0)
+	for rows.Next() {
+		var entry domain.PostEntry
+		if err := rows.Scan(&entry.ID, &entry.UpdatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// ListScheduledDue retorna os posts agendados com data de publicação vencida, da mais antiga para a mais recente
+func (r *PostRepository) ListScheduledDue(ctx context.Context, now time.Time) ([]*domain.Post, error) {
+	return r.list(ctx, `SELECT `+postColumns+` FROM posts WHERE status = ? AND publish_at <= ? ORDER BY publish_at, id`,
+		string(domain.PostStatusScheduled), now.UTC())
+}
+
+// ListPopular lista os posts publicados do mais popular para o menos popular no instante
+// rankedAt. As curtidas e a pontuação, com a mesma fórmula de domain.PopularityScore, são
+// calculadas pelo banco, de modo que a ordem e o cursor cobrem todos os posts publicados.
+func (r *PostRepository) ListPopular(ctx context.Context, rankedAt time.Time, req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+	query := `SELECT ` + postColumns + `, likes, score FROM (
+		SELECT counted.*, likes / POWER(CAST(CASE WHEN age > 0 THEN age ELSE 0 END AS DOUBLE PRECISION) / 3600 + 2, 1.5) AS score FROM (
+			SELECT p.*, COUNT(re.user_id) AS likes, ? - ` + r.db.unixSeconds(`COALESCE(p.publish_at, p.created_at)`) + ` AS age FROM posts p
+			LEFT JOIN reactions re ON re.target_type = ? AND re.target_id = p.id
+			WHERE p.status = ? GROUP BY p.id) counted) ranked`
+	args := []interface{}{rankedAt.Unix(), string(domain.ReactionTargetPost), string(domain.PostStatusPublished)}
+	if req.After != nil {
+		query += ` WHERE score < ? OR (score = ? AND id > ?)`
+		args = append(args, req.After.Score, req.After.Score, req.After.ID)
+	}
+	query += ` ORDER BY score DESC, id LIMIT ?`
+	args = append(args, req.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := make([]*domain.Post, 0)
+	scores := make(map[string]float64)
+	for rows.Next() {
+		var likes int
+		var score float64
+		post, err := scanPost(popularScanner{scanner: rows, likes: &likes, score: &score})
+		if err != nil {
+			return nil, err
+		}
+		post.Likes = likes
+		scores[post.ID] = score
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadTags(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	page := domain.NewPage(posts, req.Limit, func(post *domain.Post) domain.Cursor {
+		return domain.Cursor{Time: rankedAt, Score: scores[post.ID], ID: post.ID}
+	})
+	if req.WithTotal {
+		q := pageQuery{table: `posts`}
+		q.filter(`status = ?`, string(domain.PostStatusPublished))
+		total, err := q.count(ctx, r.db)
+		if err != nil {
+			return nil, err
+		}
+		page.SetTotal(total)
+	}
+
+	return page, nil
+}
+
+// Search busca posts por termos e tags. O banco seleciona os candidatos pelo texto
+// normalizado e a relevância é calculada com o mesmo índice do adaptador em memória.
+func (r *PostRepository) Search(ctx context.Context, query string, tags []string, req domain.PageRequest) (*domain.Page[*domain.PostSearchResult], error) {
+	tags = domain.NormalizeTags(tags)
+	terms := domain.Tokenize(query)
+
+	where := []string{`status = ?`}
+	args := []interface{}{string(domain.PostStatusPublished)}
+	if len(tags) > 0 {
+		where = append(where, `id IN (SELECT post_id FROM post_tags WHERE tag IN (`+placeholders(len(tags))+`)
+			GROUP BY post_id HAVING COUNT(*) = ?)`)
+		for _, tag := range tags {
+			args = append(args, tag)
+		}
+		args = append(args, len(tags))
+	}
+
+	// Sem termos, apenas filtra pelas tags com paginação feita pelo banco
+	if len(terms) == 0 {
+		query := `SELECT ` + postColumns + ` FROM posts` + whereClause(where) + ` ORDER BY created_at DESC, id LIMIT ? OFFSET ?`
+
+		posts, err := r.list(ctx, query, append(append([]interface{}{}, args...), req.Limit+1, textsearch.Offset(req))...)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*domain.PostSearchResult, len(posts))
+		for i, post := range posts {
+			results[i] = &domain.PostSearchResult{Post: post}
+		}
+
+		page := domain.NewPage(results, req.Limit, textsearch.NextOffset(req))
+		if req.WithTotal {
+			var total int
+			if err := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT COUNT(*) FROM posts`+whereClause(where)), args...).Scan(&total); err != nil {
+				return nil, err
+			}
+			page.SetTotal(total)
+		}
+		return page, nil
+	}
+
+	matches := make([]string, 0, len(terms))
+	for _, term := range terms {
+		matches = append(matches, `search_text LIKE ?`)
+		args = append(args, "% "+term+" %")
+	}
+	where = append(where, `(`+strings.Join(matches, ` OR `)+`)`)
+
+	candidates, err := r.list(ctx, `SELECT `+postColumns+` FROM posts WHERE `+strings.Join(where, ` AND `), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT COUNT(*) FROM posts WHERE status = ?`), string(domain.PostStatusPublished)).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	index := textsearch.NewIndex()
+	byID := make(map[string]*domain.Post, len(candidates))
+	for _, post := range candidates {
+		index.Put(post.ID, post.Title, post.Content)
+		byID[post.ID] = post
+	}
+
+	results := make([]*domain.PostSearchResult, 0, len(candidates))
+	for id, score := range index.Score(terms, total) {
+		results = append(results, &domain.PostSearchResult{Post: byID[id], Score: score})
+	}
+
+	return textsearch.RankAndPaginate(results, req), nil
+}
+
+// list executa uma consulta que retorna vários posts, já com suas tags
+func (r *PostRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.Post, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := make([]*domain.Post, 0)
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadTags(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+// postCursor gera a posição de um post nas listagens em ordem cronológica
+func postCursor(post *domain.Post) domain.Cursor {
+	return domain.Cursor{Time: post.CreatedAt, ID: post.ID}
+}
+
+// popularScanner lê as curtidas e a pontuação das colunas seguintes às colunas do post
+type popularScanner struct {
+	scanner
+	likes *int
+	score *float64
+}
+
+// Scan lê as colunas do post e, em seguida, as curtidas e a pontuação
+func (s popularScanner) Scan(dest ...interface{}) error {
+	return s.scanner.Scan(append(dest, s.likes, s.score)...)
+}
+
+// loadTags preenche as tags dos posts com uma única consulta
+func (r *PostRepository) loadTags(ctx context.Context, posts []*domain.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Post, len(posts))
+	args := make([]interface{}, 0, len(posts))
+	for _, post := range posts {
+		post.Tags = []string{}
+		byID[post.ID] = post
+		args = append(args, post.ID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT post_id, tag FROM post_tags WHERE post_id IN (`+placeholders(len(args))+`) ORDER BY tag`), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID, tag string
+		if err := rows.Scan(&postID, &tag); err != nil {
+			return err
+		}
+		byID[postID].Tags = append(byID[postID].Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+// replaceTags substitui as tags armazenadas do post pelas tags atuais
+func (r *PostRepository) replaceTags(ctx context.Context, tx *sql.Tx, post *domain.Post) error {
+	if _, err := tx.ExecContext(ctx, r.db.rebind(`DELETE FROM post_tags WHERE post_id = ?`), post.ID); err != nil {
+		return err
+	}
+
+	for _, tag := range domain.NormalizeTags(post.Tags) {
+		if _, err := tx.ExecContext(ctx, r.db.rebind(`INSERT INTO post_tags (post_id, tag) VALUES (?, ?)`), post.ID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// searchText gera o texto normalizado usado para selecionar candidatos na busca.
+// Os termos são delimitados por espaços para permitir a comparação de termos inteiros.
+func searchText(post *domain.Post) string {
+	terms := domain.Tokenize(post.Title + " " + post.Content)
+	if len(terms) == 0 {
+		return ""
+	}
+	return " " + strings.Join(terms, " ") + " "
+}
+
+// scanPost lê um post de uma linha do resultado
+func scanPost(s scanner) (*domain.Post, error) {
+	var (
+		post      domain.Post
+		status    string
+		publishAt sql.NullTime
+	)
+	err := s.Scan(&post.ID, &post.Title, &post.Content, &post.ContentHTML, &post.Excerpt, &post.AuthorID, &status, &publishAt, &post.CreatedAt, &post.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	post.Status = domain.PostStatus(status)
+	if publishAt.Valid {
+		post.PublishAt = &publishAt.Time
+	}
+	return &post, nil
+}
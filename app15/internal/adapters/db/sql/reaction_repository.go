@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// ReactionRepository implementa o repositório de reações sobre database/sql. A chave
+// primária (conteúdo, usuário) garante uma reação por usuário, e as contagens são
+// feitas sobre as linhas gravadas, corretas mesmo com curtidas simultâneas.
+type ReactionRepository struct {
+	db *DB
+}
+
+// NewReactionRepository cria uma nova instância do repositório de reações em banco de dados
+func NewReactionRepository(db *DB) *ReactionRepository {
+	return &ReactionRepository{db: db}
+}
+
+// Add registra a reação; retorna false se o usuário já havia reagido ao conteúdo
+func (r *ReactionRepository) Add(ctx context.Context, reaction *domain.Reaction) (bool, error) {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO reactions (target_type, target_id, user_id, created_at)
+		VALUES (?, ?, ?, ?) ON CONFLICT (target_type, target_id, user_id) DO NOTHING`),
+		string(reaction.TargetType), reaction.TargetID, reaction.UserID, reaction.CreatedAt.UTC())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Remove apaga a reação do usuário ao conteúdo; retorna false se ela não existia
+func (r *ReactionRepository) Remove(ctx context.Context, userID string, targetType domain.ReactionTarget, targetID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM reactions WHERE target_type = ? AND target_id = ? AND user_id = ?`),
+		string(targetType), targetID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// CountByTargets conta as reações de cada conteúdo informado com uma única consulta
+func (r *ReactionRepository) CountByTargets(ctx context.Context, targetType domain.ReactionTarget, targetIDs []string) (map[string]int, error) {
+	counts := make(map[string]int, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+
+	args := make([]interface{}, 0, len(targetIDs)+1)
+	args = append(args, string(targetType))
+	for _, id := range targetIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT target_id, COUNT(*) FROM reactions
+		WHERE target_type = ? AND target_id IN (`+placeholders(len(targetIDs))+`) GROUP BY target_id`), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		counts[id] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// DeleteByUser remove todas as reações do usuário
+func (r *ReactionRepository) DeleteByUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM reactions WHERE user_id = ?`), userID)
+	return err
+}
@@ -0,0 +1,58 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories/repositorytest"
+)
+
+func TestSQLiteRepositoryContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "blog.db"))
+		if err != nil {
+			t.Fatalf("Erro ao abrir banco SQLite: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return newRepositories(db)
+	})
+}
+
+// TestPostgresRepositoryContract roda apenas quando TEST_POSTGRES_DSN aponta para um banco descartável
+func TestPostgresRepositoryContract(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN não definido")
+	}
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db, err := Open(DriverPostgres, dsn)
+		if err != nil {
+			t.Fatalf("Erro ao abrir banco PostgreSQL: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		for _, table := range []string{"reactions", "login_lockouts", "login_attempts", "notifications", "event_outbox", "post_revisions", "audit_log", "refresh_tokens", "comments", "post_tags", "posts", "users"} {
+			if _, err := db.Exec(fmt.Sprintf("DELETE FROM %s", table)); err != nil {
+				t.Fatalf("Erro ao limpar tabela %s: %v", table, err)
+			}
+		}
+		return newRepositories(db)
+	})
+}
+
+func newRepositories(db *DB) repositorytest.Repositories {
+	return repositorytest.Repositories{
+		Users:         NewUserRepository(db),
+		Posts:         NewPostRepository(db),
+		Comments:      NewCommentRepository(db),
+		Tokens:        NewTokenRepository(db),
+		Audit:         NewAuditRepository(db),
+		Revisions:     NewRevisionRepository(db),
+		Outbox:        NewOutboxRepository(db),
+		Notifications: NewNotificationRepository(db),
+		LoginAttempts: NewLoginAttemptRepository(db),
+		Reactions:     NewReactionRepository(db),
+	}
+}
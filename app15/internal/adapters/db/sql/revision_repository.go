@@ -0,0 +1,129 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Erros específicos do repositório de revisões
+var (
+	ErrRevisionNotFound = errors.New("revisão não encontrada")
+	ErrRevisionExists   = errors.New("revisão já existe")
+)
+
+const revisionColumns = `id, post_id, version, title, content, tags, editor_id, created_at`
+
+// RevisionRepository implementa o histórico de revisões de posts sobre database/sql
+type RevisionRepository struct {
+	db *DB
+}
+
+// NewRevisionRepository cria uma nova instância do repositório de revisões em banco de dados
+func NewRevisionRepository(db *DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// Create registra uma nova revisão
+func (r *RevisionRepository) Create(ctx context.Context, revision *domain.PostRevision) error {
+	tags, err := json.Marshal(revision.Tags)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists int
+	err = tx.QueryRowContext(ctx, r.db.rebind(`SELECT 1 FROM post_revisions WHERE post_id = ? AND version = ?`),
+		revision.PostID, revision.Version).Scan(&exists)
+	if err == nil {
+		return ErrRevisionExists
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, r.db.rebind(`INSERT INTO post_revisions (`+revisionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		revision.ID, revision.PostID, revision.Version, revision.Title, revision.Content, string(tags), revision.EditorID, revision.CreatedAt.UTC())
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetByVersion busca uma revisão de um post pelo número da versão
+func (r *RevisionRepository) GetByVersion(ctx context.Context, postID string, version int) (*domain.PostRevision, error) {
+	return r.get(ctx, `SELECT `+revisionColumns+` FROM post_revisions WHERE post_id = ? AND version = ?`, postID, version)
+}
+
+// GetLatest busca a revisão mais recente de um post
+func (r *RevisionRepository) GetLatest(ctx context.Context, postID string) (*domain.PostRevision, error) {
+	return r.get(ctx, `SELECT `+revisionColumns+` FROM post_revisions WHERE post_id = ? ORDER BY version DESC LIMIT 1`, postID)
+}
+
+// ListByPost retorna uma lista paginada das revisões de um post, da mais recente para a mais antiga
+func (r *RevisionRepository) ListByPost(ctx context.Context, postID string, page, pageSize int) ([]*domain.PostRevision, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(`SELECT `+revisionColumns+` FROM post_revisions WHERE post_id = ? ORDER BY version DESC LIMIT ? OFFSET ?`),
+		postID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]*domain.PostRevision, 0)
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// ReassignEditor transfere para toID todas as revisões editadas por fromID
+func (r *RevisionRepository) ReassignEditor(ctx context.Context, fromID, toID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE post_revisions SET editor_id = ? WHERE editor_id = ?`), toID, fromID)
+	return err
+}
+
+// get executa uma consulta que retorna uma única revisão
+func (r *RevisionRepository) get(ctx context.Context, query string, args ...interface{}) (*domain.PostRevision, error) {
+	revision, err := scanRevision(r.db.QueryRowContext(ctx, r.db.rebind(query), args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, err
+}
+
+// scanRevision lê uma revisão de uma linha do resultado
+func scanRevision(s scanner) (*domain.PostRevision, error) {
+	var (
+		revision domain.PostRevision
+		tags     string
+	)
+	err := s.Scan(&revision.ID, &revision.PostID, &revision.Version, &revision.Title, &revision.Content, &tags, &revision.EditorID, &revision.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if tags != "" {
+		if err := json.Unmarshal([]byte(tags), &revision.Tags); err != nil {
+			return nil, err
+		}
+	}
+	if revision.Tags == nil {
+		revision.Tags = []string{}
+	}
+
+	return &revision, nil
+}
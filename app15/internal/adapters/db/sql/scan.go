@@ -0,0 +1,37 @@
+package sql
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// scanner abstrai *sql.Row e *sql.Rows na leitura de registros
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// checkAffected retorna notFound quando o comando não alterou nenhum registro
+func checkAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// nullTime converte um ponteiro de data opcional para um valor aceito pelos drivers
+func nullTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// placeholders gera uma lista com n placeholders "?" separados por vírgula
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
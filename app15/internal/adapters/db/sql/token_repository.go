@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Erros específicos do repositório de tokens
+var (
+	ErrTokenNotFound = errors.New("token não encontrado")
+)
+
+const tokenColumns = `id, user_id, family_id, token_hash, expires_at, created_at, revoked_at, replaced_by`
+
+// TokenRepository implementa o repositório de tokens de renovação sobre database/sql
+type TokenRepository struct {
+	db *DB
+}
+
+// NewTokenRepository cria uma nova instância do repositório de tokens em banco de dados
+func NewTokenRepository(db *DB) *TokenRepository {
+	return &TokenRepository{db: db}
+}
+
+// Create adiciona um novo token ao repositório
+func (r *TokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO refresh_tokens (`+tokenColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		token.ID, token.UserID, token.FamilyID, token.TokenHash, token.ExpiresAt.UTC(), token.CreatedAt.UTC(),
+		nullTime(token.RevokedAt), token.ReplacedBy)
+	return err
+}
+
+// GetByHash busca um token pelo hash do seu valor
+func (r *TokenRepository) GetByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	var (
+		token     domain.RefreshToken
+		revokedAt sql.NullTime
+	)
+	err := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT `+tokenColumns+` FROM refresh_tokens WHERE token_hash = ?`), hash).
+		Scan(&token.ID, &token.UserID, &token.FamilyID, &token.TokenHash, &token.ExpiresAt, &token.CreatedAt, &revokedAt, &token.ReplacedBy)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		token.RevokedAt = &revokedAt.Time
+	}
+
+	return &token, nil
+}
+
+// Revoke revoga um único token, apenas se ele ainda estiver ativo
+func (r *TokenRepository) Revoke(ctx context.Context, id, replacedBy string) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE refresh_tokens SET revoked_at = ?, replaced_by = ? WHERE id = ? AND revoked_at IS NULL`),
+		time.Now().UTC(), replacedBy, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	// Diferenciar token inexistente de token já revogado
+	var exists int
+	err = r.db.QueryRowContext(ctx, r.db.rebind(`SELECT 1 FROM refresh_tokens WHERE id = ?`), id).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTokenNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return repositories.ErrTokenAlreadyRevoked
+}
+
+// RevokeFamily revoga todos os tokens ativos de uma família
+func (r *TokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`),
+		time.Now().UTC(), familyID)
+	return err
+}
+
+// RevokeByUser revoga todos os tokens ativos do usuário
+func (r *TokenRepository) RevokeByUser(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`),
+		time.Now().UTC(), userID)
+	return err
+}
+
+// IsFamilyActive informa se a família possui algum token não revogado
+func (r *TokenRepository) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	var exists int
+	err := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT 1 FROM refresh_tokens WHERE family_id = ? AND revoked_at IS NULL LIMIT 1`), familyID).
+		Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
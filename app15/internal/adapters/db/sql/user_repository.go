@@ -0,0 +1,201 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Erros específicos do repositório de usuários
+var (
+	ErrUserNotFound          = errors.New("usuário não encontrado")
+	ErrEmailAlreadyExists    = errors.New("email já cadastrado")
+	ErrUsernameAlreadyExists = errors.New("nome de usuário já cadastrado")
+)
+
+const userColumns = `id, username, email, password, role, created_at, updated_at`
+
+// UserRepository implementa o repositório de usuários sobre database/sql
+type UserRepository struct {
+	db *DB
+}
+
+// NewUserRepository cria uma nova instância do repositório de usuários em banco de dados
+func NewUserRepository(db *DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// Create adiciona um novo usuário ao repositório
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	if err := r.checkUnique(ctx, user); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, r.db.rebind(`INSERT INTO users (`+userColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		user.ID, user.Username, domain.NormalizeEmail(user.Email), user.Password, user.Role, user.CreatedAt.UTC(), user.UpdatedAt.UTC())
+	return err
+}
+
+// GetByID busca um usuário pelo ID
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE id = ?`, id)
+}
+
+// GetByIDs busca em uma única consulta os usuários com os IDs informados
+func (r *UserRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	if len(ids) == 0 {
+		return []*domain.User{}, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return r.list(ctx, `SELECT `+userColumns+` FROM users WHERE id IN (`+placeholders(len(ids))+`)`, args...)
+}
+
+// GetByEmail busca um usuário pelo email, gravado e comparado já padronizado
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE email = ?`, domain.NormalizeEmail(email))
+}
+
+// GetByUsername busca um usuário pelo nome de usuário
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.getOne(ctx, `SELECT `+userColumns+` FROM users WHERE username = ?`, username)
+}
+
+// Update atualiza os dados de um usuário
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	if err := r.checkUnique(ctx, user); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`UPDATE users SET username = ?, email = ?, password = ?, role = ?, updated_at = ? WHERE id = ?`),
+		user.Username, domain.NormalizeEmail(user.Email), user.Password, user.Role, user.UpdatedAt.UTC(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, ErrUserNotFound)
+}
+
+// UpdateRole grava o papel do usuário em uma transação que conta os administradores
+// depois da gravação e a desfaz se não restar nenhum. No SQLite o UPDATE obtém o bloqueio
+// de escrita do banco; no PostgreSQL as linhas dos administradores são bloqueadas com
+// FOR UPDATE antes da gravação. Rebaixamentos simultâneos são assim serializados.
+func (r *UserRepository) UpdateRole(ctx context.Context, user *domain.User) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if r.db.driver == DriverPostgres {
+		rows, err := tx.QueryContext(ctx, r.db.rebind(`SELECT id FROM users WHERE role = ? FOR UPDATE`), domain.RoleAdmin)
+		if err != nil {
+			return err
+		}
+		rows.Close()
+	}
+
+	result, err := tx.ExecContext(ctx, r.db.rebind(`UPDATE users SET role = ?, updated_at = ? WHERE id = ?`),
+		user.Role, user.UpdatedAt.UTC(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAffected(result, ErrUserNotFound); err != nil {
+		return err
+	}
+
+	var admins int
+	if err := tx.QueryRowContext(ctx, r.db.rebind(`SELECT COUNT(*) FROM users WHERE role = ?`), domain.RoleAdmin).Scan(&admins); err != nil {
+		return err
+	}
+	if admins == 0 {
+		return repositories.ErrLastAdmin
+	}
+
+	return tx.Commit()
+}
+
+// Delete remove um usuário do repositório
+func (r *UserRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, r.db.rebind(`DELETE FROM users WHERE id = ?`), id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, ErrUserNotFound)
+}
+
+// List retorna uma página de usuários, em ordem de cadastro
+func (r *UserRepository) List(ctx context.Context, req domain.PageRequest) (*domain.Page[*domain.User], error) {
+	return fetchPage(ctx, r.db, pageQuery{table: `users`, columns: userColumns}, req, r.list, userCursor)
+}
+
+// checkUnique verifica se email e nome de usuário não pertencem a outro usuário
+func (r *UserRepository) checkUnique(ctx context.Context, user *domain.User) error {
+	var email, username string
+	normalized := domain.NormalizeEmail(user.Email)
+	row := r.db.QueryRowContext(ctx, r.db.rebind(`SELECT email, username FROM users WHERE (email = ? OR username = ?) AND id <> ? LIMIT 1`),
+		normalized, user.Username, user.ID)
+	switch err := row.Scan(&email, &username); {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return err
+	case email == normalized:
+		return ErrEmailAlreadyExists
+	default:
+		return ErrUsernameAlreadyExists
+	}
+}
+
+// getOne executa uma consulta que retorna no máximo um usuário
+func (r *UserRepository) getOne(ctx context.Context, query string, args ...interface{}) (*domain.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, r.db.rebind(query), args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// list executa uma consulta que retorna vários usuários
+func (r *UserRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain.User, error) {
+	rows, err := r.db.QueryContext(ctx, r.db.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]*domain.User, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// userCursor gera a posição de um usuário na listagem em ordem de cadastro
+func userCursor(user *domain.User) domain.Cursor {
+	return domain.Cursor{Time: user.CreatedAt, ID: user.ID}
+}
+
+// scanUser lê um usuário de uma linha do resultado
+func scanUser(s scanner) (*domain.User, error) {
+	var user domain.User
+	err := s.Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,94 @@
+// Package textsearch implementa o índice invertido compartilhado pelos
+// adaptadores de repositório para a busca textual de posts.
+package textsearch
+
+import (
+	"math"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// titleWeight é o peso de um termo do título em relação a um termo do conteúdo
+const titleWeight = 3
+
+// Index é um índice invertido de documentos com título e conteúdo.
+// Não é seguro para uso concorrente; o chamador deve sincronizar o acesso.
+type Index struct {
+	postings map[string]map[string]int // termo -> documento -> frequência ponderada
+	docTerms map[string][]string       // documento -> termos indexados
+}
+
+// NewIndex cria um índice vazio
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[string]int),
+		docTerms: make(map[string][]string),
+	}
+}
+
+// Put indexa (ou reindexa) um documento
+func (i *Index) Put(id, title, content string) {
+	i.Remove(id)
+
+	frequencies := make(map[string]int)
+	for _, term := range domain.Tokenize(title) {
+		frequencies[term] += titleWeight
+	}
+	for _, term := range domain.Tokenize(content) {
+		frequencies[term]++
+	}
+
+	terms := make([]string, 0, len(frequencies))
+	for term, freq := range frequencies {
+		if i.postings[term] == nil {
+			i.postings[term] = make(map[string]int)
+		}
+		i.postings[term][id] = freq
+		terms = append(terms, term)
+	}
+	i.docTerms[id] = terms
+}
+
+// Remove retira um documento do índice
+func (i *Index) Remove(id string) {
+	for _, term := range i.docTerms[id] {
+		delete(i.postings[term], id)
+		if len(i.postings[term]) == 0 {
+			delete(i.postings, term)
+		}
+	}
+	delete(i.docTerms, id)
+}
+
+// Len retorna o número de documentos indexados
+func (i *Index) Len() int {
+	return len(i.docTerms)
+}
+
+// Score calcula a relevância TF-IDF de cada documento que contém ao menos um dos termos.
+// total é o número de documentos da coleção usado no cálculo do IDF; quando zero, usa Len.
+func (i *Index) Score(terms []string, total int) map[string]float64 {
+	if total <= 0 {
+		total = i.Len()
+	}
+
+	scores := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+
+		docs := i.postings[term]
+		if len(docs) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + float64(total)/float64(len(docs)))
+		for id, freq := range docs {
+			scores[id] += (1 + math.Log(float64(freq))) * idf
+		}
+	}
+	return scores
+}
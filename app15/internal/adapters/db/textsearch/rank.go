@@ -0,0 +1,56 @@
+package textsearch
+
+import (
+	"sort"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// RankAndPaginate ordena os resultados por relevância, depois do mais recente para o
+// mais antigo, e retorna a página solicitada. O cursor da página guarda o deslocamento.
+func RankAndPaginate(results []*domain.PostSearchResult, req domain.PageRequest) *domain.Page[*domain.PostSearchResult] {
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if !a.Post.CreatedAt.Equal(b.Post.CreatedAt) {
+			return a.Post.CreatedAt.After(b.Post.CreatedAt)
+		}
+		return a.Post.ID < b.Post.ID
+	})
+
+	// Um item além do limite indica que há uma próxima página
+	startIndex := Offset(req)
+	endIndex := startIndex + req.Limit + 1
+
+	if startIndex > len(results) {
+		startIndex = len(results)
+	}
+
+	if endIndex > len(results) {
+		endIndex = len(results)
+	}
+
+	page := domain.NewPage(results[startIndex:endIndex], req.Limit, NextOffset(req))
+	if req.WithTotal {
+		page.SetTotal(len(results))
+	}
+
+	return page
+}
+
+// Offset retorna o deslocamento da página solicitada
+func Offset(req domain.PageRequest) int {
+	if req.After == nil {
+		return 0
+	}
+	return req.After.Offset
+}
+
+// NextOffset gera o cursor da página seguinte a partir do deslocamento da página solicitada
+func NextOffset(req domain.PageRequest) func(*domain.PostSearchResult) domain.Cursor {
+	return func(*domain.PostSearchResult) domain.Cursor {
+		return domain.Cursor{Offset: Offset(req) + req.Limit}
+	}
+}
@@ -0,0 +1,116 @@
+// Package events implementa o barramento de eventos de domínio sobre a caixa de saída
+// persistida (outbox), para que eventos ainda não entregues sobrevivam a reinícios.
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	eventports "github.com/caiquemiranda/go-apps/app15/internal/ports/events"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Limites da entrega de eventos
+const (
+	// batchSize é a quantidade máxima de eventos entregues por rodada
+	batchSize = 100
+	// maxAttempts é a quantidade de falhas após a qual um evento deixa de ser reenviado
+	maxAttempts = 5
+)
+
+// OutboxBus entrega aos assinantes, em segundo plano, os eventos que os repositórios
+// gravaram na caixa de saída, marcando cada evento como entregue depois que todos os handlers do
+// seu tipo terminam sem erro
+type OutboxBus struct {
+	outbox   repositories.OutboxRepository
+	handlers map[domain.EventType][]eventports.Handler
+	wake     chan struct{}
+	mu       sync.RWMutex
+}
+
+// NewOutboxBus cria um barramento de eventos sobre a caixa de saída informada
+func NewOutboxBus(outbox repositories.OutboxRepository) *OutboxBus {
+	return &OutboxBus{
+		outbox:   outbox,
+		handlers: make(map[domain.EventType][]eventports.Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Subscribe registra um handler para os eventos do tipo informado
+func (b *OutboxBus) Subscribe(eventType domain.EventType, handler eventports.Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Notify antecipa a próxima rodada de entrega, para eventos gravados pelos repositórios
+func (b *OutboxBus) Notify() {
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run entrega os eventos pendentes a cada interval, ou logo após um Notify,
+// até o contexto ser cancelado
+func (b *OutboxBus) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.Dispatch(ctx); err != nil {
+			log.Printf("Erro ao entregar eventos: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-b.wake:
+		}
+	}
+}
+
+// Dispatch entrega uma rodada de eventos pendentes aos assinantes
+func (b *OutboxBus) Dispatch(ctx context.Context) error {
+	pending, err := b.outbox.ListPending(ctx, maxAttempts, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range pending {
+		if err := b.deliver(ctx, event); err != nil {
+			log.Printf("Erro ao entregar evento %s (%s): %v", event.ID, event.Type, err)
+			if err := b.outbox.MarkFailed(ctx, event.ID, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := b.outbox.MarkDelivered(ctx, event.ID, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver executa os handlers do tipo do evento, parando no primeiro erro
+func (b *OutboxBus) deliver(ctx context.Context, event *domain.Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
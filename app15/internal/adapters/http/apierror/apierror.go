@@ -0,0 +1,180 @@
+// Package apierror define o envelope JSON de erros da API e os códigos estáveis
+// que os clientes usam para identificar cada tipo de erro.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Code identifica um tipo de erro de forma estável; as mensagens podem mudar, os códigos não
+type Code string
+
+// Códigos de erro retornados pela API
+const (
+	CodeInvalidJSON             Code = "invalid_json"
+	CodeValidationFailed        Code = "validation_failed"
+	CodeInvalidParameter        Code = "invalid_parameter"
+	CodeInvalidCursor           Code = "invalid_cursor"
+	CodeUnauthenticated         Code = "unauthenticated"
+	CodeInvalidToken            Code = "invalid_token"
+	CodeTokenRevoked            Code = "token_revoked"
+	CodeRefreshTokenReused      Code = "refresh_token_reused"
+	CodeInvalidCredentials      Code = "invalid_credentials"
+	CodeAccountLocked           Code = "account_locked"
+	CodeTooManyAttempts         Code = "too_many_login_attempts"
+	CodeForbidden               Code = "forbidden"
+	CodeUserNotFound            Code = "user_not_found"
+	CodeUserAlreadyExists       Code = "user_already_exists"
+	CodeEmailInUse              Code = "email_in_use"
+	CodeUsernameInUse           Code = "username_in_use"
+	CodeInvalidPassword         Code = "invalid_password"
+	CodeInvalidRole             Code = "invalid_role"
+	CodeLastAdmin               Code = "last_admin"
+	CodePostNotFound            Code = "post_not_found"
+	CodeInvalidPostData         Code = "invalid_post_data"
+	CodeInvalidPostStatus       Code = "invalid_post_status"
+	CodeInvalidPublishAt        Code = "invalid_publish_at"
+	CodeInvalidStatusTransition Code = "invalid_status_transition"
+	CodeRevisionNotFound        Code = "revision_not_found"
+	CodeCommentNotFound         Code = "comment_not_found"
+	CodeParentNotFound          Code = "parent_not_found"
+	CodeInvalidCommentData      Code = "invalid_comment_data"
+	CodeMaxDepthExceeded        Code = "max_depth_exceeded"
+	CodeNotificationNotFound    Code = "notification_not_found"
+	CodeRouteNotFound           Code = "route_not_found"
+	CodeMethodNotAllowed        Code = "method_not_allowed"
+	CodeRateLimited             Code = "rate_limited"
+	CodeInternal                Code = "internal_error"
+)
+
+// FieldError descreve o problema de um campo específico da requisição
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error é um erro da API com o status HTTP e o conteúdo do envelope de resposta
+type Error struct {
+	Status  int          `json:"-"`
+	Code    Code         `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// Error implementa a interface error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// envelope é o corpo JSON de todas as respostas de erro: {"error": {...}}
+type envelope struct {
+	Error *Error `json:"error"`
+}
+
+// Erros comuns da camada HTTP
+var (
+	ErrInvalidJSON      = New(http.StatusBadRequest, CodeInvalidJSON, "Formato de requisição inválido")
+	ErrUnauthenticated  = New(http.StatusUnauthorized, CodeUnauthenticated, "Usuário não autenticado")
+	ErrRouteNotFound    = New(http.StatusNotFound, CodeRouteNotFound, "Rota não encontrada")
+	ErrMethodNotAllowed = New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "Método não permitido")
+	ErrTooManyRequests  = New(http.StatusTooManyRequests, CodeRateLimited, "Limite de requisições excedido, tente novamente mais tarde")
+	ErrInternal         = New(http.StatusInternalServerError, CodeInternal, "Erro interno do servidor")
+)
+
+// New cria um erro da API
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Validation cria um erro de validação com os problemas de cada campo
+func Validation(fields []FieldError) *Error {
+	return &Error{
+		Status:  http.StatusBadRequest,
+		Code:    CodeValidationFailed,
+		Message: "Dados da requisição inválidos",
+		Fields:  fields,
+	}
+}
+
+// InvalidParameter cria um erro para um parâmetro de URL ou de query string inválido
+func InvalidParameter(name, message string) *Error {
+	return &Error{
+		Status:  http.StatusBadRequest,
+		Code:    CodeInvalidParameter,
+		Message: message,
+		Fields:  []FieldError{{Field: name, Code: "invalid", Message: message}},
+	}
+}
+
+// mapping associa um erro da aplicação ao status HTTP e ao código da API
+type mapping struct {
+	err    error
+	status int
+	code   Code
+}
+
+// mappings converte os erros conhecidos dos serviços; os demais viram erro interno
+var mappings = []mapping{
+	{application.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor},
+	{application.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+	{application.ErrAccountLocked, http.StatusTooManyRequests, CodeAccountLocked},
+	{application.ErrTooManyAttempts, http.StatusTooManyRequests, CodeTooManyAttempts},
+	{application.ErrInvalidToken, http.StatusUnauthorized, CodeInvalidToken},
+	{application.ErrTokenRevoked, http.StatusUnauthorized, CodeTokenRevoked},
+	{application.ErrRefreshTokenReused, http.StatusUnauthorized, CodeRefreshTokenReused},
+	{application.ErrNotAuthorized, http.StatusForbidden, CodeForbidden},
+	{application.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+	{application.ErrUserAlreadyExists, http.StatusConflict, CodeUserAlreadyExists},
+	{application.ErrEmailInUse, http.StatusConflict, CodeEmailInUse},
+	{application.ErrUsernameInUse, http.StatusConflict, CodeUsernameInUse},
+	{application.ErrInvalidPassword, http.StatusForbidden, CodeInvalidPassword},
+	{application.ErrInvalidRole, http.StatusBadRequest, CodeInvalidRole},
+	{application.ErrLastAdmin, http.StatusConflict, CodeLastAdmin},
+	{application.ErrPostNotFound, http.StatusNotFound, CodePostNotFound},
+	{application.ErrInvalidPostData, http.StatusBadRequest, CodeInvalidPostData},
+	{application.ErrInvalidPostStatus, http.StatusBadRequest, CodeInvalidPostStatus},
+	{application.ErrInvalidPublishAt, http.StatusBadRequest, CodeInvalidPublishAt},
+	{application.ErrInvalidStatusTransition, http.StatusConflict, CodeInvalidStatusTransition},
+	{application.ErrRevisionNotFound, http.StatusNotFound, CodeRevisionNotFound},
+	{application.ErrCommentNotFound, http.StatusNotFound, CodeCommentNotFound},
+	{application.ErrParentNotFound, http.StatusNotFound, CodeParentNotFound},
+	{application.ErrInvalidCommentData, http.StatusBadRequest, CodeInvalidCommentData},
+	{application.ErrMaxDepthExceeded, http.StatusUnprocessableEntity, CodeMaxDepthExceeded},
+	{application.ErrNotificationNotFound, http.StatusNotFound, CodeNotificationNotFound},
+}
+
+// FromError converte um erro qualquer em um erro da API
+func FromError(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.err) {
+			return New(m.status, m.code, m.err.Error())
+		}
+	}
+
+	return ErrInternal
+}
+
+// Write escreve o erro no envelope JSON com o status correspondente. Os erros internos têm
+// a causa registrada no log com o ID da requisição, já que o cliente recebe apenas a mensagem genérica.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	apiErr := FromError(err)
+	if apiErr.Status >= http.StatusInternalServerError {
+		log.Printf("[%s] Erro interno em %s %s: %v", middleware.GetReqID(r.Context()), r.Method, r.URL.Path, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(apiErr.Status)
+	json.NewEncoder(w).Encode(envelope{Error: apiErr})
+}
@@ -0,0 +1,66 @@
+package apierror
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// captureLog redireciona o log padrão durante o teste
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+// write escreve err como resposta a uma requisição que passou pelo middleware de ID
+func write(err error) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/posts", nil)
+	r.Header.Set(middleware.RequestIDHeader, "req-123")
+	middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, err)
+	})).ServeHTTP(w, r)
+	return w
+}
+
+func TestWriteLogsInternalErrors(t *testing.T) {
+	logs := captureLog(t)
+
+	w := write(fmt.Errorf("Erro ao buscar posts: %w", errors.New("conexão recusada")))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("esperava status 500, obteve %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "conexão recusada") {
+		t.Errorf("a causa não deve ser exposta ao cliente: %s", w.Body.String())
+	}
+	if got := logs.String(); !strings.Contains(got, "req-123") || !strings.Contains(got, "GET /api/posts") || !strings.Contains(got, "conexão recusada") {
+		t.Errorf("esperava a causa registrada com o ID da requisição, obteve %q", got)
+	}
+}
+
+func TestWriteDoesNotLogKnownErrors(t *testing.T) {
+	logs := captureLog(t)
+
+	w := write(fmt.Errorf("Erro ao buscar post: %w", application.ErrPostNotFound))
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("esperava status 404, obteve %d", w.Code)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("não esperava registro para erros conhecidos, obteve %q", logs.String())
+	}
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminHandler manipula as requisições de administração e moderação
+type AdminHandler struct {
+	adminService *application.AdminService
+}
+
+// NewAdminHandler cria uma nova instância do AdminHandler
+func NewAdminHandler(adminService *application.AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// ChangeRole altera o papel de um usuário
+func (h *AdminHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.RoleRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.adminService.ChangeRole(r.Context(), actorID, chi.URLParam(r, "userID"), req)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// UnlockUser desbloqueia o login de um usuário
+func (h *AdminHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.adminService.UnlockUser(r.Context(), actorID, chi.URLParam(r, "userID")); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeletePost remove o post de qualquer autor
+func (h *AdminHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.ModerationRequest
+	if !decodeOptionalRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.adminService.ForceDeletePost(r.Context(), actorID, chi.URLParam(r, "postID"), req); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteComment remove o comentário de qualquer autor
+func (h *AdminHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.ModerationRequest
+	if !decodeOptionalRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.adminService.ForceDeleteComment(r.Context(), actorID, chi.URLParam(r, "commentID"), req); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Users retorna uma página dos usuários cadastrados; exige papel de administrador
+func (h *AdminHandler) Users(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	pageQuery, err := getPageQuery(r)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	users, err := h.adminService.ListUsers(r.Context(), actorID, pageQuery)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writePage(w, r, users)
+}
+
+// AuditLog retorna a trilha de auditoria paginada
+func (h *AdminHandler) AuditLog(w http.ResponseWriter, r *http.Request) {
+	actorID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	entries, err := h.adminService.ListAuditLog(r.Context(), actorID, page, pageSize)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
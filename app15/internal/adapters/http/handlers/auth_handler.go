@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
-	"app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
 )
 
 // AuthHandler manipula as requisições de autenticação
@@ -22,19 +24,13 @@ func NewAuthHandler(authService *application.AuthService) *AuthHandler {
 // Register registra um novo usuário
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req application.RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Formato de requisição inválido", http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
 	resp, err := h.authService.Register(r.Context(), req)
 	if err != nil {
-		switch err {
-		case application.ErrUserAlreadyExists:
-			http.Error(w, err.Error(), http.StatusConflict)
-		default:
-			http.Error(w, "Erro interno do servidor", http.StatusInternalServerError)
-		}
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -46,19 +42,17 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 // Login autentica um usuário
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req application.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Formato de requisição inválido", http.StatusBadRequest)
+	if !decodeRequest(w, r, &req) {
 		return
 	}
 
-	resp, err := h.authService.Login(r.Context(), req)
+	resp, err := h.authService.Login(r.Context(), req, getClientInfo(r))
 	if err != nil {
-		switch err {
-		case application.ErrInvalidCredentials:
-			http.Error(w, err.Error(), http.StatusUnauthorized)
-		default:
-			http.Error(w, "Erro interno do servidor", http.StatusInternalServerError)
+		var locked *application.LoginLockedError
+		if errors.As(err, &locked) {
+			w.Header().Set("Retry-After", retryAfter(locked.Until))
 		}
+		apierror.Write(w, r, err)
 		return
 	}
 
@@ -67,27 +61,75 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// Refresh troca um token de renovação por um novo par de tokens
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req application.RefreshRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	resp, err := h.authService.Refresh(r.Context(), req)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Logout encerra a sessão associada ao token de renovação
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req application.RefreshRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.authService.Logout(r.Context(), req); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Profile retorna o perfil do usuário autenticado
 func (h *AuthHandler) Profile(w http.ResponseWriter, r *http.Request) {
 	// Obter ID do usuário do contexto (definido pelo middleware de autenticação)
 	userID, ok := r.Context().Value("user_id").(string)
 	if !ok {
-		http.Error(w, "Usuário não autenticado", http.StatusUnauthorized)
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
 		return
 	}
 
 	user, err := h.authService.GetUserByID(r.Context(), userID)
 	if err != nil {
-		switch err {
-		case application.ErrUserNotFound:
-			http.Error(w, err.Error(), http.StatusNotFound)
-		default:
-			http.Error(w, "Erro interno do servidor", http.StatusInternalServerError)
-		}
+		apierror.Write(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(user)
-} 
\ No newline at end of file
+} 
+
+// Sessions retorna o histórico de logins do usuário autenticado, incluindo as tentativas
+// com senha errada, para que ele identifique acessos suspeitos
+func (h *AuthHandler) Sessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+
+	attempts, err := h.authService.ListLoginHistory(r.Context(), userID, page, pageSize)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, attempts)
+}
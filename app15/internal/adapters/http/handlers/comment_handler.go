@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CommentHandler manipula as requisições de comentários
+type CommentHandler struct {
+	commentService *application.CommentService
+}
+
+// NewCommentHandler cria uma nova instância do CommentHandler
+func NewCommentHandler(commentService *application.CommentService) *CommentHandler {
+	return &CommentHandler{
+		commentService: commentService,
+	}
+}
+
+// List retorna uma página de comentários de um post.
+// Cada comentário traz os dados públicos do autor.
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	pageQuery, err := getPageQuery(r)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	viewerID, _ := getUserID(r)
+	comments, err := h.commentService.ListCommentsByPost(r.Context(), chi.URLParam(r, "postID"), viewerID, pageQuery)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writePage(w, r, comments)
+}
+
+// Thread retorna a árvore de comentários de um post. O parâmetro parent inicia a árvore
+// em um comentário específico e depth limita quantos níveis de respostas são expandidos.
+func (h *CommentHandler) Thread(w http.ResponseWriter, r *http.Request) {
+	pageQuery, err := getPageQuery(r)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	depth := -1
+	if value := r.URL.Query().Get("depth"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			apierror.Write(w, r, apierror.InvalidParameter("depth", "Parâmetro depth inválido"))
+			return
+		}
+		depth = parsed
+	}
+
+	viewerID, _ := getUserID(r)
+	threads, err := h.commentService.ListThread(r.Context(), chi.URLParam(r, "postID"), r.URL.Query().Get("parent"), depth, viewerID, pageQuery)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writePage(w, r, threads)
+}
+
+// Get retorna um comentário de um post pelo ID, com os dados públicos do autor
+func (h *CommentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	viewerID, _ := getUserID(r)
+	comment, err := h.commentService.GetCommentByID(r.Context(), chi.URLParam(r, "commentID"), viewerID)
+	if err == nil && comment.PostID != chi.URLParam(r, "postID") {
+		err = application.ErrCommentNotFound
+	}
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comment)
+}
+
+// Create adiciona um comentário do usuário autenticado a um post
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.CommentRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(r.Context(), chi.URLParam(r, "postID"), req, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+// Update atualiza um comentário do usuário autenticado
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.checkPost(r); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	var req application.CommentRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(r.Context(), chi.URLParam(r, "commentID"), req, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comment)
+}
+
+// Delete remove um comentário do usuário autenticado
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.checkPost(r); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	if err := h.commentService.DeleteComment(r.Context(), chi.URLParam(r, "commentID"), userID); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Like registra a curtida do usuário autenticado no comentário
+func (h *CommentHandler) Like(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.checkPost(r); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	summary, err := h.commentService.LikeComment(r.Context(), chi.URLParam(r, "commentID"), userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// Unlike desfaz a curtida do usuário autenticado no comentário
+func (h *CommentHandler) Unlike(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.checkPost(r); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	summary, err := h.commentService.UnlikeComment(r.Context(), chi.URLParam(r, "commentID"), userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// checkPost garante que o comentário da URL pertence ao post da URL e que o post está
+// visível para o usuário
+func (h *CommentHandler) checkPost(r *http.Request) error {
+	viewerID, _ := getUserID(r)
+	comment, err := h.commentService.GetCommentByID(r.Context(), chi.URLParam(r, "commentID"), viewerID)
+	if err != nil {
+		return err
+	}
+	if comment.PostID != chi.URLParam(r, "postID") {
+		return application.ErrCommentNotFound
+	}
+	return nil
+}
+
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// swaggerUIPage carrega o Swagger UI de uma CDN apontando para a especificação servida
+// em /api/openapi.json, relativa à página em /api/docs. A versão é fixa, para que uma
+// publicação nova do pacote não mude a página sem passar por revisão.
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="pt-BR">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>App15 - Blog API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui.css" crossorigin="anonymous">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js" crossorigin="anonymous"></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({ url: "openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
+
+// DocsHandler serve a especificação OpenAPI da API e a página do Swagger UI
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler cria uma nova instância de DocsHandler
+func NewDocsHandler(spec []byte) *DocsHandler {
+	return &DocsHandler{
+		spec: spec,
+	}
+}
+
+// Spec retorna o documento OpenAPI em JSON
+func (h *DocsHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.spec)
+}
+
+// UI retorna a página do Swagger UI
+func (h *DocsHandler) UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUIPage))
+}
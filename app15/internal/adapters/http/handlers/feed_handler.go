@@ -0,0 +1,326 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Limites dos feeds e do sitemap
+const (
+	feedSize       = 20
+	sitemapMaxURLs = 50000 // Limite do protocolo sitemaps.org por arquivo
+)
+
+// FeedHandler gera os feeds Atom e RSS e o sitemap a partir dos posts publicados
+type FeedHandler struct {
+	postService *application.PostService
+	userService *application.UserService
+	title       string
+	siteURL     string
+	postURL     string
+}
+
+// NewFeedHandler cria uma nova instância do FeedHandler. Com siteURL vazio, os links
+// são montados a partir do endereço usado na requisição. postURL é o modelo do endereço
+// da página pública de cada post, com {id} no lugar do ID; vazio, é siteURL + "/posts/{id}".
+func NewFeedHandler(postService *application.PostService, userService *application.UserService, title, siteURL, postURL string) *FeedHandler {
+	return &FeedHandler{
+		postService: postService,
+		userService: userService,
+		title:       title,
+		siteURL:     siteURL,
+		postURL:     postURL,
+	}
+}
+
+// atomFeed é o documento Atom (RFC 4287)
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published,omitempty"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// rssFeed é o documento RSS 2.0, com o link para si mesmo no vocabulário Atom
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	SelfLink      rssLink   `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssLink struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	Href string `xml:"href,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// sitemap é o documento do protocolo sitemaps.org
+type sitemap struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// Atom retorna o feed Atom dos posts publicados mais recentes
+func (h *FeedHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	page, err := h.postService.ListPosts(r.Context(), application.PageQuery{PageSize: feedSize})
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	base := h.baseURL(r)
+	feed := h.atom(h.title, base+"/feed.atom", base, page.Items)
+	writeXML(w, r, "application/atom+xml; charset=utf-8", feed)
+}
+
+// AuthorAtom retorna o feed Atom dos posts publicados mais recentes de um autor
+func (h *FeedHandler) AuthorAtom(w http.ResponseWriter, r *http.Request) {
+	author, err := h.userService.GetPublicProfile(r.Context(), chi.URLParam(r, "username"))
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	page, err := h.postService.ListPostsByAuthor(r.Context(), author.ID, "", domain.PostStatusPublished, application.PageQuery{PageSize: feedSize})
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	base := h.baseURL(r)
+	self := base + "/users/" + url.PathEscape(author.Username) + "/feed.atom"
+	feed := h.atom(h.title+" - "+author.Username, self, base, page.Items)
+	feed.Author = &atomAuthor{Name: author.Username}
+	writeXML(w, r, "application/atom+xml; charset=utf-8", feed)
+}
+
+// RSS retorna o feed RSS 2.0 dos posts publicados mais recentes
+func (h *FeedHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	page, err := h.postService.ListPosts(r.Context(), application.PageQuery{PageSize: feedSize})
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	base := h.baseURL(r)
+	feed := rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         h.title,
+			Link:          base + "/",
+			Description:   "Posts publicados em " + h.title,
+			LastBuildDate: lastUpdated(page.Items).Format(time.RFC1123Z),
+			SelfLink:      rssLink{Rel: "self", Type: "application/rss+xml", Href: base + "/feed.rss"},
+			Items:         make([]rssItem, 0, len(page.Items)),
+		},
+	}
+	for _, post := range page.Items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        h.postLink(base, post.ID),
+			GUID:        rssGUID{Value: postURN(post)},
+			PubDate:     publishedAt(post).Format(time.RFC1123Z),
+			Categories:  post.Tags,
+			Description: post.ContentHTML,
+		})
+	}
+
+	writeXML(w, r, "application/rss+xml; charset=utf-8", feed)
+}
+
+// Sitemap retorna o sitemap com todos os posts publicados, até o limite de um arquivo.
+// Apenas o ID e a última alteração de cada post são lidos do repositório.
+func (h *FeedHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.postService.ListPublishedEntries(r.Context(), sitemapMaxURLs)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	base := h.baseURL(r)
+	doc := sitemap{URLs: make([]sitemapURL, 0, len(entries))}
+	for _, entry := range entries {
+		doc.URLs = append(doc.URLs, sitemapURL{
+			Loc:     h.postLink(base, entry.ID),
+			LastMod: entry.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	writeXML(w, r, "application/xml; charset=utf-8", doc)
+}
+
+// atom monta o feed Atom com os posts informados; self é o endereço do próprio feed
+// e base, o da página inicial do blog
+func (h *FeedHandler) atom(title, self, base string, posts []*domain.Post) *atomFeed {
+	feed := &atomFeed{
+		ID:      self,
+		Title:   title,
+		Updated: lastUpdated(posts).Format(time.RFC3339),
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: self},
+			{Rel: "alternate", Type: "text/html", Href: base + "/"},
+		},
+		Entries: make([]atomEntry, 0, len(posts)),
+	}
+
+	for _, post := range posts {
+		entry := atomEntry{
+			ID:         postURN(post),
+			Title:      post.Title,
+			Link:       atomLink{Rel: "alternate", Type: "text/html", Href: h.postLink(base, post.ID)},
+			Published:  publishedAt(post).Format(time.RFC3339),
+			Updated:    post.UpdatedAt.UTC().Format(time.RFC3339),
+			Categories: make([]atomCategory, 0, len(post.Tags)),
+			Summary:    post.Excerpt,
+			Content:    atomContent{Type: "html", Body: post.ContentHTML},
+		}
+		if post.Author != nil {
+			entry.Author.Name = post.Author.Username
+		}
+		for _, tag := range post.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+// baseURL retorna a URL pública configurada ou, sem ela, a montada a partir da requisição
+func (h *FeedHandler) baseURL(r *http.Request) string {
+	if h.siteURL != "" {
+		return h.siteURL
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// postLink retorna o endereço da página pública de um post
+func (h *FeedHandler) postLink(base, id string) string {
+	template := h.postURL
+	if template == "" {
+		template = base + "/posts/{id}"
+	}
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+}
+
+// postURN retorna o identificador permanente de um post nos feeds
+func postURN(post *domain.Post) string {
+	return "urn:uuid:" + post.ID
+}
+
+// publishedAt retorna a data de publicação do post, ou a de criação quando não há uma
+func publishedAt(post *domain.Post) time.Time {
+	if post.PublishAt != nil {
+		return post.PublishAt.UTC()
+	}
+	return post.CreatedAt.UTC()
+}
+
+// lastUpdated retorna a última atualização entre os posts, que marca a versão do documento
+func lastUpdated(posts []*domain.Post) time.Time {
+	var updated time.Time
+	for _, post := range posts {
+		if post.UpdatedAt.After(updated) {
+			updated = post.UpdatedAt
+		}
+	}
+	return updated.UTC().Truncate(time.Second)
+}
+
+// writeXML serializa o documento e o entrega com um ETag calculado sobre o conteúdo.
+// Requisições com If-None-Match de uma versão ainda atual recebem 304 sem o corpo. Não há
+// Last-Modified: remoções, arquivamentos e renomeações de autores mudam o documento sem
+// mudar a data de alteração dos posts que restam.
+func writeXML(w http.ResponseWriter, r *http.Request, contentType string, doc interface{}) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+	body = append([]byte(xml.Header), body...)
+
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// writeJSON serializa o payload como JSON com o status informado
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(payload)
+}
+
+// getUserID obtém o ID do usuário autenticado do contexto da requisição
+func getUserID(r *http.Request) (string, bool) {
+	userID, ok := r.Context().Value("user_id").(string)
+	return userID, ok && userID != ""
+}
+
+// getClientInfo obtém o IP e o agente do cliente. Atrás de um proxy reverso confiável,
+// o RemoteAddr já foi substituído pelo IP original.
+func getClientInfo(r *http.Request) application.ClientInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return application.ClientInfo{IP: ip, UserAgent: r.UserAgent()}
+}
+
+// retryAfter formata o cabeçalho Retry-After em segundos até o instante informado
+func retryAfter(until time.Time) string {
+	return strconv.Itoa(int(math.Ceil(time.Until(until).Seconds())))
+}
+
+// getPagination lê os parâmetros de paginação page e pageSize da query string.
+// Valores ausentes ou inválidos são repassados como zero para que os serviços
+// apliquem seus valores padrão.
+func getPagination(r *http.Request) (int, int) {
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+	return page, pageSize
+}
+
+// getPageQuery lê os parâmetros da paginação por cursor: cursor, recebido na página anterior,
+// pageSize, de 1 a application.MaxPageSize, e total=true, que inclui a contagem de todos
+// os itens da listagem
+func getPageQuery(r *http.Request) (application.PageQuery, error) {
+	query := r.URL.Query()
+
+	pageQuery := application.PageQuery{Cursor: query.Get("cursor")}
+	if value := query.Get("pageSize"); value != "" {
+		pageSize, err := strconv.Atoi(value)
+		if err != nil || pageSize < 1 || pageSize > application.MaxPageSize {
+			return pageQuery, apierror.InvalidParameter("pageSize",
+				fmt.Sprintf("Parâmetro pageSize deve ser um número de 1 a %d", application.MaxPageSize))
+		}
+		pageQuery.PageSize = pageSize
+	}
+	if value := query.Get("total"); value != "" {
+		withTotal, err := strconv.ParseBool(value)
+		if err != nil {
+			return pageQuery, apierror.InvalidParameter("total", "Parâmetro total deve ser true ou false")
+		}
+		pageQuery.WithTotal = withTotal
+	}
+
+	return pageQuery, nil
+}
+
+// writePage escreve uma página de resultados com o cabeçalho Link (RFC 5988), que aponta
+// para a próxima página e, a partir da segunda, para a primeira
+func writePage[T any](w http.ResponseWriter, r *http.Request, page *domain.Page[T]) {
+	if page.HasMore {
+		w.Header().Add("Link", pageLink(r, page.NextCursor, "next"))
+	}
+	if r.URL.Query().Get("cursor") != "" {
+		w.Header().Add("Link", pageLink(r, "", "first"))
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// pageLink gera um valor do cabeçalho Link para a URL da requisição com outro cursor
+func pageLink(r *http.Request, cursor, rel string) string {
+	target := *r.URL
+	query := target.Query()
+	if cursor == "" {
+		query.Del("cursor")
+	} else {
+		query.Set("cursor", cursor)
+	}
+	target.RawQuery = query.Encode()
+
+	return fmt.Sprintf(`<%s>; rel="%s"`, target.RequestURI(), rel)
+}
+
+// getTags lê as tags da query string, aceitando tanto ?tag=a&tag=b quanto ?tag=a,b
+func getTags(r *http.Request) []string {
+	tags := make([]string, 0)
+	for _, value := range r.URL.Query()["tag"] {
+		for _, tag := range strings.Split(value, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificationHandler manipula as requisições da caixa de notificações do usuário
+type NotificationHandler struct {
+	notificationService *application.NotificationService
+}
+
+// NewNotificationHandler cria uma nova instância do NotificationHandler
+func NewNotificationHandler(notificationService *application.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// List lista as notificações do usuário autenticado; ?unread=true retorna apenas as não lidas
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	unreadOnly := false
+	if value := r.URL.Query().Get("unread"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			apierror.Write(w, r, apierror.InvalidParameter("unread", "Parâmetro unread deve ser true ou false"))
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	page, pageSize := getPagination(r)
+
+	inbox, err := h.notificationService.ListNotifications(r.Context(), userID, unreadOnly, page, pageSize)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, inbox)
+}
+
+// MarkRead marca uma notificação como lida
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.notificationService.MarkRead(r.Context(), userID, chi.URLParam(r, "notificationID")); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllRead marca todas as notificações do usuário como lidas
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(r.Context(), userID); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
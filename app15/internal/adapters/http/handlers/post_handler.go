@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PostHandler manipula as requisições de posts
+type PostHandler struct {
+	postService *application.PostService
+}
+
+// NewPostHandler cria uma nova instância do PostHandler
+func NewPostHandler(postService *application.PostService) *PostHandler {
+	return &PostHandler{
+		postService: postService,
+	}
+}
+
+// List retorna uma página de posts publicados, opcionalmente filtrada por autor.
+// O próprio autor pode listar seus posts em qualquer status com o parâmetro status.
+// Com os parâmetros q e/ou tag, retorna resultados de busca ordenados por relevância;
+// com sort=popular, os posts publicados mais populares.
+// Cada post traz os dados públicos do autor.
+func (h *PostHandler) List(w http.ResponseWriter, r *http.Request) {
+	pageQuery, err := getPageQuery(r)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+	switch query.Get("sort") {
+	case "", "recent":
+	case "popular":
+		if query.Get("q") != "" || len(getTags(r)) > 0 || query.Get("author") != "" {
+			apierror.Write(w, r, apierror.InvalidParameter("sort", "Parâmetro sort=popular não pode ser combinado com q, tag ou author"))
+			return
+		}
+
+		posts, err := h.postService.ListPopularPosts(r.Context(), pageQuery)
+		if err != nil {
+			apierror.Write(w, r, err)
+			return
+		}
+
+		writePage(w, r, posts)
+		return
+	default:
+		apierror.Write(w, r, apierror.InvalidParameter("sort", "Parâmetro sort inválido: use recent ou popular"))
+		return
+	}
+
+	if q, tags := query.Get("q"), getTags(r); q != "" || len(tags) > 0 {
+		results, err := h.postService.SearchPosts(r.Context(), q, tags, pageQuery)
+		if err != nil {
+			apierror.Write(w, r, err)
+			return
+		}
+
+		writePage(w, r, results)
+		return
+	}
+
+	var posts *domain.Page[*domain.Post]
+	if authorID := query.Get("author"); authorID != "" {
+		viewerID, _ := getUserID(r)
+		status := domain.PostStatus(query.Get("status"))
+		posts, err = h.postService.ListPostsByAuthor(r.Context(), authorID, viewerID, status, pageQuery)
+	} else {
+		posts, err = h.postService.ListPosts(r.Context(), pageQuery)
+	}
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writePage(w, r, posts)
+}
+
+// Get retorna um post pelo ID, com os dados públicos do autor
+func (h *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	viewerID, _ := getUserID(r)
+	post, err := h.postService.GetPostByID(r.Context(), chi.URLParam(r, "postID"), viewerID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Create cria um novo post para o usuário autenticado
+func (h *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.PostRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	post, err := h.postService.CreatePost(r.Context(), req, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, post)
+}
+
+// Update atualiza um post do usuário autenticado
+func (h *PostHandler) Update(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.PostRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	post, err := h.postService.UpdatePost(r.Context(), chi.URLParam(r, "postID"), req, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Delete remove um post do usuário autenticado
+func (h *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.postService.DeletePost(r.Context(), chi.URLParam(r, "postID"), userID); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ChangeStatus move um post do usuário autenticado pelo ciclo de vida
+func (h *PostHandler) ChangeStatus(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.StatusRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	post, err := h.postService.ChangeStatus(r.Context(), chi.URLParam(r, "postID"), req, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Revisions retorna uma lista paginada das revisões de um post do usuário autenticado
+func (h *PostHandler) Revisions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	page, pageSize := getPagination(r)
+	revisions, err := h.postService.ListRevisions(r.Context(), chi.URLParam(r, "postID"), userID, page, pageSize)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, revisions)
+}
+
+// Revision retorna uma revisão de um post do usuário autenticado
+func (h *PostHandler) Revision(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidParameter("version", "Versão inválida"))
+		return
+	}
+
+	revision, err := h.postService.GetRevision(r.Context(), chi.URLParam(r, "postID"), version, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, revision)
+}
+
+// Diff compara as revisões informadas pelos parâmetros from e to
+func (h *PostHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	query := r.URL.Query()
+	from, err := strconv.Atoi(query.Get("from"))
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidParameter("from", "Parâmetro from deve ser uma versão válida"))
+		return
+	}
+	to, err := strconv.Atoi(query.Get("to"))
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidParameter("to", "Parâmetro to deve ser uma versão válida"))
+		return
+	}
+
+	diff, err := h.postService.DiffRevisions(r.Context(), chi.URLParam(r, "postID"), from, to, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// Restore restaura uma revisão anterior de um post do usuário autenticado
+func (h *PostHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	version, err := strconv.Atoi(chi.URLParam(r, "version"))
+	if err != nil {
+		apierror.Write(w, r, apierror.InvalidParameter("version", "Versão inválida"))
+		return
+	}
+
+	post, err := h.postService.RestoreRevision(r.Context(), chi.URLParam(r, "postID"), version, userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, post)
+}
+
+// Like registra a curtida do usuário autenticado no post
+func (h *PostHandler) Like(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	summary, err := h.postService.LikePost(r.Context(), chi.URLParam(r, "postID"), userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// Unlike desfaz a curtida do usuário autenticado no post
+func (h *PostHandler) Unlike(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	summary, err := h.postService.UnlikePost(r.Context(), chi.URLParam(r, "postID"), userID)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// UserHandler manipula as requisições de perfis e de gerenciamento da própria conta
+type UserHandler struct {
+	userService *application.UserService
+}
+
+// NewUserHandler cria uma nova instância do UserHandler
+func NewUserHandler(userService *application.UserService) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+	}
+}
+
+// Get retorna o perfil público de um usuário
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	profile, err := h.userService.GetPublicProfile(r.Context(), chi.URLParam(r, "username"))
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, profile)
+}
+
+// UpdateMe altera o nome de usuário ou o email do usuário autenticado
+func (h *UserHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.UpdateProfileRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(r.Context(), userID, req)
+	if err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, user)
+}
+
+// ChangePassword troca a senha do usuário autenticado
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.ChangePasswordRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.userService.ChangePassword(r.Context(), userID, req); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteMe remove a conta do usuário autenticado
+func (h *UserHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := getUserID(r)
+	if !ok {
+		apierror.Write(w, r, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req application.DeleteAccountRequest
+	if !decodeRequest(w, r, &req) {
+		return
+	}
+
+	if err := h.userService.DeleteAccount(r.Context(), userID, req); err != nil {
+		apierror.Write(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// usernamePattern restringe os nomes de usuário a caracteres seguros para URLs
+var usernamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// validate aplica as regras declaradas nas tags `validate` dos DTOs da aplicação
+var validate = newValidator()
+
+// newValidator cria o validador usando os nomes JSON dos campos nas mensagens de erro
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	v.RegisterValidation("username", func(fl validator.FieldLevel) bool {
+		return usernamePattern.MatchString(fl.Field().String())
+	})
+
+	return v
+}
+
+// decodeRequest lê o corpo JSON da requisição em dst e valida suas regras.
+// Em caso de erro, escreve a resposta no envelope de erros e retorna false.
+func decodeRequest(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		apierror.Write(w, r, apierror.ErrInvalidJSON)
+		return false
+	}
+	return validateRequest(w, r, dst)
+}
+
+// decodeOptionalRequest é semelhante ao decodeRequest, mas aceita o corpo vazio
+func decodeOptionalRequest(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		apierror.Write(w, r, apierror.ErrInvalidJSON)
+		return false
+	}
+	return validateRequest(w, r, dst)
+}
+
+// validateRequest valida dst e escreve os erros de cada campo no envelope de erros
+func validateRequest(w http.ResponseWriter, r *http.Request, dst interface{}) bool {
+	err := validate.Struct(dst)
+	if err == nil {
+		return true
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		apierror.Write(w, r, err)
+		return false
+	}
+
+	fields := make([]apierror.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fields = append(fields, apierror.FieldError{
+			Field:   fieldPath(fe),
+			Code:    fe.Tag(),
+			Message: fieldMessage(fe),
+		})
+	}
+
+	apierror.Write(w, r, apierror.Validation(fields))
+	return false
+}
+
+// fieldPath retorna o caminho do campo sem o nome da struct, como "tags[0]"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+// fieldMessage descreve em português a regra que o campo não atendeu
+func fieldMessage(fe validator.FieldError) string {
+	unit := "caracteres"
+	switch fe.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = "itens"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "campo obrigatório"
+	case "email":
+		return "deve ser um email válido"
+	case "min":
+		return fmt.Sprintf("deve ter no mínimo %s %s", fe.Param(), unit)
+	case "max":
+		return fmt.Sprintf("deve ter no máximo %s %s", fe.Param(), unit)
+	case "oneof":
+		return fmt.Sprintf("deve ser um dos valores: %s", strings.Join(strings.Fields(fe.Param()), ", "))
+	case "username":
+		return "deve conter apenas letras, números, '.', '_' ou '-'"
+	default:
+		return "valor inválido"
+	}
+}
@@ -2,10 +2,12 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
-	"app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
 )
 
 // AuthMiddleware é um middleware para autenticação de usuários
@@ -26,21 +28,25 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 		// Extrair token do cabeçalho Authorization
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Token de autenticação não fornecido", http.StatusUnauthorized)
+			apierror.Write(w, r, apierror.New(http.StatusUnauthorized, apierror.CodeUnauthenticated, "Token de autenticação não fornecido"))
 			return
 		}
 
 		// Verificar formato "Bearer <token>"
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, "Formato de token inválido", http.StatusUnauthorized)
+			apierror.Write(w, r, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Formato de token inválido"))
 			return
 		}
 
 		// Validar token
-		userID, err := m.authService.ValidateToken(parts[1])
+		userID, err := m.authService.ValidateToken(r.Context(), parts[1])
+		if errors.Is(err, application.ErrTokenRevoked) {
+			apierror.Write(w, r, err)
+			return
+		}
 		if err != nil {
-			http.Error(w, "Token inválido", http.StatusUnauthorized)
+			apierror.Write(w, r, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Token inválido"))
 			return
 		}
 
@@ -70,7 +76,7 @@ func (m *AuthMiddleware) Optional(next http.Handler) http.Handler {
 		}
 
 		// Validar token
-		userID, err := m.authService.ValidateToken(parts[1])
+		userID, err := m.authService.ValidateToken(r.Context(), parts[1])
 		if err != nil {
 			// Continuar sem autenticação
 			next.ServeHTTP(w, r)
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+)
+
+// Limit define um balde de tokens: até Requests requisições seguidas, repostas
+// gradualmente ao longo de Period
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// RateLimitResult é o estado do balde depois de uma tentativa de consumo
+type RateLimitResult struct {
+	// Allowed indica se a requisição pode prosseguir
+	Allowed bool
+	// Remaining é a quantidade de requisições ainda disponíveis
+	Remaining int
+	// Reset é o tempo até o balde voltar a ficar cheio
+	Reset time.Duration
+	// RetryAfter é o tempo até a próxima requisição ser aceita, quando Allowed é false
+	RetryAfter time.Duration
+}
+
+// RateLimitStore guarda os baldes de tokens. A implementação em memória atende uma única
+// instância; para várias instâncias, basta implementar esta interface sobre um armazenamento
+// compartilhado, consumindo o token de forma atômica.
+type RateLimitStore interface {
+	// Take consome um token do balde identificado por key, criando-o cheio se não existir
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error)
+}
+
+// RateLimiter é um middleware de limite de requisições por balde de tokens. Requisições
+// autenticadas são contadas por usuário; as anônimas, pelo IP do cliente.
+type RateLimiter struct {
+	store RateLimitStore
+	name  string
+	limit Limit
+	// now é o relógio usado para repor os tokens, substituível nos testes
+	now func() time.Time
+}
+
+// NewRateLimiter cria um limitador para um grupo de rotas; name separa os baldes de grupos diferentes
+func NewRateLimiter(store RateLimitStore, name string, limit Limit) *RateLimiter {
+	return &RateLimiter{
+		store: store,
+		name:  name,
+		limit: limit,
+		now:   time.Now,
+	}
+}
+
+// Limit aplica o limite às requisições. Em rotas autenticadas, deve vir depois do
+// AuthMiddleware para que o usuário já esteja no contexto.
+func (l *RateLimiter) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, err := l.store.Take(r.Context(), l.key(r), l.limit, l.now())
+		if err != nil {
+			// Uma falha no armazenamento não deve derrubar a API
+			log.Printf("Erro ao consultar limite de requisições: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", l.limit.Requests, seconds(l.limit.Period)))
+		header.Set("RateLimit-Limit", strconv.Itoa(l.limit.Requests))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(seconds(result.RetryAfter)))
+			apierror.Write(w, r, apierror.ErrTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// key identifica o balde da requisição: pelo usuário autenticado ou pelo IP do cliente
+func (l *RateLimiter) key(r *http.Request) string {
+	if userID, ok := r.Context().Value("user_id").(string); ok && userID != "" {
+		return l.name + ":user:" + userID
+	}
+	return l.name + ":ip:" + clientIP(r)
+}
+
+// clientIP retorna o IP de origem da requisição. Atrás de um proxy reverso confiável,
+// o RemoteAddr já foi substituído pelo IP original.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// seconds arredonda a duração para cima em segundos, como esperado pelos cabeçalhos
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval é o intervalo mínimo entre as limpezas dos baldes cheios
+const sweepInterval = time.Minute
+
+// bucket guarda os tokens disponíveis e o momento da última reposição
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+// MemoryRateLimitStore implementa o RateLimitStore em memória, para uma única instância
+type MemoryRateLimitStore struct {
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	mutex     sync.Mutex
+}
+
+// NewMemoryRateLimitStore cria um armazenamento de baldes em memória
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Take consome um token do balde, repondo antes os tokens proporcionais ao tempo decorrido
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (RateLimitResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sweep(now)
+
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Period.Seconds()
+
+	b, exists := s.buckets[key]
+	if !exists {
+		b = &bucket{tokens: capacity, updated: now}
+		s.buckets[key] = b
+	}
+	b.period = limit.Period
+
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*rate)
+		b.updated = now
+	}
+
+	result := RateLimitResult{}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsDuration((1 - b.tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = secondsDuration((capacity - b.tokens) / rate)
+
+	return result, nil
+}
+
+// sweep remove os baldes que já teriam sido repostos por completo, limitando o uso de memória
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// secondsDuration converte uma quantidade de segundos em time.Duration
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryRateLimitStoreTake(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	limit := Limit{Requests: 2, Period: 10 * time.Second}
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		name string
+		at   time.Duration
+		want RateLimitResult
+	}{
+		{"balde cheio", 0, RateLimitResult{Allowed: true, Remaining: 1, Reset: 5 * time.Second}},
+		{"último token", 0, RateLimitResult{Allowed: true, Remaining: 0, Reset: 10 * time.Second}},
+		{"balde vazio", 2 * time.Second, RateLimitResult{Remaining: 0, Reset: 8 * time.Second, RetryAfter: 3 * time.Second}},
+		{"um token reposto", 5 * time.Second, RateLimitResult{Allowed: true, Remaining: 0, Reset: 10 * time.Second}},
+		{"reposição limitada à capacidade", time.Hour, RateLimitResult{Allowed: true, Remaining: 1, Reset: 5 * time.Second}},
+	}
+
+	for _, step := range steps {
+		got, err := store.Take(ctx, "chave", limit, start.Add(step.at))
+		if err != nil {
+			t.Fatalf("%s: erro inesperado: %v", step.name, err)
+		}
+		// A reposição é fracionária, então os tempos são comparados em milissegundos
+		got.Reset = got.Reset.Round(time.Millisecond)
+		got.RetryAfter = got.RetryAfter.Round(time.Millisecond)
+		if got != step.want {
+			t.Errorf("%s: esperava %+v, obteve %+v", step.name, step.want, got)
+		}
+	}
+}
+
+func TestMemoryRateLimitStoreSeparatesKeys(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if result, _ := store.Take(ctx, "a", limit, now); !result.Allowed {
+		t.Fatalf("esperava a primeira requisição de a aceita, obteve %+v", result)
+	}
+	if result, _ := store.Take(ctx, "a", limit, now); result.Allowed {
+		t.Errorf("esperava a segunda requisição de a recusada, obteve %+v", result)
+	}
+	if result, _ := store.Take(ctx, "b", limit, now); !result.Allowed {
+		t.Errorf("esperava o balde de b independente do de a, obteve %+v", result)
+	}
+}
+
+func TestMemoryRateLimitStoreSweepsFullBuckets(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRateLimitStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store.Take(ctx, "antigo", limit, now)
+	store.Take(ctx, "recente", limit, now.Add(50*time.Second))
+	store.Take(ctx, "novo", limit, now.Add(90*time.Second))
+
+	if _, ok := store.buckets["antigo"]; ok {
+		t.Error("esperava o balde já reposto removido na limpeza")
+	}
+	if _, ok := store.buckets["recente"]; !ok {
+		t.Error("esperava o balde ainda em reposição mantido")
+	}
+}
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// clock é um relógio controlado pelos testes
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+// newTestLimiter cria um limitador com o relógio informado, à frente de um handler que responde 200
+func newTestLimiter(c *clock, limit Limit) http.Handler {
+	limiter := NewRateLimiter(NewMemoryRateLimitStore(), "teste", limit)
+	limiter.now = c.Now
+	return limiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// request envia uma requisição vinda de ip, autenticada como userID quando informado
+func request(handler http.Handler, ip, userID string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = ip + ":1234"
+	if userID != "" {
+		r = r.WithContext(context.WithValue(r.Context(), "user_id", userID))
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w
+}
+
+func TestRateLimiterHeaders(t *testing.T) {
+	c := &clock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	handler := newTestLimiter(c, Limit{Requests: 2, Period: time.Minute})
+
+	steps := []struct {
+		name       string
+		advance    time.Duration
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+	}{
+		{"primeira requisição", 0, http.StatusOK, "1", "30", ""},
+		{"segunda requisição", 0, http.StatusOK, "0", "60", ""},
+		{"limite excedido", 10 * time.Second, http.StatusTooManyRequests, "0", "50", "20"},
+		{"token reposto", 20 * time.Second, http.StatusOK, "0", "60", ""},
+	}
+
+	for _, step := range steps {
+		c.now = c.now.Add(step.advance)
+		w := request(handler, "192.0.2.1", "")
+
+		if w.Code != step.status {
+			t.Fatalf("%s: esperava status %d, obteve %d", step.name, step.status, w.Code)
+		}
+		want := map[string]string{
+			"RateLimit-Policy":    "2;w=60",
+			"RateLimit-Limit":     "2",
+			"RateLimit-Remaining": step.remaining,
+			"RateLimit-Reset":     step.reset,
+			"Retry-After":         step.retryAfter,
+		}
+		for header, value := range want {
+			if got := w.Header().Get(header); got != value {
+				t.Errorf("%s: esperava %s %q, obteve %q", step.name, header, value, got)
+			}
+		}
+	}
+}
+
+func TestRateLimiterTooManyRequestsBody(t *testing.T) {
+	c := &clock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	handler := newTestLimiter(c, Limit{Requests: 1, Period: time.Minute})
+
+	request(handler, "192.0.2.1", "")
+	w := request(handler, "192.0.2.1", "")
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("Erro ao decodificar a resposta: %v", err)
+	}
+	if w.Code != http.StatusTooManyRequests || body.Error.Code != "rate_limited" {
+		t.Errorf("esperava 429 rate_limited, obteve %d %q", w.Code, body.Error.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("esperava Retry-After 60, obteve %q", got)
+	}
+}
+
+func TestRateLimiterBuckets(t *testing.T) {
+	c := &clock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	handler := newTestLimiter(c, Limit{Requests: 1, Period: time.Minute})
+
+	// O IP anônimo esgota o seu balde
+	request(handler, "192.0.2.1", "")
+	if w := request(handler, "192.0.2.1", ""); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("esperava o IP anônimo limitado, obteve %d", w.Code)
+	}
+
+	// Um usuário autenticado no mesmo IP é contado no seu próprio balde
+	if w := request(handler, "192.0.2.1", "user-1"); w.Code != http.StatusOK {
+		t.Errorf("esperava o usuário separado do IP anônimo, obteve %d", w.Code)
+	}
+	// O mesmo usuário em outro IP compartilha o balde
+	if w := request(handler, "198.51.100.7", "user-1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("esperava o usuário limitado em qualquer IP, obteve %d", w.Code)
+	}
+	// Outro usuário e outro IP anônimo têm baldes próprios
+	if w := request(handler, "192.0.2.1", "user-2"); w.Code != http.StatusOK {
+		t.Errorf("esperava outro usuário com balde próprio, obteve %d", w.Code)
+	}
+	if w := request(handler, "198.51.100.7", ""); w.Code != http.StatusOK {
+		t.Errorf("esperava outro IP com balde próprio, obteve %d", w.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/api"
+	"github.com/caiquemiranda/go-apps/app15/internal/app"
+	"github.com/caiquemiranda/go-apps/app15/internal/config"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// undocumentedRoutes são as rotas que ficam fora da especificação OpenAPI
+var undocumentedRoutes = map[string]bool{
+	"GET /health":           true,
+	"GET /api/openapi.json": true,
+	"GET /api/docs":         true,
+}
+
+// specOperations lista as operações da especificação no formato "MÉTODO /caminho"
+func specOperations(t *testing.T) map[string]bool {
+	t.Helper()
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(api.Spec, &spec); err != nil {
+		t.Fatalf("Especificação OpenAPI inválida: %v", err)
+	}
+
+	operations := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method := range item {
+			switch method {
+			case "get", "put", "post", "delete", "patch", "head", "options", "trace":
+				operations[strings.ToUpper(method)+" "+path] = true
+			}
+		}
+	}
+	return operations
+}
+
+// routerOperations lista as rotas registradas no chi no formato "MÉTODO /caminho"
+func routerOperations(t *testing.T) map[string]bool {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", "segredo-de-teste")
+	cfg, err := config.Load([]string{"-db-driver", "memory"})
+	if err != nil {
+		t.Fatalf("Erro ao carregar a configuração: %v", err)
+	}
+
+	handler := NewRouter(app.New(cfg, app.NewMemoryRepositories()))
+
+	operations := make(map[string]bool)
+	walk := func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		operations[method+" "+route] = true
+		return nil
+	}
+	if err := chi.Walk(handler.(chi.Routes), walk); err != nil {
+		t.Fatalf("Erro ao percorrer as rotas: %v", err)
+	}
+	return operations
+}
+
+func TestOpenAPISpecCoversRoutes(t *testing.T) {
+	documented := specOperations(t)
+	routes := routerOperations(t)
+
+	var missing, stale []string
+	for route := range routes {
+		if !documented[route] && !undocumentedRoutes[route] {
+			missing = append(missing, route)
+		}
+	}
+	for operation := range documented {
+		if !routes[operation] {
+			stale = append(stale, operation)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	for _, route := range missing {
+		t.Errorf("Rota sem entrada em api/openapi.json: %s", route)
+	}
+	for _, operation := range stale {
+		t.Errorf("Operação documentada em api/openapi.json sem rota no router: %s", operation)
+	}
+}
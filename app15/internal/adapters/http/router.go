@@ -1,85 +1,236 @@
 package http
 
 import (
+	"context"
 	"net/http"
-	"time"
 
-	"app15/internal/adapters/db/memory"
-	"app15/internal/adapters/http/handlers"
-	"app15/internal/adapters/http/middleware"
-	"app15/internal/application"
-	"app15/internal/ports/repositories"
+	"github.com/caiquemiranda/go-apps/app15/api"
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/apierror"
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/handlers"
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/http/middleware"
+	"github.com/caiquemiranda/go-apps/app15/internal/app"
+	"github.com/caiquemiranda/go-apps/app15/internal/config"
 
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 )
 
-// SetupRouter configura as rotas e middlewares da aplicação
-func SetupRouter() http.Handler {
-	// Inicializar repositórios
-	userRepo := memory.NewUserRepository()
+// SetupRouter monta a aplicação com os repositórios do driver configurado, inicia as
+// tarefas em segundo plano, como a publicação de posts agendados e a entrega de eventos,
+// que executam até ctx ser cancelado, e retorna o router HTTP.
+func SetupRouter(ctx context.Context, cfg *config.Config) (http.Handler, error) {
+	repos, err := app.NewRepositories(cfg.Database.Driver, cfg.Database.DSN)
+	if err != nil {
+		return nil, err
+	}
 
-	// Inicializar serviços de aplicação
-	jwtKey := "sua-chave-secreta-aqui" // Deve ser configurado via ambiente em produção
-	jwtExp := 24 * time.Hour
-	authService := application.NewAuthService(userRepo, jwtKey, jwtExp)
+	a := app.New(cfg, repos)
+	if err := a.BootstrapAdmin(ctx); err != nil {
+		return nil, err
+	}
+	a.Start(ctx)
+
+	return NewRouter(a), nil
+}
+
+// NewRouter configura as rotas e middlewares sobre os serviços da aplicação montada
+func NewRouter(a *app.App) http.Handler {
+	cfg := a.Config
 
 	// Inicializar handlers HTTP
-	authHandler := handlers.NewAuthHandler(authService)
+	authHandler := handlers.NewAuthHandler(a.Auth)
+	postHandler := handlers.NewPostHandler(a.Posts)
+	commentHandler := handlers.NewCommentHandler(a.Comments)
+	adminHandler := handlers.NewAdminHandler(a.Admin)
+	notificationHandler := handlers.NewNotificationHandler(a.Notifications)
+	userHandler := handlers.NewUserHandler(a.Users)
+	docsHandler := handlers.NewDocsHandler(api.Spec)
+	feedHandler := handlers.NewFeedHandler(a.Posts, a.Users, cfg.Site.Title, cfg.Site.URL, cfg.Site.PostURL)
 
 	// Inicializar middlewares
-	authMiddleware := middleware.NewAuthMiddleware(authService)
+	authMiddleware := middleware.NewAuthMiddleware(a.Auth)
+	limits := newRateLimiters(cfg.RateLimit)
 
 	// Configurar router
 	r := chi.NewRouter()
 
-	// Middlewares básicos
+	// Middlewares básicos; atrás de um proxy reverso confiável, o IP do cliente vem dos cabeçalhos
+	if cfg.Server.TrustProxy {
+		r.Use(chimiddleware.RealIP)
+	}
+	r.Use(chimiddleware.RequestID)
 	r.Use(chimiddleware.Logger)
 	r.Use(chimiddleware.Recoverer)
-	r.Use(chimiddleware.Timeout(60 * time.Second))
+	r.Use(chimiddleware.Timeout(cfg.Server.RequestTimeout))
 	
 	// Configuração de CORS
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"*"}, // Em produção, especificar origens permitidas
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		ExposedHeaders:   []string{"ETag", "Link", "RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 		AllowCredentials: true,
 		MaxAge:           300, // Maximum value not entirely supported by all browsers
 	}))
 
+	// Rotas e métodos inexistentes também respondem no envelope de erros
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		apierror.Write(w, r, apierror.ErrRouteNotFound)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		apierror.Write(w, r, apierror.ErrMethodNotAllowed)
+	})
+
 	// Rota para verificar saúde
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("OK"))
 	})
 
+	// Feeds e sitemap dos posts publicados
+	r.Group(func(r chi.Router) {
+		r.Use(limits.public)
+		r.Get("/feed.atom", feedHandler.Atom)
+		r.Get("/feed.rss", feedHandler.RSS)
+		r.Get("/sitemap.xml", feedHandler.Sitemap)
+		r.Get("/users/{username}/feed.atom", feedHandler.AuthorAtom)
+	})
+
 	// Rotas de API
 	r.Route("/api", func(r chi.Router) {
+		// Especificação OpenAPI e Swagger UI
+		r.Group(func(r chi.Router) {
+			r.Use(limits.public)
+			r.Get("/openapi.json", docsHandler.Spec)
+			r.Get("/docs", docsHandler.UI)
+		})
+
 		// Rotas de autenticação (públicas)
 		r.Route("/auth", func(r chi.Router) {
-			r.Post("/register", authHandler.Register)
-			r.Post("/login", authHandler.Login)
+			// Limite mais restrito por IP contra tentativas de adivinhar senhas
+			r.Group(func(r chi.Router) {
+				r.Use(limits.auth)
+				r.Post("/register", authHandler.Register)
+				r.Post("/login", authHandler.Login)
+				r.Post("/refresh", authHandler.Refresh)
+				r.Post("/logout", authHandler.Logout)
+			})
 			
 			// Rotas protegidas por autenticação
 			r.Group(func(r chi.Router) {
-				r.Use(authMiddleware.Authenticate)
+				r.Use(authMiddleware.Authenticate, limits.user)
 				r.Get("/profile", authHandler.Profile)
+				r.Get("/sessions", authHandler.Sessions)
 			})
 		})
 
-		// TODO: Adicionar outras rotas (posts, comentários, etc.)
+		// Rotas de usuários: perfis públicos e gerenciamento da própria conta
+		r.Route("/users", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.Authenticate, limits.user)
+				r.Patch("/me", userHandler.UpdateMe)
+				r.Put("/me/password", userHandler.ChangePassword)
+				r.Delete("/me", userHandler.DeleteMe)
+			})
+
+			r.With(limits.public).Get("/{username}", userHandler.Get)
+		})
+
+		// Rotas de posts
+		r.Route("/posts", func(r chi.Router) {
+			// Rotas públicas; autenticado, o autor também vê seus posts não publicados
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.Optional, limits.public)
+				r.Get("/", postHandler.List)
+				r.Get("/{postID}", postHandler.Get)
+			})
+
+			// Rotas protegidas por autenticação
+			r.Group(func(r chi.Router) {
+				r.Use(authMiddleware.Authenticate, limits.user)
+				r.Post("/", postHandler.Create)
+				r.Put("/{postID}", postHandler.Update)
+				r.Delete("/{postID}", postHandler.Delete)
+				r.Put("/{postID}/status", postHandler.ChangeStatus)
+				r.Get("/{postID}/revisions", postHandler.Revisions)
+				r.Get("/{postID}/revisions/diff", postHandler.Diff)
+				r.Get("/{postID}/revisions/{version}", postHandler.Revision)
+				r.Post("/{postID}/revisions/{version}/restore", postHandler.Restore)
+				r.Put("/{postID}/like", postHandler.Like)
+				r.Delete("/{postID}/like", postHandler.Unlike)
+			})
+
+			// Rotas de comentários de um post
+			r.Route("/{postID}/comments", func(r chi.Router) {
+				// Rotas públicas; os comentários de posts não publicados seguem a visibilidade do post
+				r.Group(func(r chi.Router) {
+					r.Use(authMiddleware.Optional, limits.public)
+					r.Get("/", commentHandler.List)
+					r.Get("/thread", commentHandler.Thread)
+					r.Get("/{commentID}", commentHandler.Get)
+				})
+
+				// Rotas protegidas por autenticação
+				r.Group(func(r chi.Router) {
+					r.Use(authMiddleware.Authenticate, limits.user)
+					// Limite adicional contra spam de comentários
+					r.With(limits.comment).Post("/", commentHandler.Create)
+					r.Put("/{commentID}", commentHandler.Update)
+					r.Delete("/{commentID}", commentHandler.Delete)
+					r.Put("/{commentID}/like", commentHandler.Like)
+					r.Delete("/{commentID}/like", commentHandler.Unlike)
+				})
+			})
+		})
+
+		// Rotas da caixa de notificações do usuário autenticado
+		r.Route("/notifications", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate, limits.user)
+			r.Get("/", notificationHandler.List)
+			r.Post("/read-all", notificationHandler.MarkAllRead)
+			r.Post("/{notificationID}/read", notificationHandler.MarkRead)
+		})
+
+		// Rotas de administração e moderação (o papel exigido é verificado pelos serviços)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(authMiddleware.Authenticate, limits.user)
+			r.Get("/users", adminHandler.Users)
+			r.Put("/users/{userID}/role", adminHandler.ChangeRole)
+			r.Post("/users/{userID}/unlock", adminHandler.UnlockUser)
+			r.Delete("/posts/{postID}", adminHandler.DeletePost)
+			r.Delete("/comments/{commentID}", adminHandler.DeleteComment)
+			r.Get("/audit", adminHandler.AuditLog)
+		})
 	})
 
 	return r
 }
 
-// NewRepositories cria e retorna instâncias de todos os repositórios
-func NewRepositories() (repositories.UserRepository, repositories.PostRepository, repositories.CommentRepository) {
-	userRepo := memory.NewUserRepository()
-	// TODO: Implementar e inicializar os repositórios de posts e comentários
-	var postRepo repositories.PostRepository
-	var commentRepo repositories.CommentRepository
-	
-	return userRepo, postRepo, commentRepo
-} 
\ No newline at end of file
+// rateLimiters reúne os middlewares de limite de requisições de cada grupo de rotas
+type rateLimiters struct {
+	auth    func(http.Handler) http.Handler
+	public  func(http.Handler) http.Handler
+	user    func(http.Handler) http.Handler
+	comment func(http.Handler) http.Handler
+}
+
+// newRateLimiters cria os limitadores configurados, que compartilham o mesmo armazenamento.
+// Com o limite desativado, os middlewares apenas repassam a requisição.
+func newRateLimiters(cfg config.RateLimitConfig) rateLimiters {
+	if !cfg.Enabled {
+		passthrough := func(next http.Handler) http.Handler { return next }
+		return rateLimiters{auth: passthrough, public: passthrough, user: passthrough, comment: passthrough}
+	}
+
+	store := middleware.NewMemoryRateLimitStore()
+	limiter := func(name string, limit config.RateLimit) func(http.Handler) http.Handler {
+		return middleware.NewRateLimiter(store, name, middleware.Limit{Requests: limit.Requests, Period: limit.Period}).Limit
+	}
+
+	return rateLimiters{
+		auth:    limiter("auth", cfg.Auth),
+		public:  limiter("public", cfg.Public),
+		user:    limiter("user", cfg.User),
+		comment: limiter("comment", cfg.Comment),
+	}
+}
@@ -0,0 +1,95 @@
+// Package app monta a aplicação: a partir da configuração e dos repositórios recebidos,
+// cria o barramento de eventos e os serviços, e inicia as tarefas em segundo plano.
+// Os adaptadores de entrada, como o router HTTP, recebem o App já montado.
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/events"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/config"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// maxCommentDepth limita o aninhamento de respostas a comentários
+const maxCommentDepth = 5
+
+// App reúne as dependências da aplicação montadas sobre os repositórios informados
+type App struct {
+	Config       *config.Config
+	Repositories *Repositories
+	Events       *events.OutboxBus
+
+	Auth          *application.AuthService
+	Posts         *application.PostService
+	Comments      *application.CommentService
+	Admin         *application.AdminService
+	Users         *application.UserService
+	Notifications *application.NotificationService
+}
+
+// New monta a aplicação sobre os repositórios informados, sem iniciar as tarefas em segundo plano
+func New(cfg *config.Config, repos *Repositories) *App {
+	// Barramento de eventos de domínio sobre a caixa de saída
+	eventBus := events.NewOutboxBus(repos.Outbox)
+
+	lockout := application.LockoutPolicy{
+		Account: domain.BackoffPolicy{
+			Threshold: cfg.Lockout.AccountMaxFailures,
+			BaseDelay: cfg.Lockout.BaseDelay,
+			MaxDelay:  cfg.Lockout.MaxDelay,
+			Window:    cfg.Lockout.FailureWindow,
+		},
+		IP: domain.BackoffPolicy{
+			Threshold: cfg.Lockout.IPMaxFailures,
+			BaseDelay: cfg.Lockout.BaseDelay,
+			MaxDelay:  cfg.Lockout.MaxDelay,
+			Window:    cfg.Lockout.FailureWindow,
+		},
+	}
+	policy := application.NewPolicy()
+
+	a := &App{
+		Config:        cfg,
+		Repositories:  repos,
+		Events:        eventBus,
+		Auth:          application.NewAuthService(repos.Users, repos.Tokens, repos.LoginAttempts, lockout, cfg.JWT.Secret, cfg.JWT.Expiration, cfg.JWT.RefreshExpiration),
+		Posts:         application.NewPostService(repos.Posts, repos.Users, repos.Revisions, repos.Reactions, eventBus, policy),
+		Comments:      application.NewCommentService(repos.Comments, repos.Posts, repos.Users, repos.Reactions, eventBus, policy, maxCommentDepth),
+		Admin:         application.NewAdminService(repos.Users, repos.Posts, repos.Comments, repos.Audit, repos.LoginAttempts, policy),
+		Users:         application.NewUserService(repos.Users, repos.Posts, repos.Comments, repos.Revisions, repos.Tokens, repos.Reactions, repos.Notifications, repos.LoginAttempts, repos.Audit),
+		Notifications: application.NewNotificationService(repos.Notifications, repos.Posts, repos.Comments, repos.Users),
+	}
+
+	// Assinar os eventos que geram notificações
+	eventBus.Subscribe(domain.EventPostPublished, a.Notifications.HandleEvent)
+	eventBus.Subscribe(domain.EventCommentAdded, a.Notifications.HandleEvent)
+	eventBus.Subscribe(domain.EventCommentReplied, a.Notifications.HandleEvent)
+
+	return a
+}
+
+// BootstrapAdmin promove a administrador a conta de ADMIN_EMAIL, quando configurado. Uma
+// conta ainda não cadastrada não impede a inicialização: basta cadastrá-la e reiniciar.
+func (a *App) BootstrapAdmin(ctx context.Context) error {
+	if a.Config.Admin.Email == "" {
+		return nil
+	}
+
+	_, err := a.Admin.BootstrapAdmin(ctx, a.Config.Admin.Email)
+	if errors.Is(err, application.ErrUserNotFound) {
+		log.Printf("ADMIN_EMAIL %s não pertence a nenhuma conta; cadastre-a e reinicie a aplicação", a.Config.Admin.Email)
+		return nil
+	}
+
+	return err
+}
+
+// Start inicia a entrega de eventos e a publicação de posts agendados, que executam até ctx ser cancelado
+func (a *App) Start(ctx context.Context) {
+	go a.Events.Run(ctx, a.Config.Server.EventDispatchInterval)
+	go application.NewPostScheduler(a.Posts, a.Config.Server.SchedulerInterval).Run(ctx)
+}
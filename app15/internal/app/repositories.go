@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/adapters/db/memory"
+	sqladapter "github.com/caiquemiranda/go-apps/app15/internal/adapters/db/sql"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Repositories agrupa as implementações das portas de repositório usadas pela aplicação
+type Repositories struct {
+	Users         repositories.UserRepository
+	Posts         repositories.PostRepository
+	Comments      repositories.CommentRepository
+	Tokens        repositories.TokenRepository
+	Audit         repositories.AuditRepository
+	Revisions     repositories.RevisionRepository
+	Outbox        repositories.OutboxRepository
+	Notifications repositories.NotificationRepository
+	LoginAttempts repositories.LoginAttemptRepository
+	Reactions     repositories.ReactionRepository
+}
+
+// NewRepositories cria e retorna instâncias de todos os repositórios para o driver informado.
+// Sem driver, ou com o driver "memory", os dados ficam apenas em memória.
+func NewRepositories(driver, dsn string) (*Repositories, error) {
+	switch driver {
+	case "", "memory":
+		return NewMemoryRepositories(), nil
+	case sqladapter.DriverSQLite, sqladapter.DriverPostgres:
+		db, err := sqladapter.Open(driver, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao conectar ao banco de dados: %w", err)
+		}
+		return &Repositories{
+			Users:         sqladapter.NewUserRepository(db),
+			Posts:         sqladapter.NewPostRepository(db),
+			Comments:      sqladapter.NewCommentRepository(db),
+			Tokens:        sqladapter.NewTokenRepository(db),
+			Audit:         sqladapter.NewAuditRepository(db),
+			Revisions:     sqladapter.NewRevisionRepository(db),
+			Outbox:        sqladapter.NewOutboxRepository(db),
+			Notifications: sqladapter.NewNotificationRepository(db),
+			LoginAttempts: sqladapter.NewLoginAttemptRepository(db),
+			Reactions:     sqladapter.NewReactionRepository(db),
+		}, nil
+	default:
+		return nil, fmt.Errorf("driver de banco de dados não suportado: %s", driver)
+	}
+}
+
+// NewMemoryRepositories cria repositórios que guardam os dados apenas em memória,
+// usados no driver "memory" e nos testes
+func NewMemoryRepositories() *Repositories {
+	outbox := memory.NewOutboxRepository()
+	reactions := memory.NewReactionRepository()
+	comments := memory.NewCommentRepository(outbox)
+	revisions := memory.NewRevisionRepository()
+	return &Repositories{
+		Users:         memory.NewUserRepository(),
+		Posts:         memory.NewPostRepository(comments, revisions, reactions, outbox),
+		Comments:      comments,
+		Tokens:        memory.NewTokenRepository(),
+		Audit:         memory.NewAuditRepository(),
+		Revisions:     revisions,
+		Outbox:        outbox,
+		Notifications: memory.NewNotificationRepository(),
+		LoginAttempts: memory.NewLoginAttemptRepository(),
+		Reactions:     reactions,
+	}
+}
@@ -0,0 +1,229 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// Errors específicos do serviço de administração
+var (
+	ErrInvalidRole = errors.New("papel inválido")
+	ErrLastAdmin   = errors.New("a aplicação precisa de pelo menos um administrador")
+)
+
+// AdminService representa o serviço de administração e moderação da aplicação.
+// Toda ação executada por ele é registrada na trilha de auditoria.
+type AdminService struct {
+	userRepo    repositories.UserRepository
+	postRepo    repositories.PostRepository
+	commentRepo repositories.CommentRepository
+	auditRepo   repositories.AuditRepository
+	attemptRepo repositories.LoginAttemptRepository
+	policy      *Policy
+}
+
+// NewAdminService cria uma nova instância do serviço de administração
+func NewAdminService(
+	userRepo repositories.UserRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	auditRepo repositories.AuditRepository,
+	attemptRepo repositories.LoginAttemptRepository,
+	policy *Policy,
+) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		auditRepo:   auditRepo,
+		attemptRepo: attemptRepo,
+		policy:      policy,
+	}
+}
+
+// RoleRequest representa a estrutura de dados para alteração de papel de um usuário
+type RoleRequest struct {
+	Role domain.Role `json:"role" validate:"required,oneof=reader author moderator admin"`
+}
+
+// ModerationRequest representa a estrutura de dados para remoção forçada de conteúdo
+type ModerationRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+// ChangeRole altera o papel de um usuário; apenas administradores podem fazê-lo.
+// Retorna ErrLastAdmin se a alteração deixaria a aplicação sem administradores.
+func (s *AdminService) ChangeRole(ctx context.Context, actorID, userID string, req RoleRequest) (*domain.User, error) {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || !s.policy.CanManageRoles(actor) {
+		return nil, ErrNotAuthorized
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	previous := user.Role
+	if err := user.UpdateRole(req.Role); err != nil {
+		return nil, ErrInvalidRole
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, user); err != nil {
+		if errors.Is(err, repositories.ErrLastAdmin) {
+			return nil, ErrLastAdmin
+		}
+		return nil, err
+	}
+
+	details := fmt.Sprintf("%s -> %s", previous, user.Role)
+	if err := s.audit(ctx, actorID, domain.AuditActionChangeRole, "user", user.ID, details); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// BootstrapAdmin promove a administrador a conta com o email informado, como configurado
+// em ADMIN_EMAIL. A promoção é registrada na trilha de auditoria em nome do sistema; uma
+// conta que já é admin não é alterada. Retorna ErrUserNotFound se a conta não existir.
+func (s *AdminService) BootstrapAdmin(ctx context.Context, email string) (*domain.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if user.Role == domain.RoleAdmin {
+		return user, nil
+	}
+
+	previous := user.Role
+	if err := user.UpdateRole(domain.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, user); err != nil {
+		return nil, err
+	}
+
+	details := fmt.Sprintf("%s -> %s (ADMIN_EMAIL)", previous, user.Role)
+	if err := s.audit(ctx, domain.AuditActorSystem, domain.AuditActionChangeRole, "user", user.ID, details); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UnlockUser desbloqueia o login de um usuário, esquecendo as falhas de senha da conta;
+// apenas administradores podem fazê-lo
+func (s *AdminService) UnlockUser(ctx context.Context, actorID, userID string) error {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || !s.policy.CanManageAccounts(actor) {
+		return ErrNotAuthorized
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if err := s.attemptRepo.DeleteLockout(ctx, domain.LockoutAccountKey(user.Email)); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, actorID, domain.AuditActionUnlockUser, "user", user.ID, "")
+}
+
+// ForceDeletePost remove o post de qualquer autor; exige papel de moderador
+func (s *AdminService) ForceDeletePost(ctx context.Context, actorID, postID string, req ModerationRequest) error {
+	if err := s.authorizeModeration(ctx, actorID); err != nil {
+		return err
+	}
+
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return ErrPostNotFound
+	}
+
+	if err := s.postRepo.Delete(ctx, post.ID); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, actorID, domain.AuditActionDeletePost, "post", post.ID, req.Reason)
+}
+
+// ForceDeleteComment remove o comentário de qualquer autor; exige papel de moderador
+func (s *AdminService) ForceDeleteComment(ctx context.Context, actorID, commentID string, req ModerationRequest) error {
+	if err := s.authorizeModeration(ctx, actorID); err != nil {
+		return err
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, commentID)
+	if err != nil || comment.Deleted {
+		return ErrCommentNotFound
+	}
+
+	if err := removeComment(ctx, s.commentRepo, comment); err != nil {
+		return err
+	}
+
+	return s.audit(ctx, actorID, domain.AuditActionDeleteComment, "comment", comment.ID, req.Reason)
+}
+
+// ListUsers retorna uma página dos usuários cadastrados, com email e papel; exige papel de administrador
+func (s *AdminService) ListUsers(ctx context.Context, actorID string, query PageQuery) (*domain.Page[*domain.User], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
+	}
+
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || !s.policy.CanManageAccounts(actor) {
+		return nil, ErrNotAuthorized
+	}
+
+	return s.userRepo.List(ctx, req)
+}
+
+// ListAuditLog retorna uma lista paginada da trilha de auditoria; exige papel de moderador
+func (s *AdminService) ListAuditLog(ctx context.Context, actorID string, page, pageSize int) ([]*domain.AuditEntry, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	if err := s.authorizeModeration(ctx, actorID); err != nil {
+		return nil, err
+	}
+
+	return s.auditRepo.List(ctx, page, pageSize)
+}
+
+// authorizeModeration verifica se o usuário pode moderar conteúdo
+func (s *AdminService) authorizeModeration(ctx context.Context, actorID string) error {
+	actor, err := s.userRepo.GetByID(ctx, actorID)
+	if err != nil || !s.policy.CanModerate(actor) {
+		return ErrNotAuthorized
+	}
+	return nil
+}
+
+// audit registra uma ação na trilha de auditoria
+func (s *AdminService) audit(ctx context.Context, actorID, action, targetType, targetID, details string) error {
+	entry, err := domain.NewAuditEntry(actorID, action, targetType, targetID, details)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = uuid.New().String()
+
+	return s.auditRepo.Create(ctx, entry)
+}
@@ -5,8 +5,8 @@ import (
 	"errors"
 	"time"
 
-	"app15/internal/domain"
-	"app15/internal/ports/repositories"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -17,63 +17,116 @@ var (
 	ErrInvalidCredentials = errors.New("credenciais inválidas")
 	ErrUserAlreadyExists  = errors.New("usuário já existe")
 	ErrUserNotFound       = errors.New("usuário não encontrado")
+	ErrInvalidToken       = errors.New("token inválido")
+	ErrTokenRevoked       = errors.New("token revogado")
+	ErrRefreshTokenReused = errors.New("token de renovação reutilizado; sessão encerrada")
+	ErrAccountLocked      = errors.New("conta bloqueada temporariamente por excesso de tentativas de login")
+	ErrTooManyAttempts    = errors.New("muitas tentativas de login a partir deste endereço")
 )
 
 // AuthService representa o serviço de autenticação da aplicação
 type AuthService struct {
-	userRepo repositories.UserRepository
-	jwtKey   []byte
-	jwtExp   time.Duration
+	userRepo    repositories.UserRepository
+	tokenRepo   repositories.TokenRepository
+	attemptRepo repositories.LoginAttemptRepository
+	lockout     LockoutPolicy
+	jwtKey      []byte
+	jwtExp      time.Duration
+	refreshExp  time.Duration
 }
 
-// NewAuthService cria uma nova instância do serviço de autenticação
-func NewAuthService(userRepo repositories.UserRepository, jwtKey string, jwtExp time.Duration) *AuthService {
+// NewAuthService cria uma nova instância do serviço de autenticação.
+// lockout define o bloqueio do login após falhas seguidas, jwtExp a validade dos tokens
+// de acesso e refreshExp a dos tokens de renovação.
+func NewAuthService(
+	userRepo repositories.UserRepository,
+	tokenRepo repositories.TokenRepository,
+	attemptRepo repositories.LoginAttemptRepository,
+	lockout LockoutPolicy,
+	jwtKey string,
+	jwtExp time.Duration,
+	refreshExp time.Duration,
+) *AuthService {
 	return &AuthService{
-		userRepo: userRepo,
-		jwtKey:   []byte(jwtKey),
-		jwtExp:   jwtExp,
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		attemptRepo: attemptRepo,
+		lockout:     lockout,
+		jwtKey:      []byte(jwtKey),
+		jwtExp:      jwtExp,
+		refreshExp:  refreshExp,
 	}
 }
 
 // LoginRequest representa a estrutura de dados para requisição de login
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
 }
 
 // RegisterRequest representa a estrutura de dados para requisição de registro
 type RegisterRequest struct {
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required,min=3,max=32,username"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// RefreshRequest representa a estrutura de dados para renovação de tokens e logout
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
 // AuthResponse representa a estrutura de dados de resposta após autenticação
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  *domain.User `json:"user"`
+	Token        string       `json:"token"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	RefreshToken string       `json:"refresh_token"`
+	User         *domain.User `json:"user"`
 }
 
-// Login autentica um usuário e retorna um token JWT
-func (s *AuthService) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
+// ClientInfo identifica a origem de uma tentativa de login
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// Login autentica um usuário e retorna um token JWT. As falhas são contabilizadas por
+// conta e por IP; enquanto um deles estiver bloqueado, o login é recusado sem verificar a senha.
+func (s *AuthService) Login(ctx context.Context, req LoginRequest, client ClientInfo) (*AuthResponse, error) {
+	now := time.Now()
+
+	if err := s.checkLockouts(ctx, req.Email, client.IP, now); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, ErrInvalidCredentials
+		// Emails inexistentes contam falhas como contas reais, sem revelar quais existem
+		return nil, s.loginFailed(ctx, "", req.Email, client, now)
 	}
 
 	if !user.ValidatePassword(req.Password) {
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, user.ID, req.Email, client, now)
 	}
 
-	token, err := s.generateToken(user)
-	if err != nil {
+	if err := s.loginSucceeded(ctx, user, req.Email, client); err != nil {
 		return nil, err
 	}
 
-	return &AuthResponse{
-		Token: token,
-		User:  user,
-	}, nil
+	return s.startSession(ctx, user)
+}
+
+// ListLoginHistory retorna uma lista paginada das tentativas de login na conta do usuário,
+// da mais recente para a mais antiga
+func (s *AuthService) ListLoginHistory(ctx context.Context, userID string, page, pageSize int) ([]*domain.LoginAttempt, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return s.attemptRepo.ListByUser(ctx, userID, page, pageSize)
 }
 
 // Register registra um novo usuário no sistema
@@ -105,16 +158,67 @@ func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*AuthR
 		return nil, err
 	}
 
-	// Gerar token JWT
-	token, err := s.generateToken(user)
+	// Iniciar sessão com tokens de acesso e renovação
+	return s.startSession(ctx, user)
+}
+
+// Refresh troca um token de renovação válido por um novo par de tokens.
+// O token apresentado é revogado; apresentá-lo novamente revoga toda a sessão.
+func (s *AuthService) Refresh(ctx context.Context, req RefreshRequest) (*AuthResponse, error) {
+	current, err := s.tokenRepo.GetByHash(ctx, domain.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if current.IsRevoked() {
+		// Um token já utilizado indica que ele vazou: encerrar a sessão inteira
+		if err := s.tokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	if current.IsExpired() {
+		return nil, ErrInvalidToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, current.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	next, plain, err := s.newRefreshToken(user.ID, current.FamilyID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &AuthResponse{
-		Token: token,
-		User:  user,
-	}, nil
+	// A revogação condicional impede que duas renovações simultâneas usem o mesmo token
+	if err := s.tokenRepo.Revoke(ctx, current.ID, next.ID); err != nil {
+		if errors.Is(err, repositories.ErrTokenAlreadyRevoked) {
+			if err := s.tokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+				return nil, err
+			}
+			return nil, ErrRefreshTokenReused
+		}
+		return nil, err
+	}
+
+	if err := s.tokenRepo.Create(ctx, next); err != nil {
+		return nil, err
+	}
+
+	return s.buildResponse(user, current.FamilyID, plain)
+}
+
+// Logout encerra a sessão do token de renovação informado, invalidando também
+// os tokens de acesso emitidos para ela. Tokens desconhecidos são ignorados.
+func (s *AuthService) Logout(ctx context.Context, req RefreshRequest) error {
+	token, err := s.tokenRepo.GetByHash(ctx, domain.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return nil
+	}
+
+	return s.tokenRepo.RevokeFamily(ctx, token.FamilyID)
 }
 
 // GetUserByID busca um usuário pelo ID
@@ -126,8 +230,9 @@ func (s *AuthService) GetUserByID(ctx context.Context, id string) (*domain.User,
 	return user, nil
 }
 
-// ValidateToken valida um token JWT e retorna o ID do usuário
-func (s *AuthService) ValidateToken(tokenString string) (string, error) {
+// ValidateToken valida um token JWT e retorna o ID do usuário.
+// Tokens de sessões encerradas por logout ou por reutilização de token de renovação são rejeitados.
+func (s *AuthService) ValidateToken(ctx context.Context, tokenString string) (string, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("método de assinatura inesperado")
@@ -139,24 +244,82 @@ func (s *AuthService) ValidateToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID, ok := claims["user_id"].(string)
-		if !ok {
-			return "", errors.New("token inválido: campo user_id ausente")
-		}
-		return userID, nil
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", ErrInvalidToken
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok {
+		return "", errors.New("token inválido: campo user_id ausente")
+	}
+
+	familyID, ok := claims["family_id"].(string)
+	if !ok {
+		return "", errors.New("token inválido: campo family_id ausente")
+	}
+
+	active, err := s.tokenRepo.IsFamilyActive(ctx, familyID)
+	if err != nil {
+		return "", err
+	}
+	if !active {
+		return "", ErrTokenRevoked
+	}
+
+	return userID, nil
+}
+
+// startSession inicia uma nova família de tokens para o usuário
+func (s *AuthService) startSession(ctx context.Context, user *domain.User) (*AuthResponse, error) {
+	refreshToken, plain, err := s.newRefreshToken(user.ID, uuid.New().String())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, err
 	}
 
-	return "", errors.New("token inválido")
+	return s.buildResponse(user, refreshToken.FamilyID, plain)
 }
 
-// generateToken gera um novo token JWT para o usuário
-func (s *AuthService) generateToken(user *domain.User) (string, error) {
+// newRefreshToken cria um token de renovação na família informada
+func (s *AuthService) newRefreshToken(userID, familyID string) (*domain.RefreshToken, string, error) {
+	token, plain, err := domain.NewRefreshToken(userID, familyID, s.refreshExp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token.ID = uuid.New().String()
+
+	return token, plain, nil
+}
+
+// buildResponse gera o token de acesso e monta a resposta de autenticação
+func (s *AuthService) buildResponse(user *domain.User, familyID, refreshToken string) (*AuthResponse, error) {
 	expirationTime := time.Now().Add(s.jwtExp)
 
+	token, err := s.generateToken(user, familyID, expirationTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		Token:        token,
+		ExpiresAt:    expirationTime,
+		RefreshToken: refreshToken,
+		User:         user,
+	}, nil
+}
+
+// generateToken gera um novo token JWT de acesso para o usuário
+func (s *AuthService) generateToken(user *domain.User, familyID string, expirationTime time.Time) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":   user.ID,
 		"username":  user.Username,
+		"role":      string(user.Role),
+		"family_id": familyID,
 		"exp":       expirationTime.Unix(),
 		"issued_at": time.Now().Unix(),
 	}
@@ -3,9 +3,11 @@ package application
 import (
 	"context"
 	"errors"
+	"time"
 
-	"app15/internal/domain"
-	"app15/internal/ports/repositories"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/events"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
 
 	"github.com/google/uuid"
 )
@@ -14,85 +16,139 @@ import (
 var (
 	ErrCommentNotFound    = errors.New("comentário não encontrado")
 	ErrInvalidCommentData = errors.New("dados do comentário inválidos")
+	ErrParentNotFound     = errors.New("comentário respondido não encontrado")
+	ErrMaxDepthExceeded   = errors.New("limite de respostas aninhadas atingido")
 )
 
 // CommentService representa o serviço de comentários da aplicação
 type CommentService struct {
 	commentRepo repositories.CommentRepository
 	postRepo    repositories.PostRepository
-	userRepo    repositories.UserRepository
+	userRepo     repositories.UserRepository
+	reactionRepo repositories.ReactionRepository
+	events       events.EventBus
+	policy       *Policy
+	maxDepth     int
 }
 
-// NewCommentService cria uma nova instância do serviço de comentários
+// NewCommentService cria uma nova instância do serviço de comentários.
+// maxDepth limita o aninhamento de respostas: comentários de primeiro nível têm profundidade 0.
 func NewCommentService(
 	commentRepo repositories.CommentRepository,
 	postRepo repositories.PostRepository,
 	userRepo repositories.UserRepository,
+	reactionRepo repositories.ReactionRepository,
+	events events.EventBus,
+	policy *Policy,
+	maxDepth int,
 ) *CommentService {
 	return &CommentService{
-		commentRepo: commentRepo,
-		postRepo:    postRepo,
-		userRepo:    userRepo,
+		commentRepo:  commentRepo,
+		postRepo:     postRepo,
+		userRepo:     userRepo,
+		reactionRepo: reactionRepo,
+		events:       events,
+		policy:       policy,
+		maxDepth:     maxDepth,
 	}
 }
 
 // CommentRequest representa a estrutura de dados para criação/atualização de comentários
 type CommentRequest struct {
-	Content string `json:"content"`
+	Content  string `json:"content" validate:"required,max=5000"`
+	ParentID string `json:"parent_id,omitempty" validate:"omitempty,max=64"`
 }
 
 // CreateComment cria um novo comentário
 func (s *CommentService) CreateComment(ctx context.Context, postID string, req CommentRequest, authorID string) (*domain.Comment, error) {
-	// Verificar se o post existe
-	_, err := s.postRepo.GetByID(ctx, postID)
-	if err != nil {
+	// Verificar se o post existe e está publicado
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil || !post.IsPublished() {
 		return nil, ErrPostNotFound
 	}
 
-	// Verificar se o autor existe
-	_, err = s.userRepo.GetByID(ctx, authorID)
+	// Verificar se o autor existe e pode comentar
+	author, err := s.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
+	if !s.policy.CanCreateComment(author) {
+		return nil, ErrNotAuthorized
+	}
+
 	// Criar novo comentário
 	comment, err := domain.NewComment(req.Content, postID, authorID)
 	if err != nil {
 		return nil, ErrInvalidCommentData
 	}
 
+	// Posicionar a resposta na árvore de comentários
+	if req.ParentID != "" {
+		parent, err := s.commentRepo.GetByID(ctx, req.ParentID)
+		if err != nil || parent.Deleted {
+			return nil, ErrParentNotFound
+		}
+
+		if err := comment.SetParent(parent); err != nil {
+			return nil, ErrParentNotFound
+		}
+
+		if comment.Depth > s.maxDepth {
+			return nil, ErrMaxDepthExceeded
+		}
+	}
+
 	// Gerar ID único para o comentário
 	comment.ID = uuid.New().String()
 
-	// Salvar comentário no repositório
-	err = s.commentRepo.Create(ctx, comment)
+	// Notificar o autor do post e, nas respostas, o autor do comentário respondido
+	commentEvents, err := domain.NewCommentEvents(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Salvar comentário no repositório, com os eventos na mesma transação
+	err = s.commentRepo.Create(ctx, comment, withEventIDs(commentEvents...)...)
 	if err != nil {
 		return nil, err
 	}
+	s.events.Notify()
 
 	return comment, nil
 }
 
-// GetCommentByID busca um comentário pelo ID
-func (s *CommentService) GetCommentByID(ctx context.Context, id string) (*domain.Comment, error) {
+// GetCommentByID busca um comentário pelo ID. Comentários de posts que o usuário não
+// pode ver são tratados como inexistentes.
+func (s *CommentService) GetCommentByID(ctx context.Context, id string, viewerID string) (*domain.Comment, error) {
 	comment, err := s.commentRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrCommentNotFound
 	}
-	return comment, nil
+
+	if _, err := s.visiblePost(ctx, comment.PostID, viewerID); err != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	comments := []*domain.Comment{comment}
+	if err := s.expand(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comments[0], nil
 }
 
 // UpdateComment atualiza um comentário existente
 func (s *CommentService) UpdateComment(ctx context.Context, id string, req CommentRequest, userID string) (*domain.Comment, error) {
 	// Buscar o comentário existente
 	comment, err := s.commentRepo.GetByID(ctx, id)
-	if err != nil {
+	if err != nil || comment.Deleted {
 		return nil, ErrCommentNotFound
 	}
 
-	// Verificar se o usuário é o autor do comentário
-	if !comment.IsAuthor(userID) {
-		return nil, ErrNotAuthorized
+	// Verificar se o usuário pode alterar o comentário
+	if err := s.authorize(ctx, userID, comment); err != nil {
+		return nil, err
 	}
 
 	// Atualizar o conteúdo do comentário
@@ -106,58 +162,185 @@ func (s *CommentService) UpdateComment(ctx context.Context, id string, req Comme
 		return nil, err
 	}
 
-	return comment, nil
+	comments := []*domain.Comment{comment}
+	if err := s.expand(ctx, comments); err != nil {
+		return nil, err
+	}
+
+	return comments[0], nil
 }
 
 // DeleteComment remove um comentário
 func (s *CommentService) DeleteComment(ctx context.Context, id string, userID string) error {
 	// Buscar o comentário existente
 	comment, err := s.commentRepo.GetByID(ctx, id)
-	if err != nil {
+	if err != nil || comment.Deleted {
 		return ErrCommentNotFound
 	}
 
-	// Verificar se o usuário é o autor do comentário
-	if !comment.IsAuthor(userID) {
-		return ErrNotAuthorized
+	// Verificar se o usuário pode remover o comentário
+	if err := s.authorize(ctx, userID, comment); err != nil {
+		return err
 	}
 
 	// Remover o comentário do repositório
-	return s.commentRepo.Delete(ctx, id)
+	return removeComment(ctx, s.commentRepo, comment)
 }
 
-// ListCommentsByPost retorna uma lista paginada de comentários de um post específico
-func (s *CommentService) ListCommentsByPost(ctx context.Context, postID string, page, pageSize int) ([]*domain.Comment, error) {
-	if page < 1 {
-		page = 1
+// ListCommentsByPost retorna uma página de comentários de um post específico, em ordem cronológica.
+// Os comentários de posts não publicados só são listados para quem pode ver o post.
+func (s *CommentService) ListCommentsByPost(ctx context.Context, postID string, viewerID string, query PageQuery) (*domain.Page[*domain.Comment], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	if _, err := s.visiblePost(ctx, postID, viewerID); err != nil {
+		return nil, err
 	}
 
-	// Verificar se o post existe
-	_, err := s.postRepo.GetByID(ctx, postID)
+	page, err := s.commentRepo.ListByPost(ctx, postID, req)
 	if err != nil {
-		return nil, ErrPostNotFound
+		return nil, err
 	}
 
-	return s.commentRepo.ListByPost(ctx, postID, page, pageSize)
+	if err := s.expand(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
 }
 
-// ListCommentsByAuthor retorna uma lista paginada de comentários de um autor específico
-func (s *CommentService) ListCommentsByAuthor(ctx context.Context, authorID string, page, pageSize int) ([]*domain.Comment, error) {
-	if page < 1 {
-		page = 1
+// ListThread retorna a árvore de comentários de um post a partir de parentID (vazio para a raiz).
+// O nível solicitado é paginado pela consulta; cada nível aninhado traz a primeira página
+// de respostas, até depth níveis abaixo (limitado pela profundidade máxima configurada).
+// Assim como na listagem, o post precisa estar visível para o usuário.
+func (s *CommentService) ListThread(ctx context.Context, postID, parentID string, depth int, viewerID string, query PageQuery) (*domain.Page[*domain.CommentThread], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+	if depth < 0 || depth > s.maxDepth {
+		depth = s.maxDepth
+	}
+
+	if _, err := s.visiblePost(ctx, postID, viewerID); err != nil {
+		return nil, err
+	}
+
+	// Verificar se o comentário de partida pertence ao post
+	if parentID != "" {
+		parent, err := s.commentRepo.GetByID(ctx, parentID)
+		if err != nil || parent.PostID != postID {
+			return nil, ErrParentNotFound
+		}
+	}
+
+	page, err := s.commentRepo.ListThread(ctx, postID, parentID, depth, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateThreadComments(page.Items, func(comments []*domain.Comment) error {
+		return s.expand(ctx, comments)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// ListCommentsByAuthor retorna uma página de comentários de um autor específico, em ordem cronológica
+func (s *CommentService) ListCommentsByAuthor(ctx context.Context, authorID string, query PageQuery) (*domain.Page[*domain.Comment], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
 	}
 
 	// Verificar se o autor existe
-	_, err := s.userRepo.GetByID(ctx, authorID)
+	_, err = s.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
-	return s.commentRepo.ListByAuthor(ctx, authorID, page, pageSize)
-} 
\ No newline at end of file
+	page, err := s.commentRepo.ListByAuthor(ctx, authorID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expand(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// LikeComment registra a curtida do usuário em um comentário de um post publicado.
+// Curtir de novo não altera a contagem.
+func (s *CommentService) LikeComment(ctx context.Context, id string, userID string) (*domain.ReactionSummary, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil || comment.Deleted {
+		return nil, ErrCommentNotFound
+	}
+
+	post, err := s.postRepo.GetByID(ctx, comment.PostID)
+	if err != nil || !post.IsPublished() {
+		return nil, ErrPostNotFound
+	}
+
+	return react(ctx, s.reactionRepo, userID, domain.ReactionTargetComment, comment.ID, true)
+}
+
+// UnlikeComment desfaz a curtida do usuário em um comentário
+func (s *CommentService) UnlikeComment(ctx context.Context, id string, userID string) (*domain.ReactionSummary, error) {
+	comment, err := s.commentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrCommentNotFound
+	}
+
+	return react(ctx, s.reactionRepo, userID, domain.ReactionTargetComment, comment.ID, false)
+}
+
+// expand preenche as curtidas e o autor dos comentários
+func (s *CommentService) expand(ctx context.Context, comments []*domain.Comment) error {
+	if err := countCommentLikes(ctx, s.reactionRepo, comments); err != nil {
+		return err
+	}
+	return expandCommentAuthors(ctx, s.userRepo, comments)
+}
+
+// visiblePost busca o post dos comentários, tratando como inexistentes os posts não
+// publicados que o usuário não pode ver
+func (s *CommentService) visiblePost(ctx context.Context, postID string, viewerID string) (*domain.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	if !post.IsPublished() && !canViewUnpublished(ctx, s.userRepo, s.policy, viewerID, post) {
+		return nil, ErrPostNotFound
+	}
+
+	return post, nil
+}
+
+// authorize verifica, pela política, se o usuário pode alterar o comentário
+func (s *CommentService) authorize(ctx context.Context, userID string, comment *domain.Comment) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrNotAuthorized
+	}
+
+	if !s.policy.CanModifyComment(user, comment) {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
+
+// removeComment remove o comentário do repositório. Comentários com respostas são
+// mantidos como lápide, sem conteúdo, para não deixar as respostas órfãs.
+func removeComment(ctx context.Context, repo repositories.CommentRepository, comment *domain.Comment) error {
+	return repo.Remove(ctx, comment.ID, time.Now())
+}
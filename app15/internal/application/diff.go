@@ -0,0 +1,87 @@
+package application
+
+import (
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// diffRevisions compara título, conteúdo e tags de duas revisões
+func diffRevisions(from, to *domain.PostRevision) *domain.RevisionDiff {
+	diff := &domain.RevisionDiff{
+		PostID:      from.PostID,
+		From:        from.Version,
+		To:          to.Version,
+		Title:       diffLines(from.Title, to.Title),
+		Content:     diffLines(from.Content, to.Content),
+		TagsAdded:   []string{},
+		TagsRemoved: []string{},
+	}
+
+	fromTags := make(map[string]bool, len(from.Tags))
+	for _, tag := range from.Tags {
+		fromTags[tag] = true
+	}
+	toTags := make(map[string]bool, len(to.Tags))
+	for _, tag := range to.Tags {
+		toTags[tag] = true
+		if !fromTags[tag] {
+			diff.TagsAdded = append(diff.TagsAdded, tag)
+		}
+	}
+	for _, tag := range from.Tags {
+		if !toTags[tag] {
+			diff.TagsRemoved = append(diff.TagsRemoved, tag)
+		}
+	}
+
+	return diff
+}
+
+// diffLines calcula o diff linha a linha entre dois textos pela maior subsequência comum
+func diffLines(from, to string) []domain.DiffLine {
+	a := strings.Split(from, "\n")
+	b := strings.Split(to, "\n")
+
+	// lcs[i][j] é o tamanho da maior subsequência comum entre a[i:] e b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]domain.DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, domain.DiffLine{Op: domain.DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, domain.DiffLine{Op: domain.DiffDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, domain.DiffLine{Op: domain.DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, domain.DiffLine{Op: domain.DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, domain.DiffLine{Op: domain.DiffInsert, Text: b[j]})
+	}
+
+	return lines
+}
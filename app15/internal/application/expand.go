@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// loadAuthors busca de uma só vez os autores com os IDs informados. Contas removidas
+// e autores que não existem mais são representados pelo usuário removido.
+func loadAuthors(ctx context.Context, userRepo repositories.UserRepository, ids []string) (map[string]*domain.PublicUser, error) {
+	authors := make(map[string]*domain.PublicUser, len(ids))
+
+	pending := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, seen := authors[id]; seen {
+			continue
+		}
+		authors[id] = domain.DeletedPublicUser()
+		if id != domain.DeletedUserID {
+			pending = append(pending, id)
+		}
+	}
+
+	if len(pending) == 0 {
+		return authors, nil
+	}
+
+	users, err := userRepo.GetByIDs(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		authors[user.ID] = user.Public()
+	}
+
+	return authors, nil
+}
+
+// expandPostAuthors preenche o autor dos posts com uma única consulta por página. Os posts são substituídos por cópias
+// para não alterar as instâncias compartilhadas com o repositório.
+func expandPostAuthors(ctx context.Context, userRepo repositories.UserRepository, posts []*domain.Post) error {
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.AuthorID
+	}
+
+	authors, err := loadAuthors(ctx, userRepo, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, post := range posts {
+		copied := *post
+		copied.Author = authors[post.AuthorID]
+		posts[i] = &copied
+	}
+
+	return nil
+}
+
+// expandCommentAuthors preenche o autor dos comentários, substituindo-os por cópias
+func expandCommentAuthors(ctx context.Context, userRepo repositories.UserRepository, comments []*domain.Comment) error {
+	ids := make([]string, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.AuthorID
+	}
+
+	authors, err := loadAuthors(ctx, userRepo, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, comment := range comments {
+		copied := *comment
+		copied.Author = authors[comment.AuthorID]
+		comments[i] = &copied
+	}
+
+	return nil
+}
+
+// updateThreadComments aplica update a todos os comentários da árvore de uma só vez e
+// coloca de volta nos nós os comentários substituídos por update
+func updateThreadComments(threads []*domain.CommentThread, update func([]*domain.Comment) error) error {
+	nodes := make([]*domain.CommentThread, 0, len(threads))
+	var collect func([]*domain.CommentThread)
+	collect = func(level []*domain.CommentThread) {
+		for _, thread := range level {
+			nodes = append(nodes, thread)
+			collect(thread.Replies)
+		}
+	}
+	collect(threads)
+
+	comments := make([]*domain.Comment, len(nodes))
+	for i, node := range nodes {
+		comments[i] = node.Comment
+	}
+
+	if err := update(comments); err != nil {
+		return err
+	}
+
+	for i, node := range nodes {
+		node.Comment = comments[i]
+	}
+
+	return nil
+}
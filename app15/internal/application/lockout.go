@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// LockoutPolicy configura o bloqueio do login por conta e por IP
+type LockoutPolicy struct {
+	Account domain.BackoffPolicy
+	IP      domain.BackoffPolicy
+}
+
+// LoginLockedError indica que o login está bloqueado e até quando.
+// Err é ErrAccountLocked ou ErrTooManyAttempts.
+type LoginLockedError struct {
+	Err   error
+	Until time.Time
+}
+
+// Error implementa a interface error
+func (e *LoginLockedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap permite identificar o motivo do bloqueio com errors.Is
+func (e *LoginLockedError) Unwrap() error {
+	return e.Err
+}
+
+// checkLockouts recusa o login se o IP ou a conta estiverem bloqueados
+func (s *AuthService) checkLockouts(ctx context.Context, email, ip string, now time.Time) error {
+	ipLockout, err := s.attemptRepo.GetLockout(ctx, domain.LockoutIPKey(ip))
+	if err != nil {
+		return err
+	}
+	if ipLockout.IsLocked(now) {
+		return &LoginLockedError{Err: ErrTooManyAttempts, Until: *ipLockout.LockedUntil}
+	}
+
+	accountLockout, err := s.attemptRepo.GetLockout(ctx, domain.LockoutAccountKey(email))
+	if err != nil {
+		return err
+	}
+	if accountLockout.IsLocked(now) {
+		return &LoginLockedError{Err: ErrAccountLocked, Until: *accountLockout.LockedUntil}
+	}
+
+	return nil
+}
+
+// loginFailed registra a tentativa, contabiliza a falha no IP e na conta e retorna ErrInvalidCredentials.
+// A contagem é feita atomicamente pelo repositório, para que tentativas simultâneas não
+// sobrescrevam as falhas umas das outras.
+func (s *AuthService) loginFailed(ctx context.Context, userID, email string, client ClientInfo, now time.Time) error {
+	if err := s.recordAttempt(ctx, userID, email, client, false); err != nil {
+		return err
+	}
+
+	if _, err := s.attemptRepo.RegisterFailure(ctx, domain.LockoutIPKey(client.IP), s.lockout.IP, now); err != nil {
+		return err
+	}
+
+	if _, err := s.attemptRepo.RegisterFailure(ctx, domain.LockoutAccountKey(email), s.lockout.Account, now); err != nil {
+		return err
+	}
+
+	return ErrInvalidCredentials
+}
+
+// loginSucceeded registra a tentativa e zera as falhas da conta. As falhas do IP são
+// mantidas, para que uma senha correta não libere novas tentativas em outras contas.
+func (s *AuthService) loginSucceeded(ctx context.Context, user *domain.User, email string, client ClientInfo) error {
+	if err := s.recordAttempt(ctx, user.ID, email, client, true); err != nil {
+		return err
+	}
+
+	return s.attemptRepo.DeleteLockout(ctx, domain.LockoutAccountKey(email))
+}
+
+// recordAttempt registra uma tentativa de login no histórico
+func (s *AuthService) recordAttempt(ctx context.Context, userID, email string, client ClientInfo, success bool) error {
+	attempt, err := domain.NewLoginAttempt(userID, email, client.IP, client.UserAgent, success)
+	if err != nil {
+		return err
+	}
+
+	attempt.ID = uuid.New().String()
+
+	return s.attemptRepo.Create(ctx, attempt)
+}
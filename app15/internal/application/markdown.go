@@ -0,0 +1,221 @@
+package application
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/net/html"
+)
+
+// excerptWords é a quantidade de palavras do resumo exibido nas listagens
+const excerptWords = 40
+
+// markdown converte o conteúdo dos posts em HTML. Sem a opção WithUnsafe, o HTML
+// escrito diretamente no Markdown é omitido; o resultado ainda passa pela lista de
+// elementos permitidos em sanitizeHTML.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+	),
+)
+
+// allowedTags lista os elementos permitidos no HTML dos posts e, para cada um, os atributos aceitos
+var allowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil,
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+	"strong": nil, "em": nil, "del": nil, "blockquote": nil,
+	"ul": nil, "ol": {"start"}, "li": nil,
+	"pre": nil, "code": {"class"},
+	"a":     {"href", "title"},
+	"img":   {"src", "alt", "title"},
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil,
+	"th": {"align"}, "td": {"align"},
+}
+
+// voidTags são os elementos sem conteúdo nem tag de fechamento
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// droppedTags são os elementos removidos junto com todo o seu conteúdo
+var droppedTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "noembed": true, "noframes": true, "textarea": true, "title": true,
+	"template": true, "svg": true, "math": true, "xmp": true, "plaintext": true,
+}
+
+// inlineTags são os elementos que não separam palavras no texto do resumo
+var inlineTags = map[string]bool{"a": true, "strong": true, "em": true, "del": true, "code": true}
+
+// codeLanguage valida a classe que indica a linguagem de um bloco de código
+var codeLanguage = regexp.MustCompile(`^language-[A-Za-z0-9_+#.-]+$`)
+
+// renderContent converte o conteúdo Markdown do post em HTML seguro e gera o resumo das listagens
+func renderContent(post *domain.Post) error {
+	rendered, err := renderMarkdown(post.Content)
+	if err != nil {
+		return err
+	}
+
+	post.ContentHTML = rendered
+	post.Excerpt = buildExcerpt(rendered)
+	return nil
+}
+
+// renderMissingContent gera o HTML dos posts gravados antes da renderização do Markdown.
+// Os posts devem ser cópias, como as criadas por countPostLikes: o resultado não é gravado.
+func renderMissingContent(posts []*domain.Post) error {
+	for _, post := range posts {
+		if post.ContentHTML == "" && post.Content != "" {
+			if err := renderContent(post); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderMarkdown converte o Markdown em HTML com apenas os elementos e atributos permitidos
+func renderMarkdown(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return sanitizeHTML(buf.String()), nil
+}
+
+// sanitizeHTML mantém apenas os elementos de allowedTags, com os atributos permitidos e
+// valores seguros. Os demais elementos são removidos, preservando o texto, exceto os de
+// droppedTags, removidos por inteiro. Comentários e declarações são descartados.
+func sanitizeHTML(source string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(source))
+	dropped := 0 // Profundidade dentro de elementos removidos por inteiro
+
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			if z.Err() != io.EOF {
+				return ""
+			}
+			return b.String()
+		}
+
+		token := z.Token()
+		switch tokenType {
+		case html.TextToken:
+			if dropped == 0 {
+				b.WriteString(html.EscapeString(token.Data))
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if droppedTags[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			attrs, ok := allowedTags[token.Data]
+			if dropped > 0 || !ok {
+				continue
+			}
+			b.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if attr.Namespace == "" && contains(attrs, attr.Key) && safeAttr(attr.Key, attr.Val) {
+					b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+				}
+			}
+			if token.Data == "a" {
+				b.WriteString(` rel="nofollow ugc"`)
+			}
+			b.WriteString(">")
+		case html.EndTagToken:
+			if droppedTags[token.Data] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+			if _, ok := allowedTags[token.Data]; ok && dropped == 0 && !voidTags[token.Data] {
+				b.WriteString("</" + token.Data + ">")
+			}
+		}
+	}
+}
+
+// safeAttr verifica se o valor do atributo é seguro: links e imagens só com endereços
+// relativos ou com esquemas conhecidos, e os demais atributos apenas com valores esperados
+func safeAttr(key, value string) bool {
+	switch key {
+	case "href":
+		return safeURL(value, "http", "https", "mailto")
+	case "src":
+		return safeURL(value, "http", "https")
+	case "class":
+		return codeLanguage.MatchString(value)
+	case "align":
+		return value == "left" || value == "center" || value == "right"
+	case "start":
+		for _, r := range value {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		return value != ""
+	default:
+		return true
+	}
+}
+
+// safeURL verifica se o endereço é relativo ou usa um dos esquemas informados
+func safeURL(value string, schemes ...string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "" || contains(schemes, u.Scheme)
+}
+
+// buildExcerpt gera o resumo de um post a partir do HTML: o texto, sem marcação, com até
+// excerptWords palavras. Elementos de bloco separam palavras; os de linha, não.
+func buildExcerpt(rendered string) string {
+	var b strings.Builder
+	z := html.NewTokenizer(strings.NewReader(rendered))
+	for {
+		tokenType := z.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		token := z.Token()
+		switch tokenType {
+		case html.TextToken:
+			b.WriteString(token.Data)
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			if !inlineTags[token.Data] {
+				b.WriteString(" ")
+			}
+		}
+	}
+
+	words := strings.Fields(b.String())
+	if len(words) <= excerptWords {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words[:excerptWords], " ") + "…"
+}
+
+// contains verifica se o valor está na lista
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package application
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"script e o seu conteúdo", `<p>antes<script>alert(1)</script>depois</p>`, `<p>antesdepois</p>`},
+		{"style e o seu conteúdo", `<style>p { color: red }</style><p>texto</p>`, `<p>texto</p>`},
+		{"iframe e o seu conteúdo", `<iframe src="https://exemplo.com"><p>dentro</p></iframe><p>fora</p>`, `<p>fora</p>`},
+		{"script aninhado em elemento removido", `<svg><script>alert(1)</script><p>dentro</p></svg>fim`, `fim`},
+		{"elemento desconhecido mantém o texto", `<div><span>texto</span></div>`, `texto`},
+		{"comentário", `<p>a<!-- <script>alert(1)</script> -->b</p>`, `<p>ab</p>`},
+		{"href javascript", `<a href="javascript:alert(1)">x</a>`, `<a rel="nofollow ugc">x</a>`},
+		{"href com maiúsculas", `<a href="JaVaScRiPt:alert(1)">x</a>`, `<a rel="nofollow ugc">x</a>`},
+		{"href com espaço inicial", `<a href=" javascript:alert(1)">x</a>`, `<a rel="nofollow ugc">x</a>`},
+		{"href data", `<a href="data:text/html;base64,PHNjcmlwdD4=">x</a>`, `<a rel="nofollow ugc">x</a>`},
+		{"href com tabulação", "<a href=\"java\tscript:alert(1)\">x</a>", `<a rel="nofollow ugc">x</a>`},
+		{"href com tabulação em entidade", `<a href="java&#x09;script:alert(1)">x</a>`, `<a rel="nofollow ugc">x</a>`},
+		{"href com caractere nulo", "<a href=\"\x00javascript:alert(1)\">x</a>", `<a rel="nofollow ugc">x</a>`},
+		{"href com nova linha", "<a href=\"javascript\n:alert(1)\">x</a>", `<a rel="nofollow ugc">x</a>`},
+		{"src data", `<img src="data:image/svg+xml,<svg onload=alert(1)>" alt="x">`, `<img alt="x">`},
+		{"src mailto", `<img src="mailto:a@exemplo.com">`, `<img>`},
+		{"href https", `<a href="https://exemplo.com/a?b=1&amp;c=2" title="t">x</a>`, `<a href="https://exemplo.com/a?b=1&amp;c=2" title="t" rel="nofollow ugc">x</a>`},
+		{"href relativo e mailto", `<a href="/posts/1">x</a><a href="mailto:a@exemplo.com">y</a>`, `<a href="/posts/1" rel="nofollow ugc">x</a><a href="mailto:a@exemplo.com" rel="nofollow ugc">y</a>`},
+		{"rel do autor é substituído", `<a href="/x" rel="noopener" target="_blank">x</a>`, `<a href="/x" rel="nofollow ugc">x</a>`},
+		{"eventos on*", `<p onclick="alert(1)">a</p><img src="/a.png" onerror="alert(1)" alt="b">`, `<p>a</p><img src="/a.png" alt="b">`},
+		{"atributo style", `<strong style="background:url(javascript:alert(1))">a</strong>`, `<strong>a</strong>`},
+		{"class de linguagem no código", `<pre><code class="language-go">x</code></pre>`, `<pre><code class="language-go">x</code></pre>`},
+		{"class com símbolos de linguagem", `<code class="language-c++">x</code><code class="language-c#">y</code>`, `<code class="language-c++">x</code><code class="language-c#">y</code>`},
+		{"class arbitrária no código", `<code class="x onclick">x</code>`, `<code>x</code>`},
+		{"class com duas linguagens", `<code class="language-go language-js">x</code>`, `<code>x</code>`},
+		{"class fora do código", `<p class="language-go">x</p>`, `<p>x</p>`},
+		{"alinhamento de tabela", `<td align="center">a</td><td align="javascript:x">b</td>`, `<td align="center">a</td><td>b</td>`},
+		{"início de lista", `<ol start="3"></ol><ol start="3x"></ol>`, `<ol start="3"></ol><ol></ol>`},
+		{"aspas no valor do atributo", `<a title='"><script>alert(1)</script>'>x</a>`, `<a title="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;" rel="nofollow ugc">x</a>`},
+		{"texto escapado", `<p>1 &lt; 2 &amp;&amp; "a"</p>`, `<p>1 &lt; 2 &amp;&amp; &#34;a&#34;</p>`},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeHTML(tt.input); got != tt.want {
+			t.Errorf("%s: esperava %s, obteve %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"HTML em bloco é omitido", "<script>alert(1)</script>\n\ntexto", "\n<p>texto</p>\n"},
+		{"HTML em linha é omitido", `a <img src=x onerror="alert(1)"> b`, "<p>a  b</p>\n"},
+		{"link javascript", "[x](javascript:alert(1))", `<p><a href="" rel="nofollow ugc">x</a></p>` + "\n"},
+		{"link com maiúsculas", "[x](JaVaScRiPt:alert(1))", `<p><a href="" rel="nofollow ugc">x</a></p>` + "\n"},
+		{"imagem data", "![x](data:image/png;base64,AAAA)", `<p><img alt="x"></p>` + "\n"},
+		{"link seguro", "[x](https://exemplo.com)", `<p><a href="https://exemplo.com" rel="nofollow ugc">x</a></p>` + "\n"},
+		{"link automático", "veja https://exemplo.com", `<p>veja <a href="https://exemplo.com" rel="nofollow ugc">https://exemplo.com</a></p>` + "\n"},
+		{"bloco de código com linguagem", "```go\nx := 1\n```", `<pre><code class="language-go">x := 1` + "\n</code></pre>\n"},
+		{"bloco de código com linguagem inválida", "```go\" onclick=\"x\nx\n```", "<pre><code>x\n</code></pre>\n"},
+	}
+
+	for _, tt := range tests {
+		got, err := renderMarkdown(tt.input)
+		if err != nil {
+			t.Fatalf("%s: erro inesperado: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: esperava %q, obteve %q", tt.name, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+
+	"github.com/google/uuid"
+)
+
+// Errors específicos do serviço de notificações
+var (
+	ErrNotificationNotFound = errors.New("notificação não encontrada")
+)
+
+// NotificationService representa o serviço de notificações da aplicação. Ele assina os
+// eventos de domínio e os transforma em entradas na caixa de notificações dos usuários.
+type NotificationService struct {
+	notificationRepo repositories.NotificationRepository
+	postRepo         repositories.PostRepository
+	commentRepo      repositories.CommentRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewNotificationService cria uma nova instância do serviço de notificações
+func NewNotificationService(
+	notificationRepo repositories.NotificationRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	userRepo repositories.UserRepository,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		postRepo:         postRepo,
+		commentRepo:      commentRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// NotificationInbox representa uma página da caixa de notificações com o total de não lidas
+type NotificationInbox struct {
+	Notifications []*domain.Notification `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+}
+
+// HandleEvent cria as notificações de um evento de domínio. Eventos de posts ou comentários
+// que já foram removidos são ignorados.
+func (s *NotificationService) HandleEvent(ctx context.Context, event *domain.Event) error {
+	post, err := s.postRepo.GetByID(ctx, event.PostID)
+	if err != nil {
+		return nil
+	}
+
+	switch event.Type {
+	case domain.EventPostPublished:
+		// Apenas a publicação agendada, feita pelo sistema, é notificada ao autor
+		if event.ActorID != "" {
+			return nil
+		}
+		return s.notify(ctx, post.AuthorID, event, fmt.Sprintf("Seu post agendado \"%s\" foi publicado", post.Title))
+
+	case domain.EventCommentAdded:
+		// Respostas a comentários do próprio autor do post já geram a notificação de resposta
+		if event.ParentID != "" {
+			parent, err := s.commentRepo.GetByID(ctx, event.ParentID)
+			if err == nil && parent.AuthorID == post.AuthorID {
+				return nil
+			}
+		}
+		return s.notify(ctx, post.AuthorID, event, fmt.Sprintf("%s comentou no seu post \"%s\"", s.actorName(ctx, event), post.Title))
+
+	case domain.EventCommentReplied:
+		parent, err := s.commentRepo.GetByID(ctx, event.ParentID)
+		if err != nil {
+			return nil
+		}
+		return s.notify(ctx, parent.AuthorID, event, fmt.Sprintf("%s respondeu ao seu comentário no post \"%s\"", s.actorName(ctx, event), post.Title))
+	}
+
+	return nil
+}
+
+// ListNotifications retorna uma página das notificações do usuário, da mais recente para a mais antiga
+func (s *NotificationService) ListNotifications(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) (*NotificationInbox, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	notifications, err := s.notificationRepo.ListByUser(ctx, userID, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationInbox{Notifications: notifications, UnreadCount: unread}, nil
+}
+
+// MarkRead marca como lida uma notificação do usuário
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id string) error {
+	if err := s.notificationRepo.MarkRead(ctx, userID, id, time.Now()); err != nil {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marca como lidas todas as notificações do usuário
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID string) error {
+	return s.notificationRepo.MarkAllRead(ctx, userID, time.Now())
+}
+
+// notify cria a notificação do evento para o destinatário, exceto quando ele é o próprio autor
+// da ação ou uma conta removida
+func (s *NotificationService) notify(ctx context.Context, recipientID string, event *domain.Event, message string) error {
+	if recipientID == event.ActorID || recipientID == domain.DeletedUserID {
+		return nil
+	}
+
+	notification, err := domain.NewNotification(recipientID, event, message)
+	if err != nil {
+		return err
+	}
+	notification.ID = uuid.New().String()
+
+	return s.notificationRepo.Create(ctx, notification)
+}
+
+// actorName retorna o nome de usuário de quem disparou o evento
+func (s *NotificationService) actorName(ctx context.Context, event *domain.Event) string {
+	actor, err := s.userRepo.GetByID(ctx, event.ActorID)
+	if err != nil {
+		return "Alguém"
+	}
+	return actor.Username
+}
@@ -0,0 +1,16 @@
+package application
+
+import (
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// withEventIDs identifica os eventos antes que o repositório os grave na caixa de saída,
+// na mesma transação da entidade que os originou
+func withEventIDs(events ...*domain.Event) []*domain.Event {
+	for _, event := range events {
+		event.ID = uuid.New().String()
+	}
+	return events
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"errors"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Errors da paginação por cursor
+var (
+	ErrInvalidCursor = errors.New("cursor de paginação inválido")
+)
+
+// Limites do tamanho de página das listagens paginadas por cursor
+const (
+	defaultPageSize = 10
+	MaxPageSize     = 100
+)
+
+// PageQuery representa a página solicitada por um cliente: o cursor opaco recebido
+// na página anterior (vazio para a primeira), o tamanho da página e se o total deve ser contado
+type PageQuery struct {
+	Cursor    string
+	PageSize  int
+	WithTotal bool
+}
+
+// request valida o cursor, aplica o tamanho padrão às páginas sem tamanho e limita
+// as grandes demais a MaxPageSize
+func (q PageQuery) request() (domain.PageRequest, error) {
+	after, err := domain.DecodeCursor(q.Cursor)
+	if err != nil {
+		return domain.PageRequest{}, ErrInvalidCursor
+	}
+
+	limit := q.PageSize
+	switch {
+	case limit < 1:
+		limit = defaultPageSize
+	case limit > MaxPageSize:
+		limit = MaxPageSize
+	}
+
+	return domain.PageRequest{After: after, Limit: limit, WithTotal: q.WithTotal}, nil
+}
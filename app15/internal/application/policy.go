@@ -0,0 +1,51 @@
+package application
+
+import "github.com/caiquemiranda/go-apps/app15/internal/domain"
+
+// Policy concentra as regras de autorização consultadas pelos serviços
+type Policy struct{}
+
+// NewPolicy cria uma nova instância da política de autorização
+func NewPolicy() *Policy {
+	return &Policy{}
+}
+
+// CanCreatePost verifica se o usuário pode publicar posts
+func (p *Policy) CanCreatePost(user *domain.User) bool {
+	return user.Role.AtLeast(domain.RoleAuthor)
+}
+
+// CanModifyPost verifica se o usuário pode editar ou remover o post pelas rotas comuns
+func (p *Policy) CanModifyPost(user *domain.User, post *domain.Post) bool {
+	return post.IsAuthor(user.ID)
+}
+
+// CanViewUnpublishedPost verifica se o usuário pode ver o post antes da publicação ou depois de arquivado
+func (p *Policy) CanViewUnpublishedPost(user *domain.User, post *domain.Post) bool {
+	return post.IsAuthor(user.ID) || p.CanModerate(user)
+}
+
+// CanCreateComment verifica se o usuário pode comentar
+func (p *Policy) CanCreateComment(user *domain.User) bool {
+	return user.Role.AtLeast(domain.RoleReader)
+}
+
+// CanModifyComment verifica se o usuário pode editar ou remover o comentário pelas rotas comuns
+func (p *Policy) CanModifyComment(user *domain.User, comment *domain.Comment) bool {
+	return comment.IsAuthor(user.ID)
+}
+
+// CanModerate verifica se o usuário pode remover conteúdo de outros usuários
+func (p *Policy) CanModerate(user *domain.User) bool {
+	return user.Role.AtLeast(domain.RoleModerator)
+}
+
+// CanManageAccounts verifica se o usuário pode listar e desbloquear as contas de outros usuários
+func (p *Policy) CanManageAccounts(user *domain.User) bool {
+	return user.Role.AtLeast(domain.RoleAdmin)
+}
+
+// CanManageRoles verifica se o usuário pode alterar o papel de outros usuários
+func (p *Policy) CanManageRoles(user *domain.User) bool {
+	return user.Role.AtLeast(domain.RoleAdmin)
+}
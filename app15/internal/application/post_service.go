@@ -3,48 +3,84 @@ package application
 import (
 	"context"
 	"errors"
+	"time"
 
-	"app15/internal/domain"
-	"app15/internal/ports/repositories"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/events"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
 
 	"github.com/google/uuid"
 )
 
 // Errors específicos do serviço de posts
 var (
-	ErrPostNotFound      = errors.New("post não encontrado")
-	ErrNotAuthorized     = errors.New("não autorizado para esta ação")
-	ErrInvalidPostData   = errors.New("dados do post inválidos")
+	ErrPostNotFound            = errors.New("post não encontrado")
+	ErrNotAuthorized           = errors.New("não autorizado para esta ação")
+	ErrInvalidPostData         = errors.New("dados do post inválidos")
+	ErrInvalidPostStatus       = errors.New("status de post inválido")
+	ErrInvalidStatusTransition = errors.New("mudança de status não permitida")
+	ErrInvalidPublishAt        = errors.New("data de publicação deve estar no futuro")
+	ErrRevisionNotFound        = errors.New("revisão não encontrada")
 )
 
 // PostService representa o serviço de posts da aplicação
 type PostService struct {
-	postRepo repositories.PostRepository
-	userRepo repositories.UserRepository
+	postRepo     repositories.PostRepository
+	userRepo     repositories.UserRepository
+	revisionRepo repositories.RevisionRepository
+	reactionRepo repositories.ReactionRepository
+	events       events.EventBus
+	policy       *Policy
 }
 
 // NewPostService cria uma nova instância do serviço de posts
-func NewPostService(postRepo repositories.PostRepository, userRepo repositories.UserRepository) *PostService {
+func NewPostService(
+	postRepo repositories.PostRepository,
+	userRepo repositories.UserRepository,
+	revisionRepo repositories.RevisionRepository,
+	reactionRepo repositories.ReactionRepository,
+	events events.EventBus,
+	policy *Policy,
+) *PostService {
 	return &PostService{
-		postRepo: postRepo,
-		userRepo: userRepo,
+		postRepo:     postRepo,
+		userRepo:     userRepo,
+		revisionRepo: revisionRepo,
+		reactionRepo: reactionRepo,
+		events:       events,
+		policy:       policy,
 	}
 }
 
-// PostRequest representa a estrutura de dados para criação/atualização de posts
+// PostRequest representa a estrutura de dados para criação/atualização de posts.
+// Status e PublishAt são considerados apenas na criação; sem status, o post é publicado
+// imediatamente, ou agendado se PublishAt for informado.
 type PostRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title     string            `json:"title" validate:"required,max=200"`
+	Content   string            `json:"content" validate:"required,max=100000"`
+	Tags      []string          `json:"tags" validate:"omitempty,max=10,dive,required,max=32"`
+	Status    domain.PostStatus `json:"status,omitempty" validate:"omitempty,oneof=draft scheduled published"`
+	PublishAt *time.Time        `json:"publish_at,omitempty"`
+}
+
+// StatusRequest representa a estrutura de dados para mudança de status de um post
+type StatusRequest struct {
+	Status    domain.PostStatus `json:"status" validate:"required,oneof=draft scheduled published archived"`
+	PublishAt *time.Time        `json:"publish_at,omitempty"`
 }
 
 // CreatePost cria um novo post
 func (s *PostService) CreatePost(ctx context.Context, req PostRequest, authorID string) (*domain.Post, error) {
-	// Verificar se o autor existe
-	_, err := s.userRepo.GetByID(ctx, authorID)
+	// Verificar se o autor existe e pode publicar
+	author, err := s.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
+	if !s.policy.CanCreatePost(author) {
+		return nil, ErrNotAuthorized
+	}
+
 	// Criar novo post
 	post, err := domain.NewPost(req.Title, req.Content, authorID)
 	if err != nil {
@@ -53,23 +89,63 @@ func (s *PostService) CreatePost(ctx context.Context, req PostRequest, authorID
 
 	// Gerar ID único para o post
 	post.ID = uuid.New().String()
+	post.SetTags(req.Tags)
+	if err := renderContent(post); err != nil {
+		return nil, err
+	}
 
-	// Salvar post no repositório
-	err = s.postRepo.Create(ctx, post)
+	// Definir a etapa inicial do ciclo de vida a partir do rascunho
+	status := req.Status
+	if status == "" {
+		status = domain.PostStatusPublished
+		if req.PublishAt != nil {
+			status = domain.PostStatusScheduled
+		}
+	}
+	post.Status, post.PublishAt = domain.PostStatusDraft, nil
+	if err := post.ChangeStatus(status, req.PublishAt, time.Now()); err != nil {
+		return nil, statusError(err)
+	}
+
+	// Salvar post no repositório, com o evento de publicação na mesma transação
+	var postEvents []*domain.Event
+	if post.IsPublished() {
+		postEvents = withEventIDs(domain.NewPostPublishedEvent(post, authorID))
+	}
+	err = s.postRepo.Create(ctx, post, postEvents...)
 	if err != nil {
 		return nil, err
 	}
+	if len(postEvents) > 0 {
+		s.events.Notify()
+	}
+
+	// Registrar a primeira versão do conteúdo
+	if err := s.recordRevision(ctx, post, authorID); err != nil {
+		return nil, err
+	}
 
 	return post, nil
 }
 
-// GetPostByID busca um post pelo ID
-func (s *PostService) GetPostByID(ctx context.Context, id string) (*domain.Post, error) {
+// GetPostByID busca um post pelo ID. Posts não publicados só são visíveis para o autor
+// e para moderadores; viewerID vazio representa um visitante anônimo.
+func (s *PostService) GetPostByID(ctx context.Context, id string, viewerID string) (*domain.Post, error) {
 	post, err := s.postRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrPostNotFound
 	}
-	return post, nil
+
+	if !post.IsPublished() && !canViewUnpublished(ctx, s.userRepo, s.policy, viewerID, post) {
+		return nil, ErrPostNotFound
+	}
+
+	posts := []*domain.Post{post}
+	if err := s.expand(ctx, posts); err != nil {
+		return nil, err
+	}
+
+	return posts[0], nil
 }
 
 // UpdatePost atualiza um post existente
@@ -80,9 +156,16 @@ func (s *PostService) UpdatePost(ctx context.Context, id string, req PostRequest
 		return nil, ErrPostNotFound
 	}
 
-	// Verificar se o usuário é o autor do post
-	if !post.IsAuthor(userID) {
-		return nil, ErrNotAuthorized
+	// Verificar se o usuário pode alterar o post
+	if err := s.authorize(ctx, userID, post); err != nil {
+		return nil, err
+	}
+
+	// Posts anteriores ao histórico de revisões têm o estado atual registrado como primeira versão
+	if _, err := s.revisionRepo.GetLatest(ctx, post.ID); err != nil {
+		if err := s.recordRevision(ctx, post, post.AuthorID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Atualizar os campos do post
@@ -93,6 +176,14 @@ func (s *PostService) UpdatePost(ctx context.Context, id string, req PostRequest
 	if err := post.UpdateContent(req.Content); err != nil {
 		return nil, ErrInvalidPostData
 	}
+	if err := renderContent(post); err != nil {
+		return nil, err
+	}
+
+	// Tags omitidas na requisição são mantidas
+	if req.Tags != nil {
+		post.SetTags(req.Tags)
+	}
 
 	// Salvar as alterações no repositório
 	err = s.postRepo.Update(ctx, post)
@@ -100,7 +191,155 @@ func (s *PostService) UpdatePost(ctx context.Context, id string, req PostRequest
 		return nil, err
 	}
 
-	return post, nil
+	if err := s.recordRevision(ctx, post, userID); err != nil {
+		return nil, err
+	}
+
+	return s.withLikes(ctx, post)
+}
+
+// ChangeStatus move um post pelo ciclo de vida: rascunho, agendado, publicado ou arquivado
+func (s *PostService) ChangeStatus(ctx context.Context, id string, req StatusRequest, userID string) (*domain.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	if err := s.authorize(ctx, userID, post); err != nil {
+		return nil, err
+	}
+
+	wasPublished := post.IsPublished()
+	if err := post.ChangeStatus(req.Status, req.PublishAt, time.Now()); err != nil {
+		return nil, statusError(err)
+	}
+
+	var postEvents []*domain.Event
+	if !wasPublished && post.IsPublished() {
+		postEvents = withEventIDs(domain.NewPostPublishedEvent(post, userID))
+	}
+	if err := s.postRepo.Update(ctx, post, postEvents...); err != nil {
+		return nil, err
+	}
+	if len(postEvents) > 0 {
+		s.events.Notify()
+	}
+
+	return s.withLikes(ctx, post)
+}
+
+// PublishDuePosts publica os posts agendados cuja data de publicação já chegou
+// e retorna quantos foram publicados
+func (s *PostService) PublishDuePosts(ctx context.Context, now time.Time) (int, error) {
+	posts, err := s.postRepo.ListScheduledDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	defer func() {
+		if published > 0 {
+			s.events.Notify()
+		}
+	}()
+
+	for _, post := range posts {
+		if err := post.ChangeStatus(domain.PostStatusPublished, nil, now); err != nil {
+			return published, err
+		}
+		// Publicações agendadas não têm autor da ação: são feitas pelo sistema
+		event := withEventIDs(domain.NewPostPublishedEvent(post, ""))
+		if err := s.postRepo.Update(ctx, post, event...); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// ListRevisions retorna uma lista paginada das revisões de um post, da mais recente para a mais antiga
+func (s *PostService) ListRevisions(ctx context.Context, postID string, userID string, page, pageSize int) ([]*domain.PostRevision, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	if _, err := s.authorizedPost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.revisionRepo.ListByPost(ctx, postID, page, pageSize)
+}
+
+// GetRevision busca uma revisão de um post pelo número da versão
+func (s *PostService) GetRevision(ctx context.Context, postID string, version int, userID string) (*domain.PostRevision, error) {
+	if _, err := s.authorizedPost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionRepo.GetByVersion(ctx, postID, version)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	return revision, nil
+}
+
+// DiffRevisions compara duas revisões de um post linha a linha
+func (s *PostService) DiffRevisions(ctx context.Context, postID string, from, to int, userID string) (*domain.RevisionDiff, error) {
+	if _, err := s.authorizedPost(ctx, postID, userID); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.revisionRepo.GetByVersion(ctx, postID, from)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	toRevision, err := s.revisionRepo.GetByVersion(ctx, postID, to)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	return diffRevisions(fromRevision, toRevision), nil
+}
+
+// RestoreRevision restaura o título, o conteúdo e as tags de uma revisão anterior.
+// A restauração é registrada como uma nova revisão, preservando o histórico.
+func (s *PostService) RestoreRevision(ctx context.Context, postID string, version int, userID string) (*domain.Post, error) {
+	post, err := s.authorizedPost(ctx, postID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionRepo.GetByVersion(ctx, postID, version)
+	if err != nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	if err := post.UpdateTitle(revision.Title); err != nil {
+		return nil, ErrInvalidPostData
+	}
+	if err := post.UpdateContent(revision.Content); err != nil {
+		return nil, ErrInvalidPostData
+	}
+	if err := renderContent(post); err != nil {
+		return nil, err
+	}
+	post.SetTags(revision.Tags)
+
+	if err := s.postRepo.Update(ctx, post); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordRevision(ctx, post, userID); err != nil {
+		return nil, err
+	}
+
+	return s.withLikes(ctx, post)
 }
 
 // DeletePost remove um post
@@ -111,41 +350,257 @@ func (s *PostService) DeletePost(ctx context.Context, id string, userID string)
 		return ErrPostNotFound
 	}
 
-	// Verificar se o usuário é o autor do post
-	if !post.IsAuthor(userID) {
-		return ErrNotAuthorized
+	// Verificar se o usuário pode remover o post
+	if err := s.authorize(ctx, userID, post); err != nil {
+		return err
 	}
 
 	// Remover o post do repositório
 	return s.postRepo.Delete(ctx, id)
 }
 
-// ListPosts retorna uma lista paginada de posts
-func (s *PostService) ListPosts(ctx context.Context, page, pageSize int) ([]*domain.Post, error) {
-	if page < 1 {
-		page = 1
+// ListPosts retorna uma página de posts publicados, do mais recente para o mais antigo
+func (s *PostService) ListPosts(ctx context.Context, query PageQuery) (*domain.Page[*domain.Post], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	page, err := s.postRepo.List(ctx, domain.PostStatusPublished, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expand(ctx, page.Items); err != nil {
+		return nil, err
 	}
 
-	return s.postRepo.List(ctx, page, pageSize)
+	return page, nil
 }
 
-// ListPostsByAuthor retorna uma lista paginada de posts de um autor específico
-func (s *PostService) ListPostsByAuthor(ctx context.Context, authorID string, page, pageSize int) ([]*domain.Post, error) {
-	if page < 1 {
-		page = 1
+// ListPublishedEntries retorna o ID e a última alteração de até limit posts publicados,
+// do mais recente para o mais antigo, sem carregar o conteúdo
+func (s *PostService) ListPublishedEntries(ctx context.Context, limit int) ([]domain.PostEntry, error) {
+	return s.postRepo.ListPublishedEntries(ctx, limit)
+}
+
+// ListPostsByAuthor retorna uma página de posts de um autor específico.
+// O próprio autor e os moderadores podem filtrar por qualquer status (todos, se vazio);
+// os demais usuários veem apenas os posts publicados.
+func (s *PostService) ListPostsByAuthor(ctx context.Context, authorID string, viewerID string, status domain.PostStatus, query PageQuery) (*domain.Page[*domain.Post], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	if status != "" && !status.IsValid() {
+		return nil, ErrInvalidPostStatus
 	}
 
 	// Verificar se o autor existe
-	_, err := s.userRepo.GetByID(ctx, authorID)
+	_, err = s.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
 
-	return s.postRepo.ListByAuthor(ctx, authorID, page, pageSize)
-} 
\ No newline at end of file
+	if !canViewUnpublished(ctx, s.userRepo, s.policy, viewerID, &domain.Post{AuthorID: authorID}) {
+		if status != "" && status != domain.PostStatusPublished {
+			page := &domain.Page[*domain.Post]{Items: []*domain.Post{}}
+			if req.WithTotal {
+				page.SetTotal(0)
+			}
+			return page, nil
+		}
+		status = domain.PostStatusPublished
+	}
+
+	page, err := s.postRepo.ListByAuthor(ctx, authorID, status, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.expand(ctx, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// SearchPosts busca posts por palavras-chave e tags, em ordem de relevância,
+// com um trecho do conteúdo em que os termos encontrados aparecem destacados
+func (s *PostService) SearchPosts(ctx context.Context, query string, tags []string, pageQuery PageQuery) (*domain.Page[*domain.PostSearchResult], error) {
+	req, err := pageQuery.request()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := s.postRepo.Search(ctx, query, tags, req)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := domain.Tokenize(query)
+	for _, result := range page.Items {
+		result.Snippet = buildSnippet(result.Post.Content, terms)
+	}
+
+	posts := make([]*domain.Post, len(page.Items))
+	for i, result := range page.Items {
+		posts[i] = result.Post
+	}
+	if err := s.expand(ctx, posts); err != nil {
+		return nil, err
+	}
+	for i, result := range page.Items {
+		result.Post = posts[i]
+	}
+
+	return page, nil
+}
+
+// ListPopularPosts retorna uma página dos posts publicados mais populares. A popularidade
+// considera as curtidas e decai com o tempo desde a publicação (domain.PopularityScore).
+// O instante do ranking é fixado na primeira página e guardado no cursor, junto com a
+// pontuação e o ID do último post entregue: as páginas seguintes usam o mesmo instante, e
+// só curtidas dadas durante a navegação podem mover um post entre páginas.
+func (s *PostService) ListPopularPosts(ctx context.Context, query PageQuery) (*domain.Page[*domain.Post], error) {
+	req, err := query.request()
+	if err != nil {
+		return nil, err
+	}
+
+	rankedAt := time.Now()
+	if req.After != nil {
+		rankedAt = req.After.Time
+	}
+
+	page, err := s.postRepo.ListPopular(ctx, rankedAt, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := renderMissingContent(page.Items); err != nil {
+		return nil, err
+	}
+	if err := expandPostAuthors(ctx, s.userRepo, page.Items); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// LikePost registra a curtida do usuário em um post publicado. Curtir de novo não altera a contagem.
+func (s *PostService) LikePost(ctx context.Context, postID string, userID string) (*domain.ReactionSummary, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil || !post.IsPublished() {
+		return nil, ErrPostNotFound
+	}
+
+	return react(ctx, s.reactionRepo, userID, domain.ReactionTargetPost, post.ID, true)
+}
+
+// UnlikePost desfaz a curtida do usuário em um post, mesmo que ele não esteja mais publicado
+func (s *PostService) UnlikePost(ctx context.Context, postID string, userID string) (*domain.ReactionSummary, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	return react(ctx, s.reactionRepo, userID, domain.ReactionTargetPost, post.ID, false)
+}
+
+// expand preenche as curtidas e o autor dos posts
+func (s *PostService) expand(ctx context.Context, posts []*domain.Post) error {
+	if err := countPostLikes(ctx, s.reactionRepo, posts); err != nil {
+		return err
+	}
+	if err := renderMissingContent(posts); err != nil {
+		return err
+	}
+	return expandPostAuthors(ctx, s.userRepo, posts)
+}
+
+// withLikes retorna uma cópia do post com as curtidas preenchidas
+func (s *PostService) withLikes(ctx context.Context, post *domain.Post) (*domain.Post, error) {
+	posts := []*domain.Post{post}
+	if err := countPostLikes(ctx, s.reactionRepo, posts); err != nil {
+		return nil, err
+	}
+	if err := renderMissingContent(posts); err != nil {
+		return nil, err
+	}
+	return posts[0], nil
+}
+
+// authorize verifica, pela política, se o usuário pode alterar o post
+func (s *PostService) authorize(ctx context.Context, userID string, post *domain.Post) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrNotAuthorized
+	}
+
+	if !s.policy.CanModifyPost(user, post) {
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
+
+// authorizedPost busca o post e verifica se o usuário pode alterá-lo
+func (s *PostService) authorizedPost(ctx context.Context, postID string, userID string) (*domain.Post, error) {
+	post, err := s.postRepo.GetByID(ctx, postID)
+	if err != nil {
+		return nil, ErrPostNotFound
+	}
+
+	if err := s.authorize(ctx, userID, post); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// canViewUnpublished verifica, pela política, se o usuário pode ver o post não publicado.
+// Visitantes anônimos e usuários inexistentes nunca podem.
+func canViewUnpublished(ctx context.Context, userRepo repositories.UserRepository, policy *Policy, viewerID string, post *domain.Post) bool {
+	if viewerID == "" {
+		return false
+	}
+
+	viewer, err := userRepo.GetByID(ctx, viewerID)
+	if err != nil {
+		return false
+	}
+
+	return policy.CanViewUnpublishedPost(viewer, post)
+}
+
+// recordRevision registra o estado atual do post como uma nova versão no histórico
+func (s *PostService) recordRevision(ctx context.Context, post *domain.Post, editorID string) error {
+	version := 1
+	if latest, err := s.revisionRepo.GetLatest(ctx, post.ID); err == nil {
+		version = latest.Version + 1
+	}
+
+	revision, err := domain.NewPostRevision(post, version, editorID)
+	if err != nil {
+		return err
+	}
+	revision.ID = uuid.New().String()
+
+	return s.revisionRepo.Create(ctx, revision)
+}
+
+// statusError converte os erros de ciclo de vida do domínio em erros do serviço
+func statusError(err error) error {
+	switch err {
+	case domain.ErrInvalidPostStatus:
+		return ErrInvalidPostStatus
+	case domain.ErrInvalidStatusTransition:
+		return ErrInvalidStatusTransition
+	case domain.ErrInvalidPublishAt:
+		return ErrInvalidPublishAt
+	default:
+		return err
+	}
+}
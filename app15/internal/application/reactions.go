@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// react registra ou desfaz a curtida do usuário no conteúdo e retorna o resumo atualizado.
+// Curtir de novo, ou descurtir o que não foi curtido, não altera a contagem.
+func react(ctx context.Context, reactionRepo repositories.ReactionRepository, userID string, targetType domain.ReactionTarget, targetID string, like bool) (*domain.ReactionSummary, error) {
+	if like {
+		reaction, err := domain.NewReaction(userID, targetType, targetID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := reactionRepo.Add(ctx, reaction); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := reactionRepo.Remove(ctx, userID, targetType, targetID); err != nil {
+			return nil, err
+		}
+	}
+
+	counts, err := reactionRepo.CountByTargets(ctx, targetType, []string{targetID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ReactionSummary{
+		TargetType: targetType,
+		TargetID:   targetID,
+		Likes:      counts[targetID],
+		Liked:      like,
+	}, nil
+}
+
+// countPostLikes preenche as curtidas dos posts com uma única busca. Os posts são
+// substituídos por cópias para não alterar as instâncias compartilhadas com o repositório.
+func countPostLikes(ctx context.Context, reactionRepo repositories.ReactionRepository, posts []*domain.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+
+	counts, err := reactionRepo.CountByTargets(ctx, domain.ReactionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, post := range posts {
+		copied := *post
+		copied.Likes = counts[post.ID]
+		posts[i] = &copied
+	}
+
+	return nil
+}
+
+// countCommentLikes preenche as curtidas dos comentários, substituindo-os por cópias
+func countCommentLikes(ctx context.Context, reactionRepo repositories.ReactionRepository, comments []*domain.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	counts, err := reactionRepo.CountByTargets(ctx, domain.ReactionTargetComment, ids)
+	if err != nil {
+		return err
+	}
+
+	for i, comment := range comments {
+		copied := *comment
+		copied.Likes = counts[comment.ID]
+		comments[i] = &copied
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PostScheduler publica periodicamente os posts agendados cuja data de publicação já chegou
+type PostScheduler struct {
+	postService *PostService
+	interval    time.Duration
+}
+
+// NewPostScheduler cria um agendador que verifica os posts agendados a cada interval
+func NewPostScheduler(postService *PostService, interval time.Duration) *PostScheduler {
+	return &PostScheduler{
+		postService: postService,
+		interval:    interval,
+	}
+}
+
+// Run executa o agendador até o contexto ser cancelado
+func (s *PostScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick publica os posts vencidos, registrando eventuais falhas sem interromper o agendador
+func (s *PostScheduler) tick(ctx context.Context) {
+	published, err := s.postService.PublishDuePosts(ctx, time.Now())
+	if err != nil {
+		log.Printf("Erro ao publicar posts agendados: %v", err)
+	}
+	if published > 0 {
+		log.Printf("%d post(s) agendado(s) publicado(s)", published)
+	}
+}
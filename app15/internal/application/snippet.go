@@ -0,0 +1,75 @@
+package application
+
+import (
+	"html"
+	"strings"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// snippetWords é a quantidade de palavras exibidas em um trecho de resultado de busca
+const snippetWords = 30
+
+// buildSnippet extrai do texto um trecho em torno da primeira ocorrência dos termos,
+// com o HTML escapado e os termos encontrados destacados com <mark>
+func buildSnippet(text string, terms []string) string {
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+
+	words := strings.FieldsFunc(text, func(r rune) bool { return r == ' ' || r == '\n' || r == '\t' || r == '\r' })
+	if len(words) == 0 {
+		return ""
+	}
+
+	// Posicionar a janela de forma que a primeira ocorrência fique perto do início
+	first := -1
+	for i, word := range words {
+		if matchesTerm(word, wanted) {
+			first = i
+			break
+		}
+	}
+
+	start := 0
+	if first > snippetWords/3 {
+		start = first - snippetWords/3
+	}
+	end := start + snippetWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("… ")
+	}
+	for i, word := range words[start:end] {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if matchesTerm(word, wanted) {
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(word))
+			b.WriteString("</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+	}
+	if end < len(words) {
+		b.WriteString(" …")
+	}
+
+	return b.String()
+}
+
+// matchesTerm verifica se alguma palavra contida no trecho corresponde a um dos termos buscados
+func matchesTerm(word string, wanted map[string]bool) bool {
+	for _, part := range strings.FieldsFunc(word, domain.IsWordSeparator) {
+		if wanted[domain.NormalizeTerm(part)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Errors específicos do serviço de usuários
+var (
+	ErrEmailInUse      = errors.New("email já está em uso")
+	ErrUsernameInUse   = errors.New("nome de usuário já está em uso")
+	ErrInvalidPassword = errors.New("senha atual incorreta")
+)
+
+// UserService representa o serviço de gerenciamento de contas da aplicação
+type UserService struct {
+	userRepo         repositories.UserRepository
+	postRepo         repositories.PostRepository
+	commentRepo      repositories.CommentRepository
+	revisionRepo     repositories.RevisionRepository
+	tokenRepo        repositories.TokenRepository
+	reactionRepo     repositories.ReactionRepository
+	notificationRepo repositories.NotificationRepository
+	attemptRepo      repositories.LoginAttemptRepository
+	auditRepo        repositories.AuditRepository
+}
+
+// NewUserService cria uma nova instância do serviço de usuários
+func NewUserService(
+	userRepo repositories.UserRepository,
+	postRepo repositories.PostRepository,
+	commentRepo repositories.CommentRepository,
+	revisionRepo repositories.RevisionRepository,
+	tokenRepo repositories.TokenRepository,
+	reactionRepo repositories.ReactionRepository,
+	notificationRepo repositories.NotificationRepository,
+	attemptRepo repositories.LoginAttemptRepository,
+	auditRepo repositories.AuditRepository,
+) *UserService {
+	return &UserService{
+		userRepo:         userRepo,
+		postRepo:         postRepo,
+		commentRepo:      commentRepo,
+		revisionRepo:     revisionRepo,
+		tokenRepo:        tokenRepo,
+		reactionRepo:     reactionRepo,
+		notificationRepo: notificationRepo,
+		attemptRepo:      attemptRepo,
+		auditRepo:        auditRepo,
+	}
+}
+
+// UpdateProfileRequest representa a estrutura de dados para atualização parcial do perfil.
+// Campos omitidos são mantidos.
+type UpdateProfileRequest struct {
+	Username *string `json:"username,omitempty" validate:"omitempty,min=3,max=32,username"`
+	Email    *string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+}
+
+// ChangePasswordRequest representa a estrutura de dados para troca de senha
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=72"`
+}
+
+// DeleteAccountRequest representa a estrutura de dados para remoção da própria conta
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+// GetPublicProfile busca o perfil público de um usuário pelo nome de usuário
+func (s *UserService) GetPublicProfile(ctx context.Context, username string) (*domain.PublicUser, error) {
+	user, err := s.userRepo.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return user.Public(), nil
+}
+
+// UpdateProfile altera o nome de usuário e o email, verificando se continuam únicos
+func (s *UserService) UpdateProfile(ctx context.Context, userID string, req UpdateProfileRequest) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if req.Username != nil && *req.Username != user.Username {
+		if existing, err := s.userRepo.GetByUsername(ctx, *req.Username); err == nil && existing.ID != user.ID {
+			return nil, ErrUsernameInUse
+		}
+		if err := user.UpdateUsername(*req.Username); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Email != nil && *req.Email != user.Email {
+		if existing, err := s.userRepo.GetByEmail(ctx, *req.Email); err == nil && existing.ID != user.ID {
+			return nil, ErrEmailInUse
+		}
+		if err := user.UpdateEmail(*req.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangePassword troca a senha do usuário após conferir a senha atual. Todas as sessões
+// são encerradas, inclusive a atual, e o usuário precisa entrar novamente.
+func (s *UserService) ChangePassword(ctx context.Context, userID string, req ChangePasswordRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !user.ValidatePassword(req.CurrentPassword) {
+		return ErrInvalidPassword
+	}
+
+	if err := user.UpdatePassword(req.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return s.tokenRepo.RevokeByUser(ctx, user.ID)
+}
+
+// DeleteAccount remove a conta do usuário após conferir a senha. Retorna ErrLastAdmin se
+// a conta for a do último administrador. Rascunhos e posts agendados são apagados; os
+// demais posts, os comentários e as revisões são mantidos, mas passam a ser atribuídos ao
+// usuário removido genérico, assim como as entradas da trilha de auditoria. As curtidas,
+// as notificações, as tentativas de login e o bloqueio da conta, que identificam o usuário
+// pelo ID ou pelo email, são apagados.
+//
+// As etapas gravam em repositórios diferentes e não formam uma transação. Por isso, cada
+// uma pode ser repetida sem efeito adicional, as sessões são encerradas antes de qualquer
+// alteração no conteúdo e o usuário é removido por último: depois de uma falha, repetir
+// a remoção com a mesma senha conclui as etapas que faltaram.
+func (s *UserService) DeleteAccount(ctx context.Context, userID string, req DeleteAccountRequest) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return ErrUserNotFound
+	}
+
+	if !user.ValidatePassword(req.Password) {
+		return ErrInvalidPassword
+	}
+
+	// Rebaixar o administrador antes de tudo aplica a mesma verificação atômica da troca
+	// de papel: duas remoções simultâneas não deixam a aplicação sem administradores
+	if user.Role == domain.RoleAdmin {
+		if err := user.UpdateRole(domain.RoleReader); err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdateRole(ctx, user); err != nil {
+			if errors.Is(err, repositories.ErrLastAdmin) {
+				return ErrLastAdmin
+			}
+			return err
+		}
+	}
+
+	if err := s.tokenRepo.RevokeByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	// Posts ainda não publicados não ficam com o autor genérico, nem são publicados
+	// depois pelo agendador em nome dele
+	for _, status := range []domain.PostStatus{domain.PostStatusDraft, domain.PostStatusScheduled} {
+		if err := s.deletePostsByStatus(ctx, user.ID, status); err != nil {
+			return err
+		}
+	}
+
+	if err := s.postRepo.ReassignAuthor(ctx, user.ID, domain.DeletedUserID); err != nil {
+		return err
+	}
+
+	if err := s.commentRepo.ReassignAuthor(ctx, user.ID, domain.DeletedUserID); err != nil {
+		return err
+	}
+
+	if err := s.revisionRepo.ReassignEditor(ctx, user.ID, domain.DeletedUserID); err != nil {
+		return err
+	}
+
+	if err := s.auditRepo.ReassignUser(ctx, user.ID, domain.DeletedUserID); err != nil {
+		return err
+	}
+
+	if err := s.reactionRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.notificationRepo.DeleteByUser(ctx, user.ID); err != nil {
+		return err
+	}
+
+	if err := s.attemptRepo.DeleteByUser(ctx, user.ID, user.Email); err != nil {
+		return err
+	}
+
+	if err := s.attemptRepo.DeleteLockout(ctx, domain.LockoutAccountKey(user.Email)); err != nil {
+		return err
+	}
+
+	return s.userRepo.Delete(ctx, user.ID)
+}
+
+// deletePostsByStatus apaga os posts do autor no status informado, com os seus comentários,
+// curtidas e revisões
+func (s *UserService) deletePostsByStatus(ctx context.Context, authorID string, status domain.PostStatus) error {
+	for {
+		page, err := s.postRepo.ListByAuthor(ctx, authorID, status, domain.PageRequest{Limit: MaxPageSize})
+		if err != nil {
+			return err
+		}
+
+		for _, post := range page.Items {
+			if err := s.postRepo.Delete(ctx, post.ID); err != nil {
+				return err
+			}
+		}
+
+		if !page.HasMore {
+			return nil
+		}
+	}
+}
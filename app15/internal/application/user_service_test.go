@@ -0,0 +1,80 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/app"
+	"github.com/caiquemiranda/go-apps/app15/internal/application"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// flakyNotifications falha na primeira remoção das notificações de um usuário
+type flakyNotifications struct {
+	repositories.NotificationRepository
+	failed bool
+}
+
+func (r *flakyNotifications) DeleteByUser(ctx context.Context, userID string) error {
+	if !r.failed {
+		r.failed = true
+		return errors.New("falha simulada")
+	}
+	return r.NotificationRepository.DeleteByUser(ctx, userID)
+}
+
+// Uma remoção de conta interrompida no meio é concluída ao ser repetida
+func TestDeleteAccountResumesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	repos := app.NewMemoryRepositories()
+	notifications := &flakyNotifications{NotificationRepository: repos.Notifications}
+	users := application.NewUserService(repos.Users, repos.Posts, repos.Comments, repos.Revisions, repos.Tokens,
+		repos.Reactions, notifications, repos.LoginAttempts, repos.Audit)
+
+	user, err := domain.NewUser("alice", "alice@exemplo.com", "alice-senha-secreta")
+	if err != nil {
+		t.Fatalf("Erro ao criar o usuário: %v", err)
+	}
+	user.ID = "user-1"
+	if err := repos.Users.Create(ctx, user); err != nil {
+		t.Fatalf("Erro ao gravar o usuário: %v", err)
+	}
+
+	published, _ := domain.NewPost("Publicado", "Conteúdo", user.ID)
+	published.ID = "post-1"
+	draft, _ := domain.NewPost("Rascunho", "Conteúdo", user.ID)
+	draft.ID, draft.Status, draft.PublishAt = "post-2", domain.PostStatusDraft, nil
+	for _, post := range []*domain.Post{published, draft} {
+		if err := repos.Posts.Create(ctx, post); err != nil {
+			t.Fatalf("Erro ao gravar o post: %v", err)
+		}
+	}
+
+	req := application.DeleteAccountRequest{Password: "alice-senha-secreta"}
+	if err := users.DeleteAccount(ctx, user.ID, req); err == nil {
+		t.Fatal("esperava a falha simulada na primeira tentativa")
+	}
+
+	// As etapas anteriores à falha já foram aplicadas, e a conta continua existindo
+	if _, err := repos.Users.GetByID(ctx, user.ID); err != nil {
+		t.Fatalf("esperava a conta mantida após a falha: %v", err)
+	}
+	if post, err := repos.Posts.GetByID(ctx, published.ID); err != nil || post.AuthorID != domain.DeletedUserID {
+		t.Fatalf("esperava o post já atribuído ao autor removido, obteve %+v, %v", post, err)
+	}
+
+	if err := users.DeleteAccount(ctx, user.ID, req); err != nil {
+		t.Fatalf("esperava a remoção concluída na segunda tentativa: %v", err)
+	}
+	if _, err := repos.Users.GetByID(ctx, user.ID); err == nil {
+		t.Error("esperava a conta removida")
+	}
+	if _, err := repos.Posts.GetByID(ctx, draft.ID); err == nil {
+		t.Error("esperava o rascunho apagado")
+	}
+	if post, err := repos.Posts.GetByID(ctx, published.ID); err != nil || post.AuthorID != domain.DeletedUserID {
+		t.Errorf("esperava o post publicado mantido com o autor removido, obteve %+v, %v", post, err)
+	}
+}
@@ -0,0 +1,442 @@
+// Package config carrega a configuração da aplicação a partir de flags de linha de comando,
+// variáveis de ambiente e arquivos .env, nessa ordem de precedência, sobre valores padrão.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Ambientes de execução suportados
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// DefaultJWTSecret é a chave de exemplo do .env.example; não é aceita em produção
+const DefaultJWTSecret = "sua-chave-secreta-aqui"
+
+// minProductionSecretLength é o tamanho mínimo da chave JWT em produção
+const minProductionSecretLength = 32
+
+// Config reúne todas as configurações da aplicação
+type Config struct {
+	Env       string
+	Port      string
+	JWT       JWTConfig
+	CORS      CORSConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	RateLimit RateLimitConfig
+	Lockout   LockoutConfig
+	Site      SiteConfig
+	Admin     AdminConfig
+}
+
+// JWTConfig configura a emissão dos tokens de acesso e de renovação
+type JWTConfig struct {
+	Secret            string
+	Expiration        time.Duration
+	RefreshExpiration time.Duration
+}
+
+// CORSConfig configura as origens autorizadas a chamar a API pelo navegador
+type CORSConfig struct {
+	AllowedOrigins []string
+}
+
+// ServerConfig configura os tempos limite do servidor HTTP e os intervalos das tarefas em segundo plano
+type ServerConfig struct {
+	ReadTimeout           time.Duration
+	WriteTimeout          time.Duration
+	IdleTimeout           time.Duration
+	RequestTimeout        time.Duration
+	ShutdownTimeout       time.Duration
+	SchedulerInterval     time.Duration
+	EventDispatchInterval time.Duration
+	// TrustProxy indica que o IP do cliente vem dos cabeçalhos X-Forwarded-For e X-Real-IP
+	TrustProxy bool
+}
+
+// DatabaseConfig seleciona o driver de repositório e a conexão com o banco
+type DatabaseConfig struct {
+	Driver string
+	DSN    string
+}
+
+// RateLimitConfig configura o limite de requisições de cada grupo de rotas: Auth para as rotas
+// de autenticação, Public para as leituras anônimas, User para as rotas autenticadas e Comment,
+// mais restrito e somado ao de User, para a criação de comentários
+type RateLimitConfig struct {
+	Enabled bool
+	Auth    RateLimit
+	Public  RateLimit
+	User    RateLimit
+	Comment RateLimit
+}
+
+// RateLimit permite Requests requisições a cada Period, no formato "10/1m"
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// LockoutConfig configura o bloqueio do login: a partir de AccountMaxFailures falhas seguidas
+// na mesma conta, ou IPMaxFailures no mesmo IP, o login fica bloqueado por BaseDelay, tempo que
+// dobra a cada nova falha até MaxDelay. Falhas mais antigas que FailureWindow são esquecidas.
+type LockoutConfig struct {
+	AccountMaxFailures int
+	IPMaxFailures      int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	FailureWindow      time.Duration
+}
+
+// SiteConfig identifica o blog nos feeds e no sitemap. Sem URL, os links são montados
+// a partir do endereço usado na requisição. PostURL é o modelo do endereço da página
+// pública de cada post, com {id} no lugar do ID; vazio, é URL + "/posts/{id}".
+type SiteConfig struct {
+	Title   string
+	URL     string
+	PostURL string
+}
+
+// AdminConfig indica a conta promovida a administrador na inicialização. Nenhum cadastro
+// recebe o papel admin automaticamente; sem Email, os administradores são apenas os já existentes.
+type AdminConfig struct {
+	Email string
+}
+
+// IsProduction indica se a aplicação está em modo de produção
+func (c *Config) IsProduction() bool {
+	return c.Env == EnvProduction
+}
+
+// Load carrega a configuração a partir dos argumentos de linha de comando (sem o nome
+// do programa), das variáveis de ambiente e do arquivo .env, e valida o resultado.
+func Load(args []string) (*Config, error) {
+	return load(args, os.LookupEnv)
+}
+
+// load implementa o Load com a consulta às variáveis de ambiente injetada
+func load(args []string, lookupEnv func(string) (string, bool)) (*Config, error) {
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	envFile := fs.String("env-file", "", "arquivo .env a carregar (padrão: ENV_FILE ou .env)")
+	fs.String("env", "", "ambiente de execução: development ou production")
+	fs.String("port", "", "porta HTTP")
+	fs.String("db-driver", "", "driver de repositório: memory, sqlite ou postgres")
+	fs.String("db-dsn", "", "caminho do arquivo SQLite ou string de conexão PostgreSQL")
+	fs.String("cors-origins", "", "origens CORS permitidas, separadas por vírgula")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	flags := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+
+	// Arquivo .env: opcional quando é o padrão, obrigatório quando informado explicitamente
+	path, explicit := *envFile, *envFile != ""
+	if !explicit {
+		if value, ok := lookupEnv("ENV_FILE"); ok && value != "" {
+			path, explicit = value, true
+		} else {
+			path = ".env"
+		}
+	}
+	dotenv, err := godotenv.Read(path)
+	if err != nil {
+		if explicit || !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+		}
+		dotenv = map[string]string{}
+	}
+
+	s := &source{flags: flags, lookupEnv: lookupEnv, dotenv: dotenv}
+
+	cfg := &Config{
+		Env:  s.get("env", "APP_ENV", EnvDevelopment),
+		Port: s.get("port", "PORT", "8080"),
+		JWT: JWTConfig{
+			Secret:            s.get("", "JWT_SECRET", ""),
+			Expiration:        s.duration("JWT_EXPIRATION", 15*time.Minute),
+			RefreshExpiration: s.duration("JWT_REFRESH_EXPIRATION", 7*24*time.Hour),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: splitList(s.get("cors-origins", "CORS_ALLOWED_ORIGINS", "*")),
+		},
+		Server: ServerConfig{
+			ReadTimeout:           s.duration("SERVER_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout:          s.duration("SERVER_WRITE_TIMEOUT", 75*time.Second),
+			IdleTimeout:           s.duration("SERVER_IDLE_TIMEOUT", 120*time.Second),
+			RequestTimeout:        s.duration("REQUEST_TIMEOUT", 60*time.Second),
+			ShutdownTimeout:       s.duration("SHUTDOWN_TIMEOUT", 10*time.Second),
+			SchedulerInterval:     s.duration("SCHEDULER_INTERVAL", 30*time.Second),
+			EventDispatchInterval: s.duration("EVENT_DISPATCH_INTERVAL", 5*time.Second),
+			TrustProxy:            s.bool("TRUST_PROXY", false),
+		},
+		Database: DatabaseConfig{
+			Driver: s.get("db-driver", "DB_DRIVER", "memory"),
+			DSN:    s.get("db-dsn", "DB_DSN", ""),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: s.bool("RATE_LIMIT_ENABLED", true),
+			Auth:    s.rateLimit("RATE_LIMIT_AUTH", RateLimit{Requests: 10, Period: time.Minute}),
+			Public:  s.rateLimit("RATE_LIMIT_PUBLIC", RateLimit{Requests: 300, Period: time.Minute}),
+			User:    s.rateLimit("RATE_LIMIT_USER", RateLimit{Requests: 120, Period: time.Minute}),
+			Comment: s.rateLimit("RATE_LIMIT_COMMENT", RateLimit{Requests: 10, Period: time.Minute}),
+		},
+		Lockout: LockoutConfig{
+			AccountMaxFailures: s.int("LOGIN_MAX_FAILURES", 5),
+			IPMaxFailures:      s.int("LOGIN_IP_MAX_FAILURES", 20),
+			BaseDelay:          s.duration("LOGIN_LOCKOUT_BASE_DELAY", time.Minute),
+			MaxDelay:           s.duration("LOGIN_LOCKOUT_MAX_DELAY", time.Hour),
+			FailureWindow:      s.duration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+		},
+		Site: SiteConfig{
+			Title:   s.get("", "SITE_TITLE", "App15 Blog"),
+			URL:     strings.TrimSuffix(s.get("", "SITE_URL", ""), "/"),
+			PostURL: s.get("", "SITE_POST_URL", ""),
+		},
+		Admin: AdminConfig{
+			Email: strings.TrimSpace(s.get("", "ADMIN_EMAIL", "")),
+		},
+	}
+
+	// Sem DB_DSN, a conexão PostgreSQL é montada a partir das variáveis DB_HOST, DB_PORT etc.
+	if cfg.Database.Driver == "postgres" && cfg.Database.DSN == "" && s.get("", "DB_HOST", "") != "" {
+		cfg.Database.DSN = postgresDSN(s)
+	}
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Validate verifica se a configuração é consistente e segura para o ambiente escolhido
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Env != EnvDevelopment && c.Env != EnvProduction {
+		errs = append(errs, fmt.Errorf("APP_ENV inválido: %q (use %s ou %s)", c.Env, EnvDevelopment, EnvProduction))
+	}
+
+	if c.JWT.Secret == "" {
+		errs = append(errs, errors.New("JWT_SECRET é obrigatório"))
+	} else if c.IsProduction() {
+		if c.JWT.Secret == DefaultJWTSecret {
+			errs = append(errs, errors.New("JWT_SECRET não pode usar a chave padrão em produção"))
+		} else if len(c.JWT.Secret) < minProductionSecretLength {
+			errs = append(errs, fmt.Errorf("JWT_SECRET deve ter pelo menos %d caracteres em produção", minProductionSecretLength))
+		}
+	}
+
+	if c.IsProduction() {
+		for _, origin := range c.CORS.AllowedOrigins {
+			if origin == "*" {
+				errs = append(errs, errors.New("CORS_ALLOWED_ORIGINS deve listar as origens permitidas em produção"))
+				break
+			}
+		}
+	}
+
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"JWT_EXPIRATION", c.JWT.Expiration},
+		{"JWT_REFRESH_EXPIRATION", c.JWT.RefreshExpiration},
+		{"SERVER_READ_TIMEOUT", c.Server.ReadTimeout},
+		{"SERVER_WRITE_TIMEOUT", c.Server.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", c.Server.IdleTimeout},
+		{"REQUEST_TIMEOUT", c.Server.RequestTimeout},
+		{"SHUTDOWN_TIMEOUT", c.Server.ShutdownTimeout},
+		{"SCHEDULER_INTERVAL", c.Server.SchedulerInterval},
+		{"EVENT_DISPATCH_INTERVAL", c.Server.EventDispatchInterval},
+		{"LOGIN_LOCKOUT_BASE_DELAY", c.Lockout.BaseDelay},
+		{"LOGIN_LOCKOUT_MAX_DELAY", c.Lockout.MaxDelay},
+		{"LOGIN_FAILURE_WINDOW", c.Lockout.FailureWindow},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s deve ser positivo", d.name))
+		}
+	}
+
+	limits := []struct {
+		name  string
+		value RateLimit
+	}{
+		{"RATE_LIMIT_AUTH", c.RateLimit.Auth},
+		{"RATE_LIMIT_PUBLIC", c.RateLimit.Public},
+		{"RATE_LIMIT_USER", c.RateLimit.User},
+		{"RATE_LIMIT_COMMENT", c.RateLimit.Comment},
+	}
+	for _, l := range limits {
+		if l.value.Requests <= 0 || l.value.Period <= 0 {
+			errs = append(errs, fmt.Errorf("%s deve ter requisições e período positivos", l.name))
+		}
+	}
+
+	if c.Lockout.AccountMaxFailures <= 0 {
+		errs = append(errs, errors.New("LOGIN_MAX_FAILURES deve ser positivo"))
+	}
+	if c.Lockout.IPMaxFailures <= 0 {
+		errs = append(errs, errors.New("LOGIN_IP_MAX_FAILURES deve ser positivo"))
+	}
+	if c.Lockout.MaxDelay < c.Lockout.BaseDelay {
+		errs = append(errs, errors.New("LOGIN_LOCKOUT_MAX_DELAY deve ser maior ou igual a LOGIN_LOCKOUT_BASE_DELAY"))
+	}
+
+	if c.Site.URL != "" {
+		if u, err := url.Parse(c.Site.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("SITE_URL inválido: %q (use uma URL absoluta, como https://blog.exemplo.com)", c.Site.URL))
+		}
+	}
+	if c.Site.PostURL != "" {
+		u, err := url.Parse(strings.Replace(c.Site.PostURL, "{id}", "id", 1))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || !strings.Contains(c.Site.PostURL, "{id}") {
+			errs = append(errs, fmt.Errorf("SITE_POST_URL inválido: %q (use uma URL absoluta com {id}, como https://blog.exemplo.com/posts/{id})", c.Site.PostURL))
+		}
+	}
+
+	if c.Admin.Email != "" && !strings.Contains(c.Admin.Email, "@") {
+		errs = append(errs, fmt.Errorf("ADMIN_EMAIL inválido: %q", c.Admin.Email))
+	}
+
+	switch c.Database.Driver {
+	case "memory":
+	case "sqlite", "postgres":
+		if c.Database.DSN == "" {
+			errs = append(errs, fmt.Errorf("DB_DSN é obrigatório para o driver %s", c.Database.Driver))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("DB_DRIVER não suportado: %q", c.Database.Driver))
+	}
+
+	return errors.Join(errs...)
+}
+
+// source resolve cada configuração pela precedência: flag, variável de ambiente, .env e padrão
+type source struct {
+	flags     map[string]string
+	lookupEnv func(string) (string, bool)
+	dotenv    map[string]string
+	errs      []error
+}
+
+// get retorna o valor da flag (se houver e tiver sido informada), da variável de ambiente,
+// do arquivo .env ou o padrão. Valores vazios são tratados como ausentes.
+func (s *source) get(flagName, key, fallback string) string {
+	if value, ok := s.flags[flagName]; ok && flagName != "" {
+		return value
+	}
+	if value, ok := s.lookupEnv(key); ok && value != "" {
+		return value
+	}
+	if value := s.dotenv[key]; value != "" {
+		return value
+	}
+	return fallback
+}
+
+// duration lê uma duração no formato do time.ParseDuration, como 15m ou 24h
+func (s *source) duration(key string, fallback time.Duration) time.Duration {
+	value := s.get("", key, "")
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s inválido: %q", key, value))
+		return fallback
+	}
+	return d
+}
+
+// int lê um número inteiro
+func (s *source) int(key string, fallback int) int {
+	value := s.get("", key, "")
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s inválido: %q", key, value))
+		return fallback
+	}
+	return n
+}
+
+// bool lê um valor booleano, como true, false, 1 ou 0
+func (s *source) bool(key string, fallback bool) bool {
+	value := s.get("", key, "")
+	if value == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s inválido: %q", key, value))
+		return fallback
+	}
+	return b
+}
+
+// rateLimit lê um limite no formato "requisições/período", como 10/1m
+func (s *source) rateLimit(key string, fallback RateLimit) RateLimit {
+	value := s.get("", key, "")
+	if value == "" {
+		return fallback
+	}
+	requests, period, found := strings.Cut(value, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || !found {
+		s.errs = append(s.errs, fmt.Errorf("%s inválido: %q (use o formato 10/1m)", key, value))
+		return fallback
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s inválido: %q (use o formato 10/1m)", key, value))
+		return fallback
+	}
+	return RateLimit{Requests: n, Period: d}
+}
+
+// postgresDSN monta a string de conexão a partir das variáveis DB_HOST, DB_PORT, DB_USER,
+// DB_PASSWORD, DB_NAME e DB_SSL_MODE
+func postgresDSN(s *source) string {
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(s.get("", "DB_USER", "postgres"), s.get("", "DB_PASSWORD", "")),
+		Host:     s.get("", "DB_HOST", "localhost") + ":" + s.get("", "DB_PORT", "5432"),
+		Path:     "/" + s.get("", "DB_NAME", "blog"),
+		RawQuery: "sslmode=" + url.QueryEscape(s.get("", "DB_SSL_MODE", "disable")),
+	}
+	return u.String()
+}
+
+// splitList separa uma lista de valores por vírgula, ignorando itens vazios
+func splitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
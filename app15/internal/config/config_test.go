@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeEnv simula as variáveis de ambiente do processo
+func fakeEnv(vars map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	}
+}
+
+// writeEnvFile cria um arquivo .env temporário com o conteúdo informado
+func writeEnvFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Erro ao criar arquivo .env: %v", err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeEnvFile(t, "PORT=7000\nDB_DRIVER=sqlite\nDB_DSN=arquivo.db\nJWT_SECRET=do-arquivo\nJWT_EXPIRATION=30m\nRATE_LIMIT_AUTH=5/30s\n")
+	env := fakeEnv(map[string]string{
+		"ENV_FILE":   path,
+		"PORT":       "9000",
+		"JWT_SECRET": "do-ambiente",
+	})
+
+	cfg, err := load([]string{"-port", "9999"}, env)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if cfg.Port != "9999" {
+		t.Errorf("flag deveria prevalecer: esperava porta 9999, obteve %s", cfg.Port)
+	}
+	if cfg.JWT.Secret != "do-ambiente" {
+		t.Errorf("variável de ambiente deveria prevalecer sobre o .env: obteve %s", cfg.JWT.Secret)
+	}
+	if cfg.Database.Driver != "sqlite" || cfg.Database.DSN != "arquivo.db" || cfg.JWT.Expiration != 30*time.Minute {
+		t.Errorf("valores do .env não aplicados: %+v", cfg)
+	}
+	if cfg.RateLimit.Auth != (RateLimit{Requests: 5, Period: 30 * time.Second}) {
+		t.Errorf("limite do .env não aplicado: %+v", cfg.RateLimit.Auth)
+	}
+	if cfg.JWT.RefreshExpiration != 7*24*time.Hour || cfg.Env != EnvDevelopment || !cfg.RateLimit.Enabled || cfg.RateLimit.User.Requests != 120 ||
+		cfg.RateLimit.Comment != (RateLimit{Requests: 10, Period: time.Minute}) {
+		t.Errorf("valores padrão não aplicados: %+v", cfg)
+	}
+}
+
+func TestLoadDefaultEnvFileIsOptional(t *testing.T) {
+	// O diretório do pacote não tem .env, então o arquivo padrão é ignorado
+	cfg, err := load(nil, fakeEnv(map[string]string{"JWT_SECRET": "segredo"}))
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if cfg.Database.Driver != "memory" || cfg.Port != "8080" || len(cfg.CORS.AllowedOrigins) != 1 || cfg.CORS.AllowedOrigins[0] != "*" {
+		t.Errorf("valores padrão não aplicados: %+v", cfg)
+	}
+
+	if _, err := load([]string{"-env-file", filepath.Join(t.TempDir(), "inexistente.env")}, fakeEnv(nil)); err == nil {
+		t.Error("esperava erro com arquivo .env informado explicitamente e inexistente")
+	}
+}
+
+func TestLoadPostgresDSNFromParts(t *testing.T) {
+	env := fakeEnv(map[string]string{
+		"ENV_FILE":    writeEnvFile(t, ""),
+		"JWT_SECRET":  "segredo",
+		"DB_DRIVER":   "postgres",
+		"DB_HOST":     "db",
+		"DB_USER":     "blog",
+		"DB_PASSWORD": "p@ss",
+		"DB_NAME":     "blog",
+	})
+
+	cfg, err := load(nil, env)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+	if want := "postgres://blog:p%40ss@db:5432/blog?sslmode=disable"; cfg.Database.DSN != want {
+		t.Errorf("esperava DSN %s, obteve %s", want, cfg.Database.DSN)
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	strongSecret := strings.Repeat("x", minProductionSecretLength)
+
+	tests := []struct {
+		name    string
+		vars    map[string]string
+		args    []string
+		wantErr string
+	}{
+		{"sem chave JWT", map[string]string{}, nil, "JWT_SECRET é obrigatório"},
+		{"chave padrão em produção", map[string]string{"APP_ENV": "production", "JWT_SECRET": DefaultJWTSecret, "CORS_ALLOWED_ORIGINS": "https://blog.exemplo.com"}, nil, "chave padrão"},
+		{"chave curta em produção", map[string]string{"JWT_SECRET": "curta", "CORS_ALLOWED_ORIGINS": "https://blog.exemplo.com"}, []string{"-env", "production"}, "pelo menos"},
+		{"CORS aberto em produção", map[string]string{"APP_ENV": "production", "JWT_SECRET": strongSecret}, nil, "CORS_ALLOWED_ORIGINS"},
+		{"duração inválida", map[string]string{"JWT_SECRET": "segredo", "JWT_EXPIRATION": "amanhã"}, nil, "JWT_EXPIRATION inválido"},
+		{"driver desconhecido", map[string]string{"JWT_SECRET": "segredo"}, []string{"-db-driver", "mongo"}, "DB_DRIVER não suportado"},
+		{"sqlite sem DSN", map[string]string{"JWT_SECRET": "segredo", "DB_DRIVER": "sqlite"}, nil, "DB_DSN é obrigatório"},
+		{"ambiente desconhecido", map[string]string{"JWT_SECRET": "segredo", "APP_ENV": "staging"}, nil, "APP_ENV inválido"},
+		{"limite sem período", map[string]string{"JWT_SECRET": "segredo", "RATE_LIMIT_AUTH": "10"}, nil, "RATE_LIMIT_AUTH inválido"},
+		{"limite zerado", map[string]string{"JWT_SECRET": "segredo", "RATE_LIMIT_USER": "0/1m"}, nil, "RATE_LIMIT_USER deve ter"},
+		{"limite de comentários zerado", map[string]string{"JWT_SECRET": "segredo", "RATE_LIMIT_COMMENT": "0/1m"}, nil, "RATE_LIMIT_COMMENT deve ter"},
+		{"booleano inválido", map[string]string{"JWT_SECRET": "segredo", "RATE_LIMIT_ENABLED": "talvez"}, nil, "RATE_LIMIT_ENABLED inválido"},
+		{"bloqueio sem limite de falhas", map[string]string{"JWT_SECRET": "segredo", "LOGIN_MAX_FAILURES": "0"}, nil, "LOGIN_MAX_FAILURES deve ser positivo"},
+		{"URL do site relativa", map[string]string{"JWT_SECRET": "segredo", "SITE_URL": "blog.exemplo.com"}, nil, "SITE_URL inválido"},
+		{"URL do post sem ID", map[string]string{"JWT_SECRET": "segredo", "SITE_POST_URL": "https://blog.exemplo.com/posts"}, nil, "SITE_POST_URL inválido"},
+		{"URL do post relativa", map[string]string{"JWT_SECRET": "segredo", "SITE_POST_URL": "/posts/{id}"}, nil, "SITE_POST_URL inválido"},
+		{"email do administrador inválido", map[string]string{"JWT_SECRET": "segredo", "ADMIN_EMAIL": "admin"}, nil, "ADMIN_EMAIL inválido"},
+		{"bloqueio máximo menor que o inicial", map[string]string{"JWT_SECRET": "segredo", "LOGIN_LOCKOUT_BASE_DELAY": "2h"}, nil, "LOGIN_LOCKOUT_MAX_DELAY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.vars["ENV_FILE"] = writeEnvFile(t, "")
+			_, err := load(tt.args, fakeEnv(tt.vars))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("esperava erro contendo %q, obteve %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	cfg, err := load(nil, fakeEnv(map[string]string{
+		"ENV_FILE":             writeEnvFile(t, ""),
+		"APP_ENV":              "production",
+		"JWT_SECRET":           strongSecret,
+		"CORS_ALLOWED_ORIGINS": "https://blog.exemplo.com, https://admin.exemplo.com",
+	}))
+	if err != nil {
+		t.Fatalf("configuração de produção válida rejeitada: %v", err)
+	}
+	if !cfg.IsProduction() || len(cfg.CORS.AllowedOrigins) != 2 || cfg.CORS.AllowedOrigins[1] != "https://admin.exemplo.com" {
+		t.Errorf("configuração de produção não aplicada: %+v", cfg)
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Ações registradas na trilha de auditoria
+const (
+	AuditActionChangeRole    = "user.change_role"
+	AuditActionUnlockUser    = "user.unlock"
+	AuditActionDeletePost    = "post.force_delete"
+	AuditActionDeleteComment = "comment.force_delete"
+)
+
+// AuditActorSystem é o responsável pelas ações feitas pela própria aplicação, como a
+// promoção do administrador configurado
+const AuditActorSystem = "system"
+
+// AuditEntry representa um registro da trilha de auditoria de ações administrativas
+type AuditEntry struct {
+	ID         string    `json:"id"`
+	ActorID    string    `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Details    string    `json:"details,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewAuditEntry cria uma nova instância de AuditEntry
+func NewAuditEntry(actorID, action, targetType, targetID, details string) (*AuditEntry, error) {
+	if actorID == "" {
+		return nil, errors.New("ID do responsável não pode ser vazio")
+	}
+
+	if action == "" {
+		return nil, errors.New("ação não pode ser vazia")
+	}
+
+	if targetID == "" {
+		return nil, errors.New("ID do alvo não pode ser vazio")
+	}
+
+	return &AuditEntry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}, nil
+}
@@ -7,13 +7,25 @@ import (
 
 // Comment representa a entidade de comentário no domínio
 type Comment struct {
-	ID        string    `json:"id"`
-	Content   string    `json:"content"`
-	PostID    string    `json:"post_id"`
-	AuthorID  string    `json:"author_id"`
-	Author    *User     `json:"author,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string      `json:"id"`
+	Content   string      `json:"content"`
+	PostID    string      `json:"post_id"`
+	ParentID  string      `json:"parent_id,omitempty"`
+	Depth     int         `json:"depth"`
+	Deleted   bool        `json:"deleted,omitempty"`
+	Likes     int         `json:"likes"` // Calculado a partir das reações, não é gravado com o comentário
+	AuthorID  string      `json:"author_id"`
+	Author    *PublicUser `json:"author,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// CommentThread representa um comentário com suas respostas aninhadas.
+// ReplyCount traz o total de respostas diretas, mesmo quando Replies traz apenas uma página delas.
+type CommentThread struct {
+	*Comment
+	ReplyCount int              `json:"reply_count"`
+	Replies    []*CommentThread `json:"replies"`
 }
 
 // NewComment cria uma nova instância de Comment
@@ -52,6 +64,24 @@ func (c *Comment) UpdateContent(content string) error {
 	return nil
 }
 
+// SetParent torna o comentário uma resposta ao comentário informado
+func (c *Comment) SetParent(parent *Comment) error {
+	if parent.PostID != c.PostID {
+		return errors.New("o comentário respondido pertence a outro post")
+	}
+
+	c.ParentID = parent.ID
+	c.Depth = parent.Depth + 1
+	return nil
+}
+
+// Tombstone remove o conteúdo do comentário mantendo-o na árvore de respostas
+func (c *Comment) Tombstone() {
+	c.Content = ""
+	c.Deleted = true
+	c.UpdatedAt = time.Now()
+}
+
 // IsAuthor verifica se o usuário especificado é o autor do comentário
 func (c *Comment) IsAuthor(userID string) bool {
 	return c.AuthorID == userID
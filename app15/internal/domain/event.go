@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// EventType identifica o tipo de um evento de domínio
+type EventType string
+
+// Eventos de domínio emitidos pelos serviços
+const (
+	EventPostPublished  EventType = "post.published"
+	EventCommentAdded   EventType = "comment.added"
+	EventCommentReplied EventType = "comment.replied"
+)
+
+// Event representa algo que aconteceu no domínio e interessa a outras partes da aplicação.
+// ActorID é vazio quando o evento foi disparado pelo sistema, como na publicação agendada.
+type Event struct {
+	ID         string    `json:"id"`
+	Type       EventType `json:"type"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	PostID     string    `json:"post_id"`
+	CommentID  string    `json:"comment_id,omitempty"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// NewPostPublishedEvent cria o evento de publicação de um post
+func NewPostPublishedEvent(post *Post, actorID string) *Event {
+	return &Event{
+		Type:       EventPostPublished,
+		ActorID:    actorID,
+		PostID:     post.ID,
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewCommentEvents cria os eventos de um novo comentário: CommentAdded e, se for uma
+// resposta, também CommentReplied
+func NewCommentEvents(comment *Comment) ([]*Event, error) {
+	if comment.ID == "" || comment.PostID == "" {
+		return nil, errors.New("comentário sem ID ou post")
+	}
+
+	now := time.Now()
+	events := []*Event{{
+		Type:       EventCommentAdded,
+		ActorID:    comment.AuthorID,
+		PostID:     comment.PostID,
+		CommentID:  comment.ID,
+		ParentID:   comment.ParentID,
+		OccurredAt: now,
+	}}
+
+	if comment.ParentID != "" {
+		events = append(events, &Event{
+			Type:       EventCommentReplied,
+			ActorID:    comment.AuthorID,
+			PostID:     comment.PostID,
+			CommentID:  comment.ID,
+			ParentID:   comment.ParentID,
+			OccurredAt: now,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// LoginAttempt representa uma tentativa de login, bem-sucedida ou não. UserID é vazio
+// quando o email informado não pertence a nenhuma conta.
+type LoginAttempt struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id,omitempty"`
+	Email     string    `json:"email"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewLoginAttempt cria uma nova instância de LoginAttempt
+func NewLoginAttempt(userID, email, ip, userAgent string, success bool) (*LoginAttempt, error) {
+	if email == "" {
+		return nil, errors.New("email da tentativa não pode ser vazio")
+	}
+
+	return &LoginAttempt{
+		UserID:    userID,
+		Email:     NormalizeEmail(email),
+		IP:        ip,
+		UserAgent: userAgent,
+		Success:   success,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// LockoutAccountKey retorna a chave de bloqueio da conta com o email informado. A chave
+// usa o email, e não o ID, para que emails inexistentes se comportem como contas reais.
+func LockoutAccountKey(email string) string {
+	return "account:" + NormalizeEmail(email)
+}
+
+// LockoutIPKey retorna a chave de bloqueio de um IP
+func LockoutIPKey(ip string) string {
+	return "ip:" + ip
+}
+
+// BackoffPolicy define quando uma chave é bloqueada: a partir de Threshold falhas seguidas,
+// cada nova falha bloqueia por BaseDelay, dobrando a cada falha até MaxDelay. Falhas mais
+// antigas que Window, fora de um bloqueio, são esquecidas.
+type BackoffPolicy struct {
+	Threshold int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Window    time.Duration
+}
+
+// Delay retorna a duração do bloqueio após a quantidade de falhas informada, ou zero
+// se ainda não atingiu o limite
+func (p BackoffPolicy) Delay(failures int) time.Duration {
+	if failures < p.Threshold {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := p.Threshold; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	return delay
+}
+
+// Lockout representa as falhas de login acumuladas por uma conta ou por um IP
+type Lockout struct {
+	Key         string     `json:"key"`
+	Failures    int        `json:"failures"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// NewLockout cria o estado de bloqueio de uma chave ainda sem falhas
+func NewLockout(key string) *Lockout {
+	return &Lockout{Key: key}
+}
+
+// IsLocked verifica se a chave está bloqueada no instante informado
+func (l *Lockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && now.Before(*l.LockedUntil)
+}
+
+// RegisterFailure contabiliza uma falha e bloqueia a chave quando a política exige
+func (l *Lockout) RegisterFailure(policy BackoffPolicy, now time.Time) {
+	if !l.IsLocked(now) && now.Sub(l.UpdatedAt) > policy.Window {
+		l.Failures = 0
+		l.LockedUntil = nil
+	}
+
+	l.Failures++
+	l.UpdatedAt = now
+
+	if delay := policy.Delay(l.Failures); delay > 0 {
+		lockedUntil := now.Add(delay)
+		l.LockedUntil = &lockedUntil
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Notification representa uma entrada na caixa de notificações de um usuário
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	EventID   string     `json:"event_id"`
+	Type      EventType  `json:"type"`
+	ActorID   string     `json:"actor_id,omitempty"`
+	PostID    string     `json:"post_id"`
+	CommentID string     `json:"comment_id,omitempty"`
+	Message   string     `json:"message"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewNotification cria uma notificação de um evento para o usuário informado
+func NewNotification(userID string, event *Event, message string) (*Notification, error) {
+	if userID == "" {
+		return nil, errors.New("ID do destinatário não pode ser vazio")
+	}
+
+	if event.ID == "" {
+		return nil, errors.New("ID do evento não pode ser vazio")
+	}
+
+	return &Notification{
+		UserID:    userID,
+		EventID:   event.ID,
+		Type:      event.Type,
+		ActorID:   event.ActorID,
+		PostID:    event.PostID,
+		CommentID: event.CommentID,
+		Message:   message,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// IsRead verifica se a notificação já foi lida
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor indica um cursor de paginação malformado
+var ErrInvalidCursor = errors.New("cursor de paginação inválido")
+
+// Cursor marca a posição do último item entregue em uma página. Listagens em ordem
+// cronológica usam a data e o ID do item; a listagem por popularidade usa o instante do
+// ranking, fixado na primeira página, a pontuação e o ID; listagens por relevância usam
+// o deslocamento. Para os clientes, o cursor é um texto opaco.
+type Cursor struct {
+	Time   time.Time `json:"t"`
+	ID     string    `json:"id,omitempty"`
+	Score  float64   `json:"s,omitempty"`
+	Offset int       `json:"o,omitempty"`
+}
+
+// Encode codifica o cursor no texto opaco entregue aos clientes
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodifica o texto recebido de um cliente; o texto vazio representa o início da listagem
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Offset < 0 || cursor.Score < 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// NewestFirst informa se o item (t, id) vem depois do cursor em uma listagem do mais
+// recente para o mais antigo, com empate resolvido pelo ID
+func (c *Cursor) NewestFirst(t time.Time, id string) bool {
+	return t.Before(c.Time) || (t.Equal(c.Time) && id > c.ID)
+}
+
+// OldestFirst informa se o item (t, id) vem depois do cursor em uma listagem do mais
+// antigo para o mais recente, com empate resolvido pelo ID
+func (c *Cursor) OldestFirst(t time.Time, id string) bool {
+	return t.After(c.Time) || (t.Equal(c.Time) && id > c.ID)
+}
+
+// HighestScoreFirst informa se o item (score, id) vem depois do cursor em uma listagem
+// da maior para a menor pontuação, com empate resolvido pelo ID
+func (c *Cursor) HighestScoreFirst(score float64, id string) bool {
+	return score < c.Score || (score == c.Score && id > c.ID)
+}
+
+// PageRequest descreve a página solicitada a um repositório. After é nil na primeira página;
+// WithTotal pede também a contagem de todos os itens da listagem, que tem custo adicional.
+type PageRequest struct {
+	After     *Cursor
+	Limit     int
+	WithTotal bool
+}
+
+// Page é uma página de resultados. NextCursor, presente quando HasMore é verdadeiro,
+// busca a página seguinte; Total só é preenchido quando solicitado.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+// NewPage monta uma página a partir de até limit+1 itens: o item excedente, quando existe,
+// indica apenas que há mais resultados e não é entregue. cursor gera a posição de um item.
+func NewPage[T any](items []T, limit int, cursor func(T) Cursor) *Page[T] {
+	page := &Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+	}
+	if page.HasMore && len(page.Items) > 0 {
+		page.NextCursor = cursor(page.Items[len(page.Items)-1]).Encode()
+	}
+	return page
+}
+
+// SetTotal preenche o total de itens da listagem
+func (p *Page[T]) SetTotal(total int) {
+	p.Total = &total
+}
@@ -2,18 +2,65 @@ package domain
 
 import (
 	"errors"
+	"sort"
+	"strings"
 	"time"
 )
 
+// PostStatus representa a etapa do ciclo de vida de um post
+type PostStatus string
+
+// Etapas do ciclo de vida de um post. Apenas posts publicados aparecem para os leitores.
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusScheduled PostStatus = "scheduled"
+	PostStatusPublished PostStatus = "published"
+	PostStatusArchived  PostStatus = "archived"
+)
+
+// Erros do ciclo de vida de posts
+var (
+	ErrInvalidPostStatus       = errors.New("status de post inválido")
+	ErrInvalidStatusTransition = errors.New("mudança de status não permitida")
+	ErrInvalidPublishAt        = errors.New("data de publicação deve estar no futuro")
+)
+
+// postTransitions define, para cada status, os status de origem permitidos
+var postTransitions = map[PostStatus][]PostStatus{
+	PostStatusDraft:     {PostStatusDraft, PostStatusScheduled, PostStatusPublished, PostStatusArchived},
+	PostStatusScheduled: {PostStatusDraft, PostStatusScheduled},
+	PostStatusPublished: {PostStatusDraft, PostStatusScheduled, PostStatusPublished, PostStatusArchived},
+	PostStatusArchived:  {PostStatusPublished, PostStatusArchived},
+}
+
+// IsValid verifica se o status é um dos status conhecidos
+func (s PostStatus) IsValid() bool {
+	_, ok := postTransitions[s]
+	return ok
+}
+
 // Post representa a entidade de post de blog no domínio
 type Post struct {
-	ID        string    `json:"id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	AuthorID  string    `json:"author_id"`
-	Author    *User     `json:"author,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Content     string      `json:"content"`      // Texto em Markdown, como escrito pelo autor
+	ContentHTML string      `json:"content_html"` // HTML seguro gerado a partir do Markdown
+	Excerpt     string      `json:"excerpt"`      // Resumo em texto simples para as listagens
+	AuthorID    string      `json:"author_id"`
+	Author      *PublicUser `json:"author,omitempty"`
+	Tags        []string    `json:"tags"`
+	Likes       int         `json:"likes"` // Calculado a partir das reações, não é gravado com o post
+	Status      PostStatus  `json:"status"`
+	PublishAt   *time.Time  `json:"publish_at,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// PostEntry identifica um post e a sua última alteração, sem o conteúdo, para listagens
+// extensas como a do sitemap
+type PostEntry struct {
+	ID        string
+	UpdatedAt time.Time
 }
 
 // NewPost cria uma nova instância de Post
@@ -36,11 +83,67 @@ func NewPost(title, content, authorID string) (*Post, error) {
 		Title:     title,
 		Content:   content,
 		AuthorID:  authorID,
+		Tags:      []string{},
+		Status:    PostStatusPublished,
+		PublishAt: &now,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
 }
 
+// IsPublished verifica se o post está visível para os leitores
+func (p *Post) IsPublished() bool {
+	return p.Status == PostStatusPublished
+}
+
+// PublishedAt retorna a data de publicação do post, ou a de criação quando não há uma
+func (p *Post) PublishedAt() time.Time {
+	if p.PublishAt != nil {
+		return *p.PublishAt
+	}
+	return p.CreatedAt
+}
+
+// ChangeStatus move o post para o status informado. O agendamento exige uma data de
+// publicação futura; ao publicar, a data de publicação passa a ser o momento atual,
+// exceto quando já havia uma data anterior (post agendado vencido ou republicado).
+func (p *Post) ChangeStatus(status PostStatus, publishAt *time.Time, now time.Time) error {
+	allowed, ok := postTransitions[status]
+	if !ok {
+		return ErrInvalidPostStatus
+	}
+
+	permitted := false
+	for _, from := range allowed {
+		if from == p.Status {
+			permitted = true
+			break
+		}
+	}
+	if !permitted {
+		return ErrInvalidStatusTransition
+	}
+
+	switch status {
+	case PostStatusDraft:
+		p.PublishAt = nil
+	case PostStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return ErrInvalidPublishAt
+		}
+		at := publishAt.UTC()
+		p.PublishAt = &at
+	case PostStatusPublished:
+		if p.PublishAt == nil || p.PublishAt.After(now) {
+			p.PublishAt = &now
+		}
+	}
+
+	p.Status = status
+	p.UpdatedAt = now
+	return nil
+}
+
 // UpdateTitle atualiza o título do post
 func (p *Post) UpdateTitle(title string) error {
 	if title == "" {
@@ -66,4 +169,44 @@ func (p *Post) UpdateContent(content string) error {
 // IsAuthor verifica se o usuário especificado é o autor do post
 func (p *Post) IsAuthor(userID string) bool {
 	return p.AuthorID == userID
-} 
\ No newline at end of file
+} 
+
+// SetTags substitui as tags do post, normalizadas para minúsculas, sem repetições e ordenadas
+func (p *Post) SetTags(tags []string) {
+	p.Tags = NormalizeTags(tags)
+	p.UpdatedAt = time.Now()
+}
+
+// HasTags verifica se o post possui todas as tags informadas
+func (p *Post) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range p.Tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeTags normaliza uma lista de tags: minúsculas, sem espaços nas pontas,
+// sem repetições e em ordem alfabética
+func NormalizeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
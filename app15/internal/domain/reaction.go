@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ReactionTarget identifica o tipo de conteúdo que recebeu uma reação
+type ReactionTarget string
+
+// Tipos de conteúdo que aceitam reações
+const (
+	ReactionTargetPost    ReactionTarget = "post"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// Reaction representa a curtida de um usuário em um post ou comentário.
+// Cada usuário curte um mesmo conteúdo no máximo uma vez.
+type Reaction struct {
+	UserID     string         `json:"user_id"`
+	TargetType ReactionTarget `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// NewReaction cria uma nova instância de Reaction
+func NewReaction(userID string, targetType ReactionTarget, targetID string) (*Reaction, error) {
+	if userID == "" {
+		return nil, errors.New("ID do usuário não pode ser vazio")
+	}
+
+	if targetType != ReactionTargetPost && targetType != ReactionTargetComment {
+		return nil, errors.New("tipo de conteúdo da reação inválido")
+	}
+
+	if targetID == "" {
+		return nil, errors.New("ID do conteúdo não pode ser vazio")
+	}
+
+	return &Reaction{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ReactionSummary resume as reações de um conteúdo para o usuário que reagiu
+type ReactionSummary struct {
+	TargetType ReactionTarget `json:"target_type"`
+	TargetID   string         `json:"target_id"`
+	Likes      int            `json:"likes"`
+	Liked      bool           `json:"liked"`
+}
+
+// Parâmetros do ranking de popularidade: a idade do post, em horas, é somada a
+// popularityAgeOffset e elevada a popularityGravity, de modo que as curtidas de um
+// post valem cada vez menos à medida que ele envelhece
+const (
+	popularityAgeOffset = 2.0
+	popularityGravity   = 1.5
+)
+
+// PopularityScore calcula a popularidade, no instante rankedAt, de um post com o número de
+// curtidas informado, publicado em publishedAt. A idade é contada em segundos inteiros, como
+// nos bancos de dados. Posts sem curtidas têm popularidade zero.
+func PopularityScore(likes int, publishedAt, rankedAt time.Time) float64 {
+	age := rankedAt.Unix() - publishedAt.Unix()
+	if age < 0 {
+		age = 0
+	}
+	return float64(likes) / math.Pow(float64(age)/3600+popularityAgeOffset, popularityGravity)
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// RefreshToken representa um token de renovação emitido para uma sessão.
+// Tokens renovados a partir do mesmo login compartilham o mesmo FamilyID.
+type RefreshToken struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	FamilyID   string     `json:"family_id"`
+	TokenHash  string     `json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	ReplacedBy string     `json:"replaced_by,omitempty"`
+}
+
+// NewRefreshToken cria um novo token de renovação e retorna também o seu valor em texto,
+// que é entregue ao cliente e nunca armazenado
+func NewRefreshToken(userID, familyID string, ttl time.Duration) (*RefreshToken, string, error) {
+	if userID == "" {
+		return nil, "", errors.New("ID do usuário não pode ser vazio")
+	}
+
+	if familyID == "" {
+		return nil, "", errors.New("ID da família de tokens não pode ser vazio")
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, "", err
+	}
+	plain := base64.RawURLEncoding.EncodeToString(raw)
+
+	now := time.Now()
+
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(plain),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, plain, nil
+}
+
+// HashRefreshToken calcula o hash com o qual o token de renovação é armazenado e buscado
+func HashRefreshToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsExpired verifica se o token já expirou
+func (t *RefreshToken) IsExpired() bool {
+	return time.Now().After(t.ExpiresAt)
+}
+
+// IsRevoked verifica se o token já foi revogado ou substituído por outro
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
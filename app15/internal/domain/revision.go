@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// PostRevision representa uma versão do conteúdo de um post, registrada a cada alteração
+type PostRevision struct {
+	ID        string    `json:"id"`
+	PostID    string    `json:"post_id"`
+	Version   int       `json:"version"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	EditorID  string    `json:"editor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPostRevision cria uma revisão com o estado atual do post
+func NewPostRevision(post *Post, version int, editorID string) (*PostRevision, error) {
+	if post.ID == "" {
+		return nil, errors.New("ID do post não pode ser vazio")
+	}
+
+	if version < 1 {
+		return nil, errors.New("versão deve ser positiva")
+	}
+
+	tags := make([]string, len(post.Tags))
+	copy(tags, post.Tags)
+
+	return &PostRevision{
+		PostID:    post.ID,
+		Version:   version,
+		Title:     post.Title,
+		Content:   post.Content,
+		Tags:      tags,
+		EditorID:  editorID,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// DiffOp identifica se uma linha foi mantida, adicionada ou removida entre duas versões
+type DiffOp string
+
+// Operações de uma linha do diff
+const (
+	DiffEqual  DiffOp = "="
+	DiffInsert DiffOp = "+"
+	DiffDelete DiffOp = "-"
+)
+
+// DiffLine representa uma linha do diff entre duas versões de um texto
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiff representa as diferenças entre duas revisões de um post
+type RevisionDiff struct {
+	PostID      string     `json:"post_id"`
+	From        int        `json:"from"`
+	To          int        `json:"to"`
+	Title       []DiffLine `json:"title"`
+	Content     []DiffLine `json:"content"`
+	TagsAdded   []string   `json:"tags_added"`
+	TagsRemoved []string   `json:"tags_removed"`
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// PostSearchResult representa um post encontrado por uma busca, com sua relevância
+type PostSearchResult struct {
+	Post    *Post   `json:"post"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet,omitempty"`
+}
+
+// minTermLength é o tamanho mínimo de um termo indexável
+const minTermLength = 2
+
+// Tokenize divide o texto em termos de busca normalizados
+func Tokenize(text string) []string {
+	terms := make([]string, 0)
+	for _, word := range strings.FieldsFunc(text, IsWordSeparator) {
+		if term := NormalizeTerm(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// NormalizeTerm converte uma palavra em termo de busca: minúsculas e sem acentos.
+// Palavras curtas demais resultam em termo vazio.
+func NormalizeTerm(word string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(word)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	term := b.String()
+	if len([]rune(term)) < minTermLength {
+		return ""
+	}
+	return term
+}
+
+// IsWordSeparator informa se o caractere separa palavras no texto
+func IsWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+}
@@ -2,17 +2,55 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Role representa o papel de um usuário, que define o que ele pode fazer no blog
+type Role string
+
+// Papéis disponíveis, do menos para o mais privilegiado
+const (
+	RoleReader    Role = "reader"
+	RoleAuthor    Role = "author"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// roleRank define a hierarquia dos papéis
+var roleRank = map[Role]int{
+	RoleReader:    1,
+	RoleAuthor:    2,
+	RoleModerator: 3,
+	RoleAdmin:     4,
+}
+
+// IsValid verifica se o papel é um dos papéis conhecidos
+func (r Role) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast verifica se o papel tem pelo menos os privilégios do papel informado
+func (r Role) AtLeast(min Role) bool {
+	return roleRank[r] >= roleRank[min]
+}
+
+// Autor atribuído aos posts, comentários e revisões de contas removidas
+const (
+	DeletedUserID       = "deleted"
+	DeletedUserUsername = "[removido]"
+)
+
 // User representa a entidade de usuário no domínio
 type User struct {
 	ID        string    `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` // O campo senha não será serializado para JSON
+	Role      Role      `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -41,8 +79,9 @@ func NewUser(username, email, password string) (*User, error) {
 	
 	return &User{
 		Username:  username,
-		Email:     email,
+		Email:     NormalizeEmail(email),
 		Password:  string(hashedPassword),
+		Role:      RoleAuthor,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
@@ -70,17 +109,22 @@ func (u *User) UpdatePassword(password string) error {
 	return nil
 }
 
-// UpdateEmail atualiza o email do usuário
+// UpdateEmail atualiza o email do usuário, padronizado por NormalizeEmail
 func (u *User) UpdateEmail(email string) error {
 	if email == "" {
 		return errors.New("email não pode ser vazio")
 	}
 	
-	u.Email = email
+	u.Email = NormalizeEmail(email)
 	u.UpdatedAt = time.Now()
 	return nil
 }
 
+// NormalizeEmail padroniza o email para comparar contas e tentativas de login da mesma conta
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // UpdateUsername atualiza o nome de usuário
 func (u *User) UpdateUsername(username string) error {
 	if username == "" {
@@ -90,4 +134,41 @@ func (u *User) UpdateUsername(username string) error {
 	u.Username = username
 	u.UpdatedAt = time.Now()
 	return nil
-} 
\ No newline at end of file
+} 
+
+// PublicUser contém os dados de um usuário que podem ser exibidos a qualquer visitante
+type PublicUser struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Role      Role      `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Public retorna os dados públicos do usuário, sem email
+func (u *User) Public() *PublicUser {
+	return &PublicUser{
+		ID:        u.ID,
+		Username:  u.Username,
+		Role:      u.Role,
+		CreatedAt: u.CreatedAt,
+	}
+}
+
+// DeletedPublicUser retorna o autor exibido no lugar de uma conta removida
+func DeletedPublicUser() *PublicUser {
+	return &PublicUser{
+		ID:       DeletedUserID,
+		Username: DeletedUserUsername,
+	}
+}
+
+// UpdateRole altera o papel do usuário
+func (u *User) UpdateRole(role Role) error {
+	if !role.IsValid() {
+		return errors.New("papel inválido")
+	}
+
+	u.Role = role
+	u.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,20 @@
+package events
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// EventBus define a interface do barramento de eventos de domínio. Os eventos são gravados
+// pelos repositórios na caixa de saída, na mesma operação que as entidades que os originaram,
+// e entregues aos assinantes de forma assíncrona e pelo menos uma vez: os handlers devem ser
+// idempotentes.
+type EventBus interface {
+	// Notify avisa que os repositórios gravaram eventos na caixa de saída junto com as
+	// entidades que os originaram, antecipando a entrega
+	Notify()
+}
+
+// Handler processa um evento entregue pelo barramento
+type Handler func(ctx context.Context, event *domain.Event) error
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// AuditRepository define a interface para persistência da trilha de auditoria
+type AuditRepository interface {
+	// Create registra uma nova entrada na trilha de auditoria
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+
+	// List retorna uma lista paginada de entradas, da mais recente para a mais antiga
+	List(ctx context.Context, page, pageSize int) ([]*domain.AuditEntry, error)
+
+	// ReassignUser anonimiza a trilha de um usuário removido: as entradas feitas por fromID
+	// ou sobre ele passam a citar toID. As ações continuam registradas.
+	ReassignUser(ctx context.Context, fromID, toID string) error
+}
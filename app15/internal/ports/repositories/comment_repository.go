@@ -2,14 +2,16 @@ package repositories
 
 import (
 	"context"
+	"time"
 
-	"app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
 )
 
 // CommentRepository define a interface para operações de persistência de comentários
 type CommentRepository interface {
-	// Create cria um novo comentário no repositório
-	Create(ctx context.Context, comment *domain.Comment) error
+	// Create cria um novo comentário no repositório. Os eventos informados são gravados
+	// na caixa de saída na mesma transação: ou ambos são gravados, ou nenhum.
+	Create(ctx context.Context, comment *domain.Comment, events ...*domain.Event) error
 	
 	// GetByID busca um comentário pelo seu ID
 	GetByID(ctx context.Context, id string) (*domain.Comment, error)
@@ -20,9 +22,22 @@ type CommentRepository interface {
 	// Delete remove um comentário do repositório
 	Delete(ctx context.Context, id string) error
 	
-	// ListByPost retorna uma lista paginada de comentários de um post específico
-	ListByPost(ctx context.Context, postID string, page, pageSize int) ([]*domain.Comment, error)
+	// ListByPost retorna uma página de comentários de um post específico em ordem cronológica
+	ListByPost(ctx context.Context, postID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error)
 	
-	// ListByAuthor retorna uma lista paginada de comentários de um autor específico
-	ListByAuthor(ctx context.Context, authorID string, page, pageSize int) ([]*domain.Comment, error)
+	// ListByAuthor retorna uma página de comentários de um autor específico em ordem cronológica
+	ListByAuthor(ctx context.Context, authorID string, req domain.PageRequest) (*domain.Page[*domain.Comment], error)
+
+	// ReassignAuthor transfere a autoria de todos os comentários de fromID para toID
+	ReassignAuthor(ctx context.Context, fromID, toID string) error
+
+	// ListThread retorna uma página das respostas diretas a parentID (ou dos comentários de
+	// primeiro nível, se parentID for vazio) em ordem cronológica. Cada comentário traz o total
+	// de respostas e a primeira página delas, com até req.Limit itens, aninhadas até depth níveis abaixo.
+	ListThread(ctx context.Context, postID, parentID string, depth int, req domain.PageRequest) (*domain.Page[*domain.CommentThread], error)
+
+	// Remove apaga o comentário se ele não tiver respostas; se tiver, mantém-no como lápide,
+	// sem conteúdo e alterado em at, para não deixar as respostas órfãs. A verificação e a
+	// alteração são atômicas: uma resposta gravada antes da remoção nunca perde o comentário respondido.
+	Remove(ctx context.Context, id string, at time.Time) error
 } 
\ No newline at end of file
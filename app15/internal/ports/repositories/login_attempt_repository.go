@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// LoginAttemptRepository define a interface para persistência das tentativas de login
+// e do estado de bloqueio por conta e por IP
+type LoginAttemptRepository interface {
+	// Create registra uma tentativa de login
+	Create(ctx context.Context, attempt *domain.LoginAttempt) error
+
+	// ListByUser retorna uma lista paginada das tentativas de login do usuário, da mais recente para a mais antiga
+	ListByUser(ctx context.Context, userID string, page, pageSize int) ([]*domain.LoginAttempt, error)
+
+	// GetLockout retorna o estado de bloqueio da chave; uma chave sem falhas retorna um estado vazio
+	GetLockout(ctx context.Context, key string) (*domain.Lockout, error)
+
+	// RegisterFailure contabiliza uma falha na chave segundo a política e retorna o estado
+	// resultante. A leitura, a contagem e a gravação são atômicas: falhas simultâneas na
+	// mesma chave são todas contabilizadas.
+	RegisterFailure(ctx context.Context, key string, policy domain.BackoffPolicy, now time.Time) (*domain.Lockout, error)
+
+	// DeleteLockout esquece as falhas da chave, desbloqueando-a
+	DeleteLockout(ctx context.Context, key string) error
+
+	// DeleteByUser remove as tentativas de login do usuário e as feitas com o seu email
+	DeleteByUser(ctx context.Context, userID, email string) error
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// NotificationRepository define a interface para persistência das caixas de notificações
+type NotificationRepository interface {
+	// Create registra uma notificação. Uma segunda notificação do mesmo evento para o mesmo
+	// usuário é ignorada, o que torna a entrega repetida de eventos segura.
+	Create(ctx context.Context, notification *domain.Notification) error
+
+	// ListByUser retorna uma lista paginada das notificações do usuário, da mais recente para a
+	// mais antiga; com unreadOnly, apenas as não lidas
+	ListByUser(ctx context.Context, userID string, unreadOnly bool, page, pageSize int) ([]*domain.Notification, error)
+
+	// CountUnread retorna a quantidade de notificações não lidas do usuário
+	CountUnread(ctx context.Context, userID string) (int, error)
+
+	// MarkRead marca como lida uma notificação do usuário
+	MarkRead(ctx context.Context, userID, id string, at time.Time) error
+
+	// MarkAllRead marca como lidas todas as notificações do usuário
+	MarkAllRead(ctx context.Context, userID string, at time.Time) error
+
+	// DeleteByUser remove as notificações recebidas pelo usuário e as causadas por ele,
+	// cujas mensagens citam o seu nome
+	DeleteByUser(ctx context.Context, userID string) error
+}
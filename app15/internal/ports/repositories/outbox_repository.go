@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// OutboxRepository define a interface para persistência dos eventos ainda não entregues
+type OutboxRepository interface {
+	// ListPending retorna até limit eventos não entregues e com menos de maxAttempts falhas,
+	// do mais antigo para o mais recente
+	ListPending(ctx context.Context, maxAttempts, limit int) ([]*domain.Event, error)
+
+	// MarkDelivered marca o evento como entregue
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+
+	// MarkFailed registra uma falha de entrega do evento, com o motivo
+	MarkFailed(ctx context.Context, id string, reason string) error
+}
@@ -2,27 +2,55 @@ package repositories
 
 import (
 	"context"
+	"time"
 
-	"app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
 )
 
 // PostRepository define a interface para operações de persistência de posts
 type PostRepository interface {
-	// Create cria um novo post no repositório
-	Create(ctx context.Context, post *domain.Post) error
+	// Create cria um novo post no repositório. Os eventos informados são gravados na
+	// caixa de saída na mesma transação: ou ambos são gravados, ou nenhum.
+	Create(ctx context.Context, post *domain.Post, events ...*domain.Event) error
 	
 	// GetByID busca um post pelo seu ID
 	GetByID(ctx context.Context, id string) (*domain.Post, error)
 	
-	// Update atualiza os dados de um post existente
-	Update(ctx context.Context, post *domain.Post) error
+	// Update atualiza os dados de um post existente, gravando os eventos informados na
+	// caixa de saída na mesma transação
+	Update(ctx context.Context, post *domain.Post, events ...*domain.Event) error
 	
-	// Delete remove um post do repositório
+	// Delete remove um post do repositório junto com os seus comentários, as curtidas no
+	// post e nos comentários e o histórico de revisões, na mesma transação
 	Delete(ctx context.Context, id string) error
 	
-	// List retorna uma lista paginada de posts
-	List(ctx context.Context, page, pageSize int) ([]*domain.Post, error)
+	// List retorna uma página de posts com o status informado (todos, se vazio),
+	// do mais recente para o mais antigo
+	List(ctx context.Context, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error)
 	
-	// ListByAuthor retorna uma lista paginada de posts de um autor específico
-	ListByAuthor(ctx context.Context, authorID string, page, pageSize int) ([]*domain.Post, error)
+	// ListByAuthor retorna uma página de posts de um autor específico com o status informado (todos, se vazio),
+	// do mais recente para o mais antigo
+	ListByAuthor(ctx context.Context, authorID string, status domain.PostStatus, req domain.PageRequest) (*domain.Page[*domain.Post], error)
+
+	// ReassignAuthor transfere a autoria de todos os posts de fromID para toID
+	ReassignAuthor(ctx context.Context, fromID, toID string) error
+
+	// ListPublishedEntries retorna o ID e a última alteração de até limit posts publicados,
+	// do mais recente para o mais antigo, sem carregar o conteúdo nem as tags
+	ListPublishedEntries(ctx context.Context, limit int) ([]domain.PostEntry, error)
+
+	// ListScheduledDue retorna os posts agendados cuja data de publicação é anterior ou igual a now
+	ListScheduledDue(ctx context.Context, now time.Time) ([]*domain.Post, error)
+
+	// ListPopular retorna uma página de posts publicados, do mais popular para o menos
+	// popular no instante rankedAt (domain.PopularityScore), com empates resolvidos pelo ID.
+	// As curtidas de cada post vêm preenchidas em Likes, e o cursor guarda rankedAt, a
+	// pontuação e o ID do último post entregue.
+	ListPopular(ctx context.Context, rankedAt time.Time, req domain.PageRequest) (*domain.Page[*domain.Post], error)
+
+	// Search retorna uma página de posts publicados que contêm algum termo da consulta e
+	// todas as tags informadas, ordenada por relevância e depois do mais recente para o mais antigo.
+	// Com a consulta vazia, filtra apenas pelas tags. Como a relevância muda com o acervo,
+	// o cursor da busca guarda apenas o deslocamento.
+	Search(ctx context.Context, query string, tags []string, req domain.PageRequest) (*domain.Page[*domain.PostSearchResult], error)
 } 
\ No newline at end of file
@@ -0,0 +1,24 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// ReactionRepository define a interface para persistência das curtidas em posts e comentários.
+// Cada usuário reage a um conteúdo no máximo uma vez, mesmo com requisições simultâneas.
+type ReactionRepository interface {
+	// Add registra a reação; retorna false, sem erro, se o usuário já havia reagido ao conteúdo
+	Add(ctx context.Context, reaction *domain.Reaction) (bool, error)
+
+	// Remove apaga a reação do usuário ao conteúdo; retorna false, sem erro, se ela não existia
+	Remove(ctx context.Context, userID string, targetType domain.ReactionTarget, targetID string) (bool, error)
+
+	// CountByTargets conta as reações de cada conteúdo informado com uma única busca.
+	// Conteúdos sem reações ficam fora do mapa.
+	CountByTargets(ctx context.Context, targetType domain.ReactionTarget, targetIDs []string) (map[string]int, error)
+
+	// DeleteByUser remove todas as reações do usuário, descontando-as das contagens
+	DeleteByUser(ctx context.Context, userID string) error
+}
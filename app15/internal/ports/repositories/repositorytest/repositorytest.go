@@ -0,0 +1,1673 @@
+// Package repositorytest contém a suíte de contrato que toda implementação
+// das portas de repositório deve satisfazer.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/ports/repositories"
+)
+
+// Repositories agrupa as implementações de repositório sob teste
+type Repositories struct {
+	Users         repositories.UserRepository
+	Posts         repositories.PostRepository
+	Comments      repositories.CommentRepository
+	Tokens        repositories.TokenRepository
+	Audit         repositories.AuditRepository
+	Revisions     repositories.RevisionRepository
+	Outbox        repositories.OutboxRepository
+	Notifications repositories.NotificationRepository
+	LoginAttempts repositories.LoginAttemptRepository
+	Reactions     repositories.ReactionRepository
+}
+
+// Factory cria um conjunto de repositórios novo e vazio para cada teste
+type Factory func(t *testing.T) Repositories
+
+// Run executa a suíte de contrato completa sobre os repositórios criados pela factory
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("UserRepository", func(t *testing.T) { testUserRepository(t, newRepos) })
+	t.Run("PostRepository", func(t *testing.T) { testPostRepository(t, newRepos) })
+	t.Run("CommentRepository", func(t *testing.T) { testCommentRepository(t, newRepos) })
+	t.Run("TokenRepository", func(t *testing.T) { testTokenRepository(t, newRepos) })
+	t.Run("AuditRepository", func(t *testing.T) { testAuditRepository(t, newRepos) })
+	t.Run("RevisionRepository", func(t *testing.T) { testRevisionRepository(t, newRepos) })
+	t.Run("OutboxRepository", func(t *testing.T) { testOutboxRepository(t, newRepos) })
+	t.Run("NotificationRepository", func(t *testing.T) { testNotificationRepository(t, newRepos) })
+	t.Run("LoginAttemptRepository", func(t *testing.T) { testLoginAttemptRepository(t, newRepos) })
+	t.Run("ReactionRepository", func(t *testing.T) { testReactionRepository(t, newRepos) })
+}
+
+// baseTime é truncado em microssegundos, a maior precisão comum aos bancos suportados
+var baseTime = time.Date(2023, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+func newUser(n int) *domain.User {
+	return &domain.User{
+		ID:        fmt.Sprintf("user-%d", n),
+		Username:  fmt.Sprintf("usuario%d", n),
+		Email:     fmt.Sprintf("usuario%d@exemplo.com", n),
+		Password:  "hash",
+		Role:      domain.RoleAuthor,
+		CreatedAt: baseTime.Add(time.Duration(n) * time.Second),
+		UpdatedAt: baseTime.Add(time.Duration(n) * time.Second),
+	}
+}
+
+func newPost(n int, authorID string) *domain.Post {
+	createdAt := baseTime.Add(time.Duration(n) * time.Minute)
+	return &domain.Post{
+		ID:          fmt.Sprintf("post-%d", n),
+		Title:       fmt.Sprintf("Título %d", n),
+		Content:     fmt.Sprintf("Conteúdo %d", n),
+		ContentHTML: fmt.Sprintf("<p>Conteúdo %d</p>", n),
+		Excerpt:     fmt.Sprintf("Conteúdo %d", n),
+		AuthorID:    authorID,
+		Tags:        []string{},
+		Status:      domain.PostStatusPublished,
+		PublishAt:   &createdAt,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+}
+
+func newComment(n int, postID, authorID string) *domain.Comment {
+	return &domain.Comment{
+		ID:        fmt.Sprintf("comment-%d", n),
+		Content:   fmt.Sprintf("Comentário %d", n),
+		PostID:    postID,
+		AuthorID:  authorID,
+		CreatedAt: baseTime.Add(time.Duration(n) * time.Minute),
+		UpdatedAt: baseTime.Add(time.Duration(n) * time.Minute),
+	}
+}
+
+func newToken(n int, familyID string) *domain.RefreshToken {
+	return &domain.RefreshToken{
+		ID:        fmt.Sprintf("token-%d", n),
+		UserID:    "user-1",
+		FamilyID:  familyID,
+		TokenHash: fmt.Sprintf("hash-%d", n),
+		ExpiresAt: baseTime.Add(24 * time.Hour),
+		CreatedAt: baseTime,
+	}
+}
+
+func mustNoErr(t *testing.T, err error, op string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: erro inesperado: %v", op, err)
+	}
+}
+
+func testUserRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Email não diferencia maiúsculas de minúsculas", func(t *testing.T) {
+		repo := newRepos(t).Users
+		user := newUser(1)
+		user.Email = " Usuario1@Exemplo.COM "
+		mustNoErr(t, repo.Create(ctx, user), "Create")
+
+		got, err := repo.GetByEmail(ctx, "usuario1@exemplo.com")
+		mustNoErr(t, err, "GetByEmail")
+		if got.ID != user.ID || got.Email != "usuario1@exemplo.com" {
+			t.Errorf("esperava o email padronizado, obtido %+v", got)
+		}
+		if _, err := repo.GetByEmail(ctx, "USUARIO1@exemplo.com"); err != nil {
+			t.Errorf("busca com maiúsculas deveria encontrar o usuário: %v", err)
+		}
+
+		other := newUser(2)
+		other.Email = "USUARIO1@EXEMPLO.COM"
+		if err := repo.Create(ctx, other); err == nil {
+			t.Error("esperava erro ao cadastrar o mesmo email com outras maiúsculas")
+		}
+		mustNoErr(t, repo.Create(ctx, newUser(2)), "Create")
+		other = newUser(2)
+		other.Email = "Usuario1@exemplo.com"
+		if err := repo.Update(ctx, other); err == nil {
+			t.Error("esperava erro ao atualizar para o email de outro usuário com outras maiúsculas")
+		}
+	})
+
+	t.Run("Create e buscas", func(t *testing.T) {
+		repo := newRepos(t).Users
+		user := newUser(1)
+		mustNoErr(t, repo.Create(ctx, user), "Create")
+
+		lookups := map[string]func() (*domain.User, error){
+			"GetByID":       func() (*domain.User, error) { return repo.GetByID(ctx, user.ID) },
+			"GetByEmail":    func() (*domain.User, error) { return repo.GetByEmail(ctx, user.Email) },
+			"GetByUsername": func() (*domain.User, error) { return repo.GetByUsername(ctx, user.Username) },
+		}
+		for name, lookup := range lookups {
+			got, err := lookup()
+			mustNoErr(t, err, name)
+			if got.ID != user.ID || got.Email != user.Email || got.Username != user.Username || got.Password != user.Password || got.Role != user.Role {
+				t.Errorf("%s: esperado %+v, obtido %+v", name, user, got)
+			}
+			if !got.CreatedAt.Equal(user.CreatedAt) {
+				t.Errorf("%s: CreatedAt esperado %v, obtido %v", name, user.CreatedAt, got.CreatedAt)
+			}
+		}
+	})
+
+	t.Run("Buscas inexistentes", func(t *testing.T) {
+		repo := newRepos(t).Users
+		if _, err := repo.GetByID(ctx, "nao-existe"); err == nil {
+			t.Error("GetByID: esperava erro para usuário inexistente")
+		}
+		if _, err := repo.GetByEmail(ctx, "nao@existe.com"); err == nil {
+			t.Error("GetByEmail: esperava erro para usuário inexistente")
+		}
+		if _, err := repo.GetByUsername(ctx, "naoexiste"); err == nil {
+			t.Error("GetByUsername: esperava erro para usuário inexistente")
+		}
+	})
+
+	t.Run("GetByIDs", func(t *testing.T) {
+		repo := newRepos(t).Users
+		for i := 1; i <= 3; i++ {
+			mustNoErr(t, repo.Create(ctx, newUser(i)), "Create")
+		}
+
+		users, err := repo.GetByIDs(ctx, []string{newUser(3).ID, "nao-existe", newUser(1).ID, newUser(3).ID})
+		mustNoErr(t, err, "GetByIDs")
+		got := make(map[string]string)
+		for _, u := range users {
+			got[u.ID] = u.Username
+		}
+		if len(users) != 2 || got[newUser(1).ID] != newUser(1).Username || got[newUser(3).ID] != newUser(3).Username {
+			t.Errorf("esperava os usuários 1 e 3 sem repetição, obteve %v", got)
+		}
+
+		users, err = repo.GetByIDs(ctx, nil)
+		mustNoErr(t, err, "GetByIDs")
+		if len(users) != 0 {
+			t.Errorf("esperava nenhum usuário para a lista vazia, obteve %d", len(users))
+		}
+	})
+
+	t.Run("Create rejeita email e username duplicados", func(t *testing.T) {
+		repo := newRepos(t).Users
+		mustNoErr(t, repo.Create(ctx, newUser(1)), "Create")
+
+		sameEmail := newUser(2)
+		sameEmail.Email = newUser(1).Email
+		if err := repo.Create(ctx, sameEmail); err == nil {
+			t.Error("esperava erro para email duplicado")
+		}
+
+		sameUsername := newUser(3)
+		sameUsername.Username = newUser(1).Username
+		if err := repo.Create(ctx, sameUsername); err == nil {
+			t.Error("esperava erro para username duplicado")
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepos(t).Users
+		mustNoErr(t, repo.Create(ctx, newUser(1)), "Create")
+		mustNoErr(t, repo.Create(ctx, newUser(2)), "Create")
+
+		user := newUser(1)
+		user.Email = "novo@exemplo.com"
+		user.Role = domain.RoleModerator
+		user.UpdatedAt = baseTime.Add(time.Hour)
+		mustNoErr(t, repo.Update(ctx, user), "Update")
+
+		got, err := repo.GetByEmail(ctx, "novo@exemplo.com")
+		mustNoErr(t, err, "GetByEmail")
+		if got.ID != user.ID || got.Role != user.Role || !got.UpdatedAt.Equal(user.UpdatedAt) {
+			t.Errorf("esperado %+v, obtido %+v", user, got)
+		}
+
+		conflict := newUser(2)
+		conflict.Email = user.Email
+		if err := repo.Update(ctx, conflict); err == nil {
+			t.Error("esperava erro ao atualizar para email de outro usuário")
+		}
+
+		if err := repo.Update(ctx, newUser(99)); err == nil {
+			t.Error("esperava erro ao atualizar usuário inexistente")
+		}
+	})
+
+	t.Run("UpdateRole preserva o último administrador", func(t *testing.T) {
+		repo := newRepos(t).Users
+		for n := 1; n <= 2; n++ {
+			user := newUser(n)
+			user.Role = domain.RoleAdmin
+			mustNoErr(t, repo.Create(ctx, user), "Create")
+		}
+
+		first := newUser(1)
+		first.Role = domain.RoleAuthor
+		first.UpdatedAt = baseTime.Add(time.Hour)
+		mustNoErr(t, repo.UpdateRole(ctx, first), "UpdateRole")
+
+		got, err := repo.GetByID(ctx, first.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Role != domain.RoleAuthor || !got.UpdatedAt.Equal(first.UpdatedAt) || got.Email != first.Email {
+			t.Errorf("esperado papel author, obtido %+v", got)
+		}
+
+		last := newUser(2)
+		last.Role = domain.RoleReader
+		if err := repo.UpdateRole(ctx, last); !errors.Is(err, repositories.ErrLastAdmin) {
+			t.Errorf("esperava ErrLastAdmin, obtido %v", err)
+		}
+		got, err = repo.GetByID(ctx, last.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Role != domain.RoleAdmin {
+			t.Errorf("o último administrador não deveria ser rebaixado, obtido %s", got.Role)
+		}
+
+		if err := repo.UpdateRole(ctx, newUser(99)); err == nil {
+			t.Error("esperava erro ao alterar o papel de usuário inexistente")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepos(t).Users
+		mustNoErr(t, repo.Create(ctx, newUser(1)), "Create")
+		mustNoErr(t, repo.Delete(ctx, newUser(1).ID), "Delete")
+
+		if _, err := repo.GetByID(ctx, newUser(1).ID); err == nil {
+			t.Error("esperava erro ao buscar usuário removido")
+		}
+		if err := repo.Delete(ctx, newUser(1).ID); err == nil {
+			t.Error("esperava erro ao remover usuário inexistente")
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		repo := newRepos(t).Users
+		for i := 1; i <= 5; i++ {
+			mustNoErr(t, repo.Create(ctx, newUser(i)), "Create")
+		}
+
+		got := walkPages(t, "List", 2, func(req domain.PageRequest) (*domain.Page[*domain.User], error) {
+			return repo.List(ctx, req)
+		}, func(u *domain.User) string { return u.ID })
+		if want := "[user-1 user-2] [user-3 user-4] [user-5]"; got != want {
+			t.Errorf("páginas esperadas %s, obtidas %s", want, got)
+		}
+	})
+}
+
+func testPostRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create, GetByID, Update e Delete", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		post := newPost(1, "user-1")
+		mustNoErr(t, repo.Create(ctx, post), "Create")
+
+		got, err := repo.GetByID(ctx, post.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Title != post.Title || got.Content != post.Content || got.AuthorID != post.AuthorID || !got.CreatedAt.Equal(post.CreatedAt) {
+			t.Errorf("esperado %+v, obtido %+v", post, got)
+		}
+		if got.ContentHTML != post.ContentHTML || got.Excerpt != post.Excerpt {
+			t.Errorf("esperava HTML %q e resumo %q, obteve %q e %q", post.ContentHTML, post.Excerpt, got.ContentHTML, got.Excerpt)
+		}
+		if got.Status != domain.PostStatusPublished || got.PublishAt == nil || !got.PublishAt.Equal(*post.PublishAt) {
+			t.Errorf("esperava post publicado em %v, obteve %s em %v", post.PublishAt, got.Status, got.PublishAt)
+		}
+
+		updated := newPost(1, "user-1")
+		updated.Title = "Novo título"
+		updated.Content = "Novo conteúdo"
+		updated.ContentHTML = "<p>Novo conteúdo</p>"
+		updated.Excerpt = "Novo conteúdo"
+		updated.Tags = []string{"go", "web"}
+		updated.Status = domain.PostStatusDraft
+		updated.PublishAt = nil
+		updated.UpdatedAt = baseTime.Add(time.Hour)
+		mustNoErr(t, repo.Update(ctx, updated), "Update")
+
+		got, err = repo.GetByID(ctx, post.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Title != updated.Title || got.Content != updated.Content || got.ContentHTML != updated.ContentHTML || !got.UpdatedAt.Equal(updated.UpdatedAt) {
+			t.Errorf("esperado %+v, obtido %+v", updated, got)
+		}
+		if got.Status != domain.PostStatusDraft || got.PublishAt != nil {
+			t.Errorf("esperava rascunho sem data de publicação, obteve %s em %v", got.Status, got.PublishAt)
+		}
+		if fmt.Sprint(got.Tags) != "[go web]" {
+			t.Errorf("esperava tags [go web], obteve %v", got.Tags)
+		}
+
+		mustNoErr(t, repo.Delete(ctx, post.ID), "Delete")
+		if _, err := repo.GetByID(ctx, post.ID); err == nil {
+			t.Error("esperava erro ao buscar post removido")
+		}
+		if err := repo.Delete(ctx, post.ID); err == nil {
+			t.Error("esperava erro ao remover post inexistente")
+		}
+		if err := repo.Update(ctx, post); err == nil {
+			t.Error("esperava erro ao atualizar post inexistente")
+		}
+	})
+
+	t.Run("Alterações só são gravadas por Update", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		post := newPost(1, "user-1")
+		post.Tags = []string{"go"}
+		mustNoErr(t, repo.Create(ctx, post), "Create")
+
+		// Alterar o post criado, o buscado ou o listado não altera o registro gravado
+		post.Title = "Alterado após Create"
+		got, err := repo.GetByID(ctx, post.ID)
+		mustNoErr(t, err, "GetByID")
+		got.Title = "Alterado após GetByID"
+		got.Tags[0] = "alterada"
+		listed, err := items(repo.List(ctx, domain.PostStatusPublished, firstPage(10)))
+		mustNoErr(t, err, "List")
+		listed[0].Status = domain.PostStatusDraft
+
+		got, err = repo.GetByID(ctx, post.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Title != "Título 1" || fmt.Sprint(got.Tags) != "[go]" || got.Status != domain.PostStatusPublished {
+			t.Errorf("registro alterado sem Update: %+v", got)
+		}
+	})
+
+	t.Run("List ordena do mais recente e pagina", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		for i := 1; i <= 5; i++ {
+			author := "user-1"
+			if i%2 == 0 {
+				author = "user-2"
+			}
+			mustNoErr(t, repo.Create(ctx, newPost(i, author)), "Create")
+		}
+
+		got := walkPages(t, "List", 2, func(req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+			return repo.List(ctx, "", req)
+		}, postID)
+		if want := "[post-5 post-4] [post-3 post-2] [post-1]"; got != want {
+			t.Errorf("List: páginas esperadas %s, obtidas %s", want, got)
+		}
+
+		assertPostIDs(t, "ListByAuthor", func() ([]*domain.Post, error) { return items(repo.ListByAuthor(ctx, "user-1", "", firstPage(10))) }, "post-5", "post-3", "post-1")
+		got = walkPages(t, "ListByAuthor", 1, func(req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+			return repo.ListByAuthor(ctx, "user-2", "", req)
+		}, postID)
+		if want := "[post-4] [post-2]"; got != want {
+			t.Errorf("ListByAuthor: páginas esperadas %s, obtidas %s", want, got)
+		}
+	})
+
+	t.Run("ListPopular ordena pela popularidade no instante do ranking e pagina", func(t *testing.T) {
+		repos := newRepos(t)
+		rankedAt := baseTime.Add(72 * time.Hour)
+		// post-1 é o mais curtido, mas foi publicado há dois dias; os demais, há uma hora
+		ages := map[int]time.Duration{1: 48 * time.Hour}
+		for i := 1; i <= 5; i++ {
+			post := newPost(i, "user-1")
+			age, ok := ages[i]
+			if !ok {
+				age = time.Hour
+			}
+			publishAt := rankedAt.Add(-age)
+			post.PublishAt = &publishAt
+			mustNoErr(t, repos.Posts.Create(ctx, post), "Create")
+		}
+		draft := newPost(6, "user-1")
+		draft.Status, draft.PublishAt = domain.PostStatusDraft, nil
+		mustNoErr(t, repos.Posts.Create(ctx, draft), "Create")
+
+		likes := map[string]int{"post-1": 6, "post-3": 2, "post-5": 2, "post-6": 5}
+		for postID, count := range likes {
+			for u := 1; u <= count; u++ {
+				reaction := &domain.Reaction{UserID: fmt.Sprintf("user-%d", u), TargetType: domain.ReactionTargetPost, TargetID: postID, CreatedAt: baseTime}
+				_, err := repos.Reactions.Add(ctx, reaction)
+				mustNoErr(t, err, "Add")
+			}
+		}
+		// Curtidas em um comentário com o mesmo ID não contam para o post
+		_, err := repos.Reactions.Add(ctx, &domain.Reaction{UserID: "user-1", TargetType: domain.ReactionTargetComment, TargetID: "post-4", CreatedAt: baseTime})
+		mustNoErr(t, err, "Add")
+
+		got := walkPages(t, "ListPopular", 2, func(req domain.PageRequest) (*domain.Page[*domain.Post], error) {
+			if req.After != nil && !req.After.Time.Equal(rankedAt) {
+				t.Errorf("ListPopular: cursor deveria guardar o instante do ranking %v, obteve %v", rankedAt, req.After.Time)
+			}
+			return repos.Posts.ListPopular(ctx, rankedAt, req)
+		}, func(post *domain.Post) string {
+			return fmt.Sprintf("%s:%d", post.ID, post.Likes)
+		})
+		if want := "[post-3:2 post-5:2] [post-1:6 post-2:0] [post-4:0]"; got != want {
+			t.Errorf("ListPopular: páginas esperadas %s, obtidas %s", want, got)
+		}
+
+		// Logo após a publicação de post-1, suas curtidas ainda pesam mais que as dos outros
+		page, err := repos.Posts.ListPopular(ctx, baseTime.Add(24*time.Hour), firstPage(1))
+		mustNoErr(t, err, "ListPopular")
+		if len(page.Items) != 1 || page.Items[0].ID != "post-1" {
+			t.Errorf("ListPopular: esperava post-1 no topo logo após a publicação, obteve %v", page.Items)
+		}
+	})
+
+	t.Run("Delete remove comentários, curtidas e revisões do post", func(t *testing.T) {
+		repos := newRepos(t)
+		like := func(targetType domain.ReactionTarget, targetID string) {
+			_, err := repos.Reactions.Add(ctx, &domain.Reaction{UserID: "user-2", TargetType: targetType, TargetID: targetID, CreatedAt: baseTime})
+			mustNoErr(t, err, "Add")
+		}
+		for i := 1; i <= 2; i++ {
+			post := newPost(i, "user-1")
+			mustNoErr(t, repos.Posts.Create(ctx, post), "Create")
+			mustNoErr(t, repos.Comments.Create(ctx, newComment(i, post.ID, "user-2")), "Create")
+			mustNoErr(t, repos.Revisions.Create(ctx, &domain.PostRevision{
+				ID: fmt.Sprintf("revision-%d", i), PostID: post.ID, Version: 1, Title: post.Title, Content: post.Content,
+				Tags: []string{}, EditorID: "user-1", CreatedAt: baseTime,
+			}), "Create")
+			like(domain.ReactionTargetPost, post.ID)
+			like(domain.ReactionTargetComment, fmt.Sprintf("comment-%d", i))
+		}
+
+		mustNoErr(t, repos.Posts.Delete(ctx, "post-1"), "Delete")
+
+		assertCommentIDs(t, "ListByPost", func() ([]*domain.Comment, error) { return items(repos.Comments.ListByPost(ctx, "post-1", firstPage(10))) })
+		assertCommentIDs(t, "ListByAuthor", func() ([]*domain.Comment, error) { return items(repos.Comments.ListByAuthor(ctx, "user-2", firstPage(10))) }, "comment-2")
+		if _, err := repos.Comments.GetByID(ctx, "comment-1"); err == nil {
+			t.Error("esperava o comentário do post removido também removido")
+		}
+
+		postLikes, err := repos.Reactions.CountByTargets(ctx, domain.ReactionTargetPost, []string{"post-1", "post-2"})
+		mustNoErr(t, err, "CountByTargets")
+		commentLikes, err := repos.Reactions.CountByTargets(ctx, domain.ReactionTargetComment, []string{"comment-1", "comment-2"})
+		mustNoErr(t, err, "CountByTargets")
+		if fmt.Sprint(postLikes, commentLikes) != "map[post-2:1] map[comment-2:1]" {
+			t.Errorf("esperava apenas as curtidas do post 2 e do seu comentário, obtido %v %v", postLikes, commentLikes)
+		}
+		// Curtir de novo conta a partir de zero, sem reações antigas
+		like(domain.ReactionTargetPost, "post-1")
+		postLikes, err = repos.Reactions.CountByTargets(ctx, domain.ReactionTargetPost, []string{"post-1"})
+		mustNoErr(t, err, "CountByTargets")
+		if postLikes["post-1"] != 1 {
+			t.Errorf("esperava 1 curtida nova, obtido %v", postLikes)
+		}
+
+		revisions, err := repos.Revisions.ListByPost(ctx, "post-1", 1, 10)
+		mustNoErr(t, err, "ListByPost")
+		if len(revisions) != 0 {
+			t.Errorf("esperava o histórico do post removido apagado, obtido %d revisões", len(revisions))
+		}
+		if _, err := repos.Revisions.GetLatest(ctx, "post-2"); err != nil {
+			t.Errorf("o histórico de outro post não deveria ser apagado: %v", err)
+		}
+	})
+
+	t.Run("Cursor não pula nem repete posts criados durante a paginação", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		for i := 2; i <= 5; i++ {
+			mustNoErr(t, repo.Create(ctx, newPost(i, "user-1")), "Create")
+		}
+
+		page, err := repo.List(ctx, "", firstPage(2))
+		mustNoErr(t, err, "List")
+		if !page.HasMore || page.Total != nil {
+			t.Errorf("esperava mais páginas e nenhum total sem solicitá-lo, obteve %+v", page)
+		}
+
+		// Um post mais novo e outro mais antigo que o cursor surgem entre as páginas
+		mustNoErr(t, repo.Create(ctx, newPost(6, "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newPost(1, "user-1")), "Create")
+
+		after, err := domain.DecodeCursor(page.NextCursor)
+		mustNoErr(t, err, "DecodeCursor")
+		assertPostIDs(t, "List após o cursor", func() ([]*domain.Post, error) {
+			return items(repo.List(ctx, "", domain.PageRequest{After: after, Limit: 10}))
+		}, "post-3", "post-2", "post-1")
+	})
+
+	t.Run("Filtros por status e agendamento", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		statuses := []domain.PostStatus{
+			domain.PostStatusPublished, domain.PostStatusDraft, domain.PostStatusScheduled,
+			domain.PostStatusScheduled, domain.PostStatusArchived,
+		}
+		for i, status := range statuses {
+			post := newPost(i+1, "user-1")
+			post.Status = status
+			switch status {
+			case domain.PostStatusDraft:
+				post.PublishAt = nil
+			case domain.PostStatusScheduled:
+				publishAt := baseTime.Add(time.Duration(i) * time.Hour)
+				post.PublishAt = &publishAt
+			}
+			mustNoErr(t, repo.Create(ctx, post), "Create")
+		}
+
+		assertPostIDs(t, "List publicados", func() ([]*domain.Post, error) {
+			return items(repo.List(ctx, domain.PostStatusPublished, firstPage(10)))
+		}, "post-1")
+		assertPostIDs(t, "List agendados", func() ([]*domain.Post, error) {
+			return items(repo.List(ctx, domain.PostStatusScheduled, firstPage(10)))
+		}, "post-4", "post-3")
+		assertPostIDs(t, "ListByAuthor rascunhos", func() ([]*domain.Post, error) {
+			return items(repo.ListByAuthor(ctx, "user-1", domain.PostStatusDraft, firstPage(10)))
+		}, "post-2")
+		assertPostIDs(t, "ListByAuthor todos", func() ([]*domain.Post, error) { return items(repo.ListByAuthor(ctx, "user-1", "", firstPage(10))) },
+			"post-5", "post-4", "post-3", "post-2", "post-1")
+
+		// post-3 vence em baseTime+2h e post-4 em baseTime+3h
+		assertPostIDs(t, "ListScheduledDue antes de vencer", func() ([]*domain.Post, error) {
+			return repo.ListScheduledDue(ctx, baseTime.Add(time.Hour))
+		})
+		assertPostIDs(t, "ListScheduledDue no vencimento", func() ([]*domain.Post, error) {
+			return repo.ListScheduledDue(ctx, baseTime.Add(2*time.Hour))
+		}, "post-3")
+		assertPostIDs(t, "ListScheduledDue depois de vencer", func() ([]*domain.Post, error) {
+			return repo.ListScheduledDue(ctx, baseTime.Add(24*time.Hour))
+		}, "post-3", "post-4")
+	})
+
+	t.Run("ListPublishedEntries", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		for i := 1; i <= 4; i++ {
+			post := newPost(i, "user-1")
+			if i == 2 {
+				post.Status, post.PublishAt = domain.PostStatusDraft, nil
+			}
+			mustNoErr(t, repo.Create(ctx, post), "Create")
+		}
+		edited := newPost(1, "user-1")
+		edited.UpdatedAt = baseTime.Add(time.Hour)
+		mustNoErr(t, repo.Update(ctx, edited), "Update")
+
+		entries, err := repo.ListPublishedEntries(ctx, 10)
+		mustNoErr(t, err, "ListPublishedEntries")
+		want := []domain.PostEntry{
+			{ID: "post-4", UpdatedAt: baseTime.Add(4 * time.Minute)},
+			{ID: "post-3", UpdatedAt: baseTime.Add(3 * time.Minute)},
+			{ID: "post-1", UpdatedAt: baseTime.Add(time.Hour)},
+		}
+		if len(entries) != len(want) {
+			t.Fatalf("esperava %d posts publicados, obteve %+v", len(want), entries)
+		}
+		for i, entry := range entries {
+			if entry.ID != want[i].ID || !entry.UpdatedAt.Equal(want[i].UpdatedAt) {
+				t.Errorf("posição %d: esperava %+v, obteve %+v", i, want[i], entry)
+			}
+		}
+
+		entries, err = repo.ListPublishedEntries(ctx, 2)
+		mustNoErr(t, err, "ListPublishedEntries")
+		if len(entries) != 2 || entries[1].ID != "post-3" {
+			t.Errorf("esperava os 2 posts mais recentes, obteve %+v", entries)
+		}
+	})
+
+	t.Run("ReassignAuthor", func(t *testing.T) {
+		repo := newRepos(t).Posts
+		mustNoErr(t, repo.Create(ctx, newPost(1, "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newPost(2, "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newPost(3, "user-2")), "Create")
+
+		mustNoErr(t, repo.ReassignAuthor(ctx, "user-1", domain.DeletedUserID), "ReassignAuthor")
+
+		assertPostIDs(t, "ListByAuthor(removido)", func() ([]*domain.Post, error) {
+			return items(repo.ListByAuthor(ctx, domain.DeletedUserID, "", firstPage(10)))
+		}, "post-2", "post-1")
+		assertPostIDs(t, "ListByAuthor(user-1)", func() ([]*domain.Post, error) {
+			return items(repo.ListByAuthor(ctx, "user-1", "", firstPage(10)))
+		})
+		assertPostIDs(t, "ListByAuthor(user-2)", func() ([]*domain.Post, error) {
+			return items(repo.ListByAuthor(ctx, "user-2", "", firstPage(10)))
+		}, "post-3")
+	})
+
+	t.Run("Search", func(t *testing.T) { testPostSearch(t, newRepos) })
+}
+
+func testPostSearch(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+	repo := newRepos(t).Posts
+
+	fixtures := []struct {
+		title, content string
+		tags           []string
+	}{
+		{"Introdução ao Go", "Go é uma linguagem compilada.", []string{"go"}},
+		{"Receitas de bolo", "Bolo de cenoura com cobertura de chocolate.", []string{"culinaria"}},
+		{"Concorrência", "Goroutines e canais tornam a programação concorrente em Go simples. Go, Go, Go, Go!", []string{"go", "concorrencia"}},
+		{"Programação web", "APIs HTTP com a biblioteca padrão.", []string{"go", "web"}},
+	}
+	for i, f := range fixtures {
+		post := newPost(i+1, "user-1")
+		post.Title, post.Content, post.Tags = f.title, f.content, f.tags
+		mustNoErr(t, repo.Create(ctx, post), "Create")
+	}
+
+	search := func(query string, tags []string) func() ([]*domain.Post, error) {
+		return func() ([]*domain.Post, error) {
+			page, err := repo.Search(ctx, query, tags, firstPage(10))
+			if err != nil {
+				return nil, err
+			}
+			posts := make([]*domain.Post, len(page.Items))
+			for i, r := range page.Items {
+				posts[i] = r.Post
+			}
+			return posts, nil
+		}
+	}
+
+	assertPostIDs(t, "termo mais frequente primeiro", search("go", nil), "post-3", "post-1")
+	assertPostIDs(t, "acentos e maiúsculas ignorados", search("PROGRAMACAO", nil), "post-4", "post-3")
+	assertPostIDs(t, "termos combinados", search("bolo chocolate", nil), "post-2")
+	assertPostIDs(t, "termo com tag", search("go", []string{"concorrencia"}), "post-3")
+	assertPostIDs(t, "apenas tags", search("", []string{"go"}), "post-4", "post-3", "post-1")
+	assertPostIDs(t, "todas as tags exigidas", search("", []string{"GO", "web"}), "post-4")
+	assertPostIDs(t, "sem resultados", search("python", nil))
+
+	for _, tc := range []struct {
+		name, query string
+		tags        []string
+		want        string
+	}{
+		{"paginação por tags", "", []string{"go"}, "[post-4 post-3] [post-1]"},
+		{"paginação por relevância", "programacao linguagem", nil, "[post-4 post-1] [post-3]"},
+	} {
+		got := walkPages(t, tc.name, 2, func(req domain.PageRequest) (*domain.Page[*domain.PostSearchResult], error) {
+			return repo.Search(ctx, tc.query, tc.tags, req)
+		}, func(r *domain.PostSearchResult) string { return r.Post.ID })
+		if got != tc.want {
+			t.Errorf("%s: páginas esperadas %s, obtidas %s", tc.name, tc.want, got)
+		}
+	}
+
+	page, err := repo.Search(ctx, "go", nil, firstPage(10))
+	mustNoErr(t, err, "Search")
+	results := page.Items
+	if len(results) == 2 && !(results[0].Score > results[1].Score && results[1].Score > 0) {
+		t.Errorf("esperava relevâncias positivas e decrescentes, obteve %v e %v", results[0].Score, results[1].Score)
+	}
+
+	// Alterações e remoções refletem na busca
+	updated := newPost(2, "user-1")
+	updated.Title, updated.Content, updated.Tags = "Receitas em Go", "Bolo de fubá.", []string{"culinaria"}
+	mustNoErr(t, repo.Update(ctx, updated), "Update")
+	assertPostIDs(t, "após update", search("chocolate", nil))
+	assertPostIDs(t, "após update com novo termo", search("fuba", nil), "post-2")
+
+	mustNoErr(t, repo.Delete(ctx, "post-3"), "Delete")
+	assertPostIDs(t, "após delete", search("goroutines", nil))
+
+	// Posts não publicados ficam fora da busca
+	draft := newPost(4, "user-1")
+	draft.Title, draft.Content, draft.Tags = fixtures[3].title, fixtures[3].content, fixtures[3].tags
+	draft.Status, draft.PublishAt = domain.PostStatusDraft, nil
+	mustNoErr(t, repo.Update(ctx, draft), "Update")
+	assertPostIDs(t, "rascunho fora da busca por termo", search("http", nil))
+	assertPostIDs(t, "rascunho fora da busca por tag", search("", []string{"web"}))
+}
+
+func testCommentRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create, GetByID, Update e Delete", func(t *testing.T) {
+		repo := newRepos(t).Comments
+		comment := newComment(1, "post-1", "user-1")
+		mustNoErr(t, repo.Create(ctx, comment), "Create")
+
+		got, err := repo.GetByID(ctx, comment.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Content != comment.Content || got.PostID != comment.PostID || got.AuthorID != comment.AuthorID || !got.CreatedAt.Equal(comment.CreatedAt) {
+			t.Errorf("esperado %+v, obtido %+v", comment, got)
+		}
+
+		updated := newComment(1, "post-1", "user-1")
+		updated.Tombstone()
+		updated.UpdatedAt = baseTime.Add(time.Hour)
+		mustNoErr(t, repo.Update(ctx, updated), "Update")
+
+		got, err = repo.GetByID(ctx, comment.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Content != "" || !got.Deleted || !got.UpdatedAt.Equal(updated.UpdatedAt) {
+			t.Errorf("esperado %+v, obtido %+v", updated, got)
+		}
+
+		mustNoErr(t, repo.Delete(ctx, comment.ID), "Delete")
+		if _, err := repo.GetByID(ctx, comment.ID); err == nil {
+			t.Error("esperava erro ao buscar comentário removido")
+		}
+		if err := repo.Delete(ctx, comment.ID); err == nil {
+			t.Error("esperava erro ao remover comentário inexistente")
+		}
+		if err := repo.Update(ctx, comment); err == nil {
+			t.Error("esperava erro ao atualizar comentário inexistente")
+		}
+	})
+
+	t.Run("Alterações só são gravadas por Update", func(t *testing.T) {
+		repo := newRepos(t).Comments
+		comment := newComment(1, "post-1", "user-1")
+		mustNoErr(t, repo.Create(ctx, comment), "Create")
+
+		got, err := repo.GetByID(ctx, comment.ID)
+		mustNoErr(t, err, "GetByID")
+		got.Tombstone()
+		listed, err := items(repo.ListByPost(ctx, "post-1", firstPage(10)))
+		mustNoErr(t, err, "ListByPost")
+		listed[0].AuthorID = "user-2"
+
+		got, err = repo.GetByID(ctx, comment.ID)
+		mustNoErr(t, err, "GetByID")
+		if got.Deleted || got.Content != comment.Content || got.AuthorID != "user-1" {
+			t.Errorf("registro alterado sem Update: %+v", got)
+		}
+	})
+
+	t.Run("ListByPost e ListByAuthor em ordem cronológica", func(t *testing.T) {
+		repo := newRepos(t).Comments
+		mustNoErr(t, repo.Create(ctx, newComment(3, "post-1", "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newComment(1, "post-1", "user-2")), "Create")
+		mustNoErr(t, repo.Create(ctx, newComment(2, "post-2", "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newComment(4, "post-1", "user-1")), "Create")
+
+		assertCommentIDs(t, "ListByPost", func() ([]*domain.Comment, error) { return items(repo.ListByPost(ctx, "post-1", firstPage(10))) }, "comment-1", "comment-3", "comment-4")
+		got := walkPages(t, "ListByPost", 2, func(req domain.PageRequest) (*domain.Page[*domain.Comment], error) {
+			return repo.ListByPost(ctx, "post-1", req)
+		}, func(c *domain.Comment) string { return c.ID })
+		if want := "[comment-1 comment-3] [comment-4]"; got != want {
+			t.Errorf("ListByPost: páginas esperadas %s, obtidas %s", want, got)
+		}
+		assertCommentIDs(t, "ListByPost sem comentários", func() ([]*domain.Comment, error) { return items(repo.ListByPost(ctx, "post-3", firstPage(10))) })
+		assertCommentIDs(t, "ListByAuthor", func() ([]*domain.Comment, error) { return items(repo.ListByAuthor(ctx, "user-1", firstPage(10))) }, "comment-2", "comment-3", "comment-4")
+	})
+
+	t.Run("ReassignAuthor", func(t *testing.T) {
+		repo := newRepos(t).Comments
+		mustNoErr(t, repo.Create(ctx, newComment(1, "post-1", "user-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newComment(2, "post-2", "user-2")), "Create")
+		mustNoErr(t, repo.Create(ctx, newComment(3, "post-1", "user-1")), "Create")
+
+		mustNoErr(t, repo.ReassignAuthor(ctx, "user-1", domain.DeletedUserID), "ReassignAuthor")
+
+		assertCommentIDs(t, "ListByAuthor(removido)", func() ([]*domain.Comment, error) {
+			return items(repo.ListByAuthor(ctx, domain.DeletedUserID, firstPage(10)))
+		}, "comment-1", "comment-3")
+		assertCommentIDs(t, "ListByAuthor(user-1)", func() ([]*domain.Comment, error) {
+			return items(repo.ListByAuthor(ctx, "user-1", firstPage(10)))
+		})
+
+		got, err := repo.GetByID(ctx, "comment-2")
+		mustNoErr(t, err, "GetByID")
+		if got.AuthorID != "user-2" {
+			t.Errorf("comentários de outros autores não deveriam mudar: %+v", got)
+		}
+	})
+
+	t.Run("ListThread", func(t *testing.T) {
+		repo := newRepos(t).Comments
+
+		// comment-1
+		// ├── comment-2
+		// │   └── comment-4
+		// │       └── comment-7
+		// ├── comment-3
+		// └── comment-5
+		// comment-6
+		parents := map[int]int{2: 1, 3: 1, 4: 2, 5: 1, 7: 4}
+		created := make(map[int]*domain.Comment)
+		for i := 1; i <= 7; i++ {
+			comment := newComment(i, "post-1", "user-1")
+			if parent, ok := parents[i]; ok {
+				mustNoErr(t, comment.SetParent(created[parent]), "SetParent")
+			}
+			created[i] = comment
+			mustNoErr(t, repo.Create(ctx, comment), "Create")
+		}
+		mustNoErr(t, repo.Create(ctx, newComment(8, "post-2", "user-1")), "Create")
+
+		got, err := repo.GetByID(ctx, "comment-7")
+		mustNoErr(t, err, "GetByID")
+		if got.ParentID != "comment-4" || got.Depth != 3 {
+			t.Errorf("esperava resposta a comment-4 com profundidade 3, obteve %q e %d", got.ParentID, got.Depth)
+		}
+
+		threads, err := repo.ListThread(ctx, "post-1", "", 1, firstPage(2))
+		mustNoErr(t, err, "ListThread")
+		if got := describeThreads(threads.Items); got != "[comment-1(3)[comment-2(1)[] comment-3(0)[]] comment-6(0)[]]" || threads.HasMore {
+			t.Errorf("árvore inesperada: %s (has_more %v)", got, threads.HasMore)
+		}
+
+		threads, err = repo.ListThread(ctx, "post-1", "comment-1", 5, domain.PageRequest{Limit: 2, WithTotal: true})
+		mustNoErr(t, err, "ListThread")
+		if !threads.HasMore || threads.Total == nil || *threads.Total != 3 {
+			t.Errorf("esperava mais respostas e total 3, obteve has_more %v e total %v", threads.HasMore, threads.Total)
+		}
+		after, err := domain.DecodeCursor(threads.NextCursor)
+		mustNoErr(t, err, "DecodeCursor")
+		threads, err = repo.ListThread(ctx, "post-1", "comment-1", 5, domain.PageRequest{After: after, Limit: 2})
+		mustNoErr(t, err, "ListThread")
+		if got := describeThreads(threads.Items); got != "[comment-5(0)[]]" {
+			t.Errorf("segunda página de respostas inesperada: %s", got)
+		}
+
+		threads, err = repo.ListThread(ctx, "post-1", "comment-2", 5, firstPage(10))
+		mustNoErr(t, err, "ListThread")
+		if got := describeThreads(threads.Items); got != "[comment-4(1)[comment-7(0)[]]]" {
+			t.Errorf("subárvore inesperada: %s", got)
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		repo := newRepos(t).Comments
+		parent := newComment(1, "post-1", "user-1")
+		reply := newComment(2, "post-1", "user-2")
+		mustNoErr(t, reply.SetParent(parent), "SetParent")
+		for _, comment := range []*domain.Comment{parent, reply, newComment(3, "post-1", "user-1")} {
+			mustNoErr(t, repo.Create(ctx, comment), "Create")
+		}
+
+		// Com respostas, o comentário vira lápide
+		mustNoErr(t, repo.Remove(ctx, "comment-1", baseTime.Add(time.Hour)), "Remove")
+		got, err := repo.GetByID(ctx, "comment-1")
+		mustNoErr(t, err, "GetByID")
+		if !got.Deleted || got.Content != "" || !got.UpdatedAt.Equal(baseTime.Add(time.Hour)) {
+			t.Errorf("esperava o comentário com respostas mantido como lápide, obteve %+v", got)
+		}
+
+		// Sem respostas, é apagado
+		for _, id := range []string{"comment-2", "comment-3"} {
+			mustNoErr(t, repo.Remove(ctx, id, baseTime.Add(time.Hour)), "Remove")
+			if _, err := repo.GetByID(ctx, id); err == nil {
+				t.Errorf("esperava o comentário %s sem respostas apagado", id)
+			}
+		}
+
+		if err := repo.Remove(ctx, "inexistente", baseTime); err == nil {
+			t.Error("esperava erro ao remover comentário inexistente")
+		}
+	})
+}
+
+// describeThreads descreve a árvore como id(total de respostas)[respostas]
+func describeThreads(threads []*domain.CommentThread) string {
+	parts := make([]string, len(threads))
+	for i, thread := range threads {
+		parts[i] = fmt.Sprintf("%s(%d)%s", thread.ID, thread.ReplyCount, describeThreads(thread.Replies))
+	}
+	return fmt.Sprint(parts)
+}
+
+func testTokenRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create e GetByHash", func(t *testing.T) {
+		repo := newRepos(t).Tokens
+		token := newToken(1, "family-1")
+		mustNoErr(t, repo.Create(ctx, token), "Create")
+
+		got, err := repo.GetByHash(ctx, token.TokenHash)
+		mustNoErr(t, err, "GetByHash")
+		if got.ID != token.ID || got.UserID != token.UserID || got.FamilyID != token.FamilyID || !got.ExpiresAt.Equal(token.ExpiresAt) {
+			t.Errorf("esperado %+v, obtido %+v", token, got)
+		}
+		if got.IsRevoked() {
+			t.Error("token recém-criado não deveria estar revogado")
+		}
+
+		if _, err := repo.GetByHash(ctx, "hash-inexistente"); err == nil {
+			t.Error("esperava erro para hash inexistente")
+		}
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		repo := newRepos(t).Tokens
+		mustNoErr(t, repo.Create(ctx, newToken(1, "family-1")), "Create")
+		mustNoErr(t, repo.Revoke(ctx, "token-1", "token-2"), "Revoke")
+
+		got, err := repo.GetByHash(ctx, "hash-1")
+		mustNoErr(t, err, "GetByHash")
+		if !got.IsRevoked() || got.ReplacedBy != "token-2" {
+			t.Errorf("esperava token revogado e substituído por token-2, obtido %+v", got)
+		}
+
+		if err := repo.Revoke(ctx, "token-1", "token-3"); !errors.Is(err, repositories.ErrTokenAlreadyRevoked) {
+			t.Errorf("esperava ErrTokenAlreadyRevoked, obtido %v", err)
+		}
+		if err := repo.Revoke(ctx, "token-inexistente", ""); err == nil || errors.Is(err, repositories.ErrTokenAlreadyRevoked) {
+			t.Errorf("esperava erro de token inexistente, obtido %v", err)
+		}
+	})
+
+	t.Run("RevokeFamily e IsFamilyActive", func(t *testing.T) {
+		repo := newRepos(t).Tokens
+		mustNoErr(t, repo.Create(ctx, newToken(1, "family-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newToken(2, "family-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newToken(3, "family-2")), "Create")
+
+		assertFamilyActive(t, repo, "family-1", true)
+		mustNoErr(t, repo.Revoke(ctx, "token-1", "token-2"), "Revoke")
+		assertFamilyActive(t, repo, "family-1", true)
+
+		mustNoErr(t, repo.RevokeFamily(ctx, "family-1"), "RevokeFamily")
+		assertFamilyActive(t, repo, "family-1", false)
+		assertFamilyActive(t, repo, "family-2", true)
+		assertFamilyActive(t, repo, "family-inexistente", false)
+	})
+
+	t.Run("RevokeByUser", func(t *testing.T) {
+		repo := newRepos(t).Tokens
+		mustNoErr(t, repo.Create(ctx, newToken(1, "family-1")), "Create")
+		mustNoErr(t, repo.Create(ctx, newToken(2, "family-2")), "Create")
+		other := newToken(3, "family-3")
+		other.UserID = "user-2"
+		mustNoErr(t, repo.Create(ctx, other), "Create")
+
+		mustNoErr(t, repo.RevokeByUser(ctx, "user-1"), "RevokeByUser")
+		assertFamilyActive(t, repo, "family-1", false)
+		assertFamilyActive(t, repo, "family-2", false)
+		assertFamilyActive(t, repo, "family-3", true)
+	})
+}
+
+func testAuditRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("ReassignUser anonimiza autor e alvo", func(t *testing.T) {
+		repo := newRepos(t).Audit
+		for i, entry := range []*domain.AuditEntry{
+			{ActorID: "user-1", Action: domain.AuditActionDeletePost, TargetType: "post", TargetID: "post-1"},
+			{ActorID: "user-2", Action: domain.AuditActionChangeRole, TargetType: "user", TargetID: "user-1"},
+			{ActorID: "user-2", Action: domain.AuditActionDeleteComment, TargetType: "comment", TargetID: "user-1"},
+		} {
+			entry.ID = fmt.Sprintf("audit-%d", i+1)
+			entry.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			mustNoErr(t, repo.Create(ctx, entry), "Create")
+		}
+
+		mustNoErr(t, repo.ReassignUser(ctx, "user-1", domain.DeletedUserID), "ReassignUser")
+
+		entries, err := repo.List(ctx, 1, 10)
+		mustNoErr(t, err, "List")
+		got := make([]string, len(entries))
+		for i, entry := range entries {
+			got[i] = entry.ActorID + ">" + entry.TargetID
+		}
+		want := []string{"user-2>user-1", "user-2>" + domain.DeletedUserID, domain.DeletedUserID + ">post-1"}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("esperava %v, obtido %v", want, got)
+		}
+	})
+
+	t.Run("Create e List do mais recente", func(t *testing.T) {
+		repo := newRepos(t).Audit
+		for i := 1; i <= 3; i++ {
+			entry := &domain.AuditEntry{
+				ID:         fmt.Sprintf("audit-%d", i),
+				ActorID:    "user-1",
+				Action:     domain.AuditActionDeletePost,
+				TargetType: "post",
+				TargetID:   fmt.Sprintf("post-%d", i),
+				Details:    "spam",
+				CreatedAt:  baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			mustNoErr(t, repo.Create(ctx, entry), "Create")
+		}
+
+		entries, err := repo.List(ctx, 1, 2)
+		mustNoErr(t, err, "List")
+		if len(entries) != 2 || entries[0].ID != "audit-3" || entries[1].ID != "audit-2" {
+			t.Fatalf("esperava audit-3 e audit-2, obteve %+v", entries)
+		}
+		if entries[0].ActorID != "user-1" || entries[0].TargetID != "post-3" || entries[0].Details != "spam" {
+			t.Errorf("campos não preservados: %+v", entries[0])
+		}
+
+		entries, err = repo.List(ctx, 2, 2)
+		mustNoErr(t, err, "List")
+		if len(entries) != 1 || entries[0].ID != "audit-1" {
+			t.Errorf("esperava audit-1 na página 2, obteve %+v", entries)
+		}
+	})
+}
+
+func testRevisionRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Create, buscas e List da mais recente", func(t *testing.T) {
+		repo := newRepos(t).Revisions
+		if _, err := repo.GetLatest(ctx, "post-1"); err == nil {
+			t.Error("esperava erro ao buscar revisão de post sem histórico")
+		}
+
+		for i := 1; i <= 3; i++ {
+			revision := &domain.PostRevision{
+				ID:        fmt.Sprintf("revision-%d", i),
+				PostID:    "post-1",
+				Version:   i,
+				Title:     fmt.Sprintf("Título %d", i),
+				Content:   fmt.Sprintf("Linha 1\nLinha %d", i),
+				Tags:      []string{"go", fmt.Sprintf("tag%d", i)},
+				EditorID:  "user-1",
+				CreatedAt: baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			mustNoErr(t, repo.Create(ctx, revision), "Create")
+		}
+		mustNoErr(t, repo.Create(ctx, &domain.PostRevision{ID: "revision-other", PostID: "post-2", Version: 1, Title: "Outro", Content: "Outro", Tags: []string{}, EditorID: "user-2", CreatedAt: baseTime}), "Create")
+
+		duplicate := &domain.PostRevision{ID: "revision-dup", PostID: "post-1", Version: 2, Title: "x", Content: "x", Tags: []string{}, EditorID: "user-1", CreatedAt: baseTime}
+		if err := repo.Create(ctx, duplicate); err == nil {
+			t.Error("esperava erro ao criar versão repetida")
+		}
+
+		latest, err := repo.GetLatest(ctx, "post-1")
+		mustNoErr(t, err, "GetLatest")
+		if latest.Version != 3 || latest.ID != "revision-3" {
+			t.Errorf("esperava a versão 3, obteve %+v", latest)
+		}
+
+		got, err := repo.GetByVersion(ctx, "post-1", 2)
+		mustNoErr(t, err, "GetByVersion")
+		if got.Title != "Título 2" || got.Content != "Linha 1\nLinha 2" || fmt.Sprint(got.Tags) != "[go tag2]" ||
+			got.EditorID != "user-1" || !got.CreatedAt.Equal(baseTime.Add(2*time.Minute)) {
+			t.Errorf("campos não preservados: %+v", got)
+		}
+		if _, err := repo.GetByVersion(ctx, "post-1", 4); err == nil {
+			t.Error("esperava erro ao buscar versão inexistente")
+		}
+
+		revisions, err := repo.ListByPost(ctx, "post-1", 1, 2)
+		mustNoErr(t, err, "ListByPost")
+		if len(revisions) != 2 || revisions[0].Version != 3 || revisions[1].Version != 2 {
+			t.Fatalf("esperava as versões 3 e 2, obteve %+v", revisions)
+		}
+		revisions, err = repo.ListByPost(ctx, "post-1", 2, 2)
+		mustNoErr(t, err, "ListByPost")
+		if len(revisions) != 1 || revisions[0].Version != 1 {
+			t.Errorf("esperava a versão 1 na página 2, obteve %+v", revisions)
+		}
+
+		mustNoErr(t, repo.ReassignEditor(ctx, "user-1", domain.DeletedUserID), "ReassignEditor")
+		got, err = repo.GetByVersion(ctx, "post-1", 3)
+		mustNoErr(t, err, "GetByVersion")
+		if got.EditorID != domain.DeletedUserID {
+			t.Errorf("esperava o editor reatribuído, obteve %s", got.EditorID)
+		}
+		other, err := repo.GetLatest(ctx, "post-2")
+		mustNoErr(t, err, "GetLatest")
+		if other.EditorID != "user-2" {
+			t.Errorf("revisões de outros editores não deveriam mudar, obteve %s", other.EditorID)
+		}
+	})
+}
+
+func testOutboxRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("Eventos gravados junto com posts e comentários", func(t *testing.T) {
+		repos := newRepos(t)
+		event := func(id string, eventType domain.EventType) *domain.Event {
+			return &domain.Event{ID: id, Type: eventType, PostID: "post-1", OccurredAt: baseTime}
+		}
+
+		post := newPost(1, "user-1")
+		mustNoErr(t, repos.Posts.Create(ctx, post, event("event-1", domain.EventPostPublished)), "Create")
+		mustNoErr(t, repos.Posts.Update(ctx, post, event("event-2", domain.EventPostPublished)), "Update")
+		mustNoErr(t, repos.Comments.Create(ctx, newComment(1, post.ID, "user-2"),
+			event("event-3", domain.EventCommentAdded), event("event-4", domain.EventCommentReplied)), "Create")
+
+		// Uma gravação que falha não deixa eventos na caixa de saída
+		if err := repos.Posts.Update(ctx, newPost(99, "user-1"), event("event-5", domain.EventPostPublished)); err == nil {
+			t.Error("esperava erro ao atualizar post inexistente")
+		}
+
+		pending, err := repos.Outbox.ListPending(ctx, 5, 10)
+		mustNoErr(t, err, "ListPending")
+		ids := make([]string, len(pending))
+		for i, event := range pending {
+			ids[i] = event.ID
+		}
+		sort.Strings(ids)
+		if strings.Join(ids, ",") != "event-1,event-2,event-3,event-4" {
+			t.Errorf("esperava os eventos 1 a 4 pendentes, obtido %v", ids)
+		}
+	})
+
+	t.Run("ListPending, MarkDelivered e MarkFailed", func(t *testing.T) {
+		repos := newRepos(t)
+		repo := repos.Outbox
+		post := newPost(1, "user-1")
+		mustNoErr(t, repos.Posts.Create(ctx, post), "Create")
+		for i := 1; i <= 3; i++ {
+			event := &domain.Event{
+				ID:         fmt.Sprintf("event-%d", i),
+				Type:       domain.EventCommentReplied,
+				ActorID:    "user-2",
+				PostID:     "post-1",
+				CommentID:  fmt.Sprintf("comment-%d", i),
+				ParentID:   "comment-0",
+				OccurredAt: baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			mustNoErr(t, repos.Comments.Create(ctx, newComment(i, post.ID, "user-2"), event), "Create")
+		}
+
+		pending, err := repo.ListPending(ctx, 2, 10)
+		mustNoErr(t, err, "ListPending")
+		if len(pending) != 3 || pending[0].ID != "event-1" || pending[2].ID != "event-3" {
+			t.Fatalf("esperava os 3 eventos do mais antigo para o mais recente, obteve %+v", pending)
+		}
+		got := pending[0]
+		if got.Type != domain.EventCommentReplied || got.ActorID != "user-2" || got.PostID != "post-1" ||
+			got.CommentID != "comment-1" || got.ParentID != "comment-0" || !got.OccurredAt.Equal(baseTime.Add(time.Minute)) {
+			t.Errorf("campos não preservados: %+v", got)
+		}
+
+		mustNoErr(t, repo.MarkDelivered(ctx, "event-1", baseTime.Add(time.Hour)), "MarkDelivered")
+		mustNoErr(t, repo.MarkFailed(ctx, "event-2", "falha"), "MarkFailed")
+		mustNoErr(t, repo.MarkFailed(ctx, "event-2", "falha de novo"), "MarkFailed")
+		if err := repo.MarkDelivered(ctx, "inexistente", baseTime); err == nil {
+			t.Error("esperava erro ao marcar evento inexistente")
+		}
+
+		pending, err = repo.ListPending(ctx, 2, 10)
+		mustNoErr(t, err, "ListPending")
+		if len(pending) != 1 || pending[0].ID != "event-3" {
+			t.Errorf("esperava apenas o evento 3 pendente, obteve %+v", pending)
+		}
+
+		pending, err = repo.ListPending(ctx, 5, 1)
+		mustNoErr(t, err, "ListPending")
+		if len(pending) != 1 || pending[0].ID != "event-2" {
+			t.Errorf("esperava o evento 2 com o limite de 1, obteve %+v", pending)
+		}
+	})
+}
+
+func testNotificationRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("DeleteByUser remove as notificações recebidas e causadas", func(t *testing.T) {
+		repo := newRepos(t).Notifications
+		for i, pair := range [][2]string{{"user-1", "user-2"}, {"user-2", "user-1"}, {"user-2", "user-3"}, {"user-3", ""}} {
+			mustNoErr(t, repo.Create(ctx, &domain.Notification{
+				ID: fmt.Sprintf("notification-%d", i+1), UserID: pair[0], ActorID: pair[1], EventID: fmt.Sprintf("event-%d", i+1),
+				Type: domain.EventCommentAdded, PostID: "post-1", Message: "Mensagem", CreatedAt: baseTime,
+			}), "Create")
+		}
+
+		mustNoErr(t, repo.DeleteByUser(ctx, "user-1"), "DeleteByUser")
+
+		for user, want := range map[string]int{"user-1": 0, "user-2": 1, "user-3": 1} {
+			notifications, err := repo.ListByUser(ctx, user, false, 1, 10)
+			mustNoErr(t, err, "ListByUser")
+			if len(notifications) != want {
+				t.Errorf("%s: esperava %d notificações, obteve %+v", user, want, notifications)
+			}
+		}
+	})
+
+	t.Run("Create, ListByUser, CountUnread e MarkRead", func(t *testing.T) {
+		repo := newRepos(t).Notifications
+		for i := 1; i <= 3; i++ {
+			notification := &domain.Notification{
+				ID:        fmt.Sprintf("notification-%d", i),
+				UserID:    "user-1",
+				EventID:   fmt.Sprintf("event-%d", i),
+				Type:      domain.EventCommentAdded,
+				ActorID:   "user-2",
+				PostID:    "post-1",
+				CommentID: fmt.Sprintf("comment-%d", i),
+				Message:   fmt.Sprintf("Mensagem %d", i),
+				CreatedAt: baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			mustNoErr(t, repo.Create(ctx, notification), "Create")
+		}
+		mustNoErr(t, repo.Create(ctx, &domain.Notification{ID: "notification-other", UserID: "user-2", EventID: "event-1", Type: domain.EventCommentAdded, PostID: "post-1", Message: "Outra", CreatedAt: baseTime}), "Create")
+
+		// Mesmo evento para o mesmo usuário é ignorado
+		duplicate := &domain.Notification{ID: "notification-dup", UserID: "user-1", EventID: "event-1", Type: domain.EventCommentAdded, PostID: "post-1", Message: "Repetida", CreatedAt: baseTime}
+		mustNoErr(t, repo.Create(ctx, duplicate), "Create repetido")
+
+		notifications, err := repo.ListByUser(ctx, "user-1", false, 1, 10)
+		mustNoErr(t, err, "ListByUser")
+		if len(notifications) != 3 || notifications[0].ID != "notification-3" || notifications[2].ID != "notification-1" {
+			t.Fatalf("esperava as 3 notificações da mais recente para a mais antiga, obteve %+v", notifications)
+		}
+		got := notifications[0]
+		if got.EventID != "event-3" || got.Type != domain.EventCommentAdded || got.ActorID != "user-2" || got.PostID != "post-1" ||
+			got.CommentID != "comment-3" || got.Message != "Mensagem 3" || got.ReadAt != nil || !got.CreatedAt.Equal(baseTime.Add(3*time.Minute)) {
+			t.Errorf("campos não preservados: %+v", got)
+		}
+
+		mustNoErr(t, repo.MarkRead(ctx, "user-1", "notification-2", baseTime.Add(time.Hour)), "MarkRead")
+		if err := repo.MarkRead(ctx, "user-2", "notification-1", baseTime); err == nil {
+			t.Error("esperava erro ao marcar notificação de outro usuário")
+		}
+
+		unread, err := repo.CountUnread(ctx, "user-1")
+		mustNoErr(t, err, "CountUnread")
+		if unread != 2 {
+			t.Errorf("esperava 2 não lidas, obteve %d", unread)
+		}
+
+		notifications, err = repo.ListByUser(ctx, "user-1", true, 1, 10)
+		mustNoErr(t, err, "ListByUser")
+		if len(notifications) != 2 || notifications[0].ID != "notification-3" || notifications[1].ID != "notification-1" {
+			t.Errorf("esperava apenas as não lidas, obteve %+v", notifications)
+		}
+
+		notifications, err = repo.ListByUser(ctx, "user-1", false, 2, 2)
+		mustNoErr(t, err, "ListByUser")
+		if len(notifications) != 1 || notifications[0].ID != "notification-1" {
+			t.Errorf("esperava a notificação 1 na página 2, obteve %+v", notifications)
+		}
+
+		mustNoErr(t, repo.MarkAllRead(ctx, "user-1", baseTime.Add(2*time.Hour)), "MarkAllRead")
+		unread, err = repo.CountUnread(ctx, "user-1")
+		mustNoErr(t, err, "CountUnread")
+		if unread != 0 {
+			t.Errorf("esperava nenhuma não lida, obteve %d", unread)
+		}
+		read, err := repo.ListByUser(ctx, "user-1", false, 1, 10)
+		mustNoErr(t, err, "ListByUser")
+		if read[1].ReadAt == nil || !read[1].ReadAt.Equal(baseTime.Add(time.Hour)) {
+			t.Errorf("MarkAllRead não deveria alterar a leitura anterior: %+v", read[1])
+		}
+
+		unread, err = repo.CountUnread(ctx, "user-2")
+		mustNoErr(t, err, "CountUnread")
+		if unread != 1 {
+			t.Errorf("notificações de outro usuário não deveriam ser afetadas, obteve %d", unread)
+		}
+	})
+}
+
+func testLoginAttemptRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("DeleteByUser remove as tentativas do usuário e do seu email", func(t *testing.T) {
+		repo := newRepos(t).LoginAttempts
+		for i, attempt := range []*domain.LoginAttempt{
+			{UserID: "user-1", Email: "usuario1@exemplo.com"},
+			{UserID: "", Email: "usuario1@exemplo.com"},
+			{UserID: "", Email: "ninguem@exemplo.com"},
+			{UserID: "user-2", Email: "usuario2@exemplo.com"},
+		} {
+			attempt.ID = fmt.Sprintf("attempt-%d", i+1)
+			attempt.IP = "10.0.0.1"
+			attempt.CreatedAt = baseTime.Add(time.Duration(i) * time.Minute)
+			mustNoErr(t, repo.Create(ctx, attempt), "Create")
+		}
+
+		mustNoErr(t, repo.DeleteByUser(ctx, "user-1", "Usuario1@Exemplo.com"), "DeleteByUser")
+
+		for user, want := range map[string]string{"user-1": "[]", "": "[attempt-3]", "user-2": "[attempt-4]"} {
+			attempts, err := repo.ListByUser(ctx, user, 1, 10)
+			mustNoErr(t, err, "ListByUser")
+			ids := make([]string, len(attempts))
+			for i, attempt := range attempts {
+				ids[i] = attempt.ID
+			}
+			if fmt.Sprint(ids) != want {
+				t.Errorf("%q: esperava %s, obteve %v", user, want, ids)
+			}
+		}
+	})
+
+	t.Run("Create e ListByUser da mais recente", func(t *testing.T) {
+		repo := newRepos(t).LoginAttempts
+		for i := 1; i <= 3; i++ {
+			attempt := &domain.LoginAttempt{
+				ID:        fmt.Sprintf("attempt-%d", i),
+				UserID:    "user-1",
+				Email:     "usuario1@exemplo.com",
+				IP:        "10.0.0.1",
+				UserAgent: "curl/8.0",
+				Success:   i != 2,
+				CreatedAt: baseTime.Add(time.Duration(i) * time.Minute),
+			}
+			mustNoErr(t, repo.Create(ctx, attempt), "Create")
+		}
+		mustNoErr(t, repo.Create(ctx, &domain.LoginAttempt{ID: "attempt-unknown", Email: "ninguem@exemplo.com", IP: "10.0.0.2", CreatedAt: baseTime}), "Create")
+
+		attempts, err := repo.ListByUser(ctx, "user-1", 1, 2)
+		mustNoErr(t, err, "ListByUser")
+		if len(attempts) != 2 || attempts[0].ID != "attempt-3" || attempts[1].ID != "attempt-2" {
+			t.Fatalf("esperava as tentativas 3 e 2, obteve %+v", attempts)
+		}
+		got := attempts[1]
+		if got.Email != "usuario1@exemplo.com" || got.IP != "10.0.0.1" || got.UserAgent != "curl/8.0" || got.Success ||
+			!got.CreatedAt.Equal(baseTime.Add(2*time.Minute)) {
+			t.Errorf("campos não preservados: %+v", got)
+		}
+
+		attempts, err = repo.ListByUser(ctx, "user-1", 2, 2)
+		mustNoErr(t, err, "ListByUser")
+		if len(attempts) != 1 || attempts[0].ID != "attempt-1" || !attempts[0].Success {
+			t.Errorf("esperava a tentativa 1 na página 2, obteve %+v", attempts)
+		}
+	})
+
+	policy := domain.BackoffPolicy{Threshold: 3, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute, Window: 15 * time.Minute}
+
+	t.Run("GetLockout, RegisterFailure e DeleteLockout", func(t *testing.T) {
+		repo := newRepos(t).LoginAttempts
+		empty, err := repo.GetLockout(ctx, "account:usuario1@exemplo.com")
+		mustNoErr(t, err, "GetLockout")
+		if empty.Key != "account:usuario1@exemplo.com" || empty.Failures != 0 || empty.LockedUntil != nil {
+			t.Errorf("esperava um estado vazio, obteve %+v", empty)
+		}
+
+		for i := 1; i <= 2; i++ {
+			lockout, err := repo.RegisterFailure(ctx, "account:usuario1@exemplo.com", policy, baseTime.Add(time.Duration(i)*time.Second))
+			mustNoErr(t, err, "RegisterFailure")
+			if lockout.Failures != i || lockout.LockedUntil != nil {
+				t.Fatalf("falha %d: esperava %d falhas sem bloqueio, obteve %+v", i, i, lockout)
+			}
+		}
+		_, err = repo.RegisterFailure(ctx, "ip:10.0.0.1", policy, baseTime)
+		mustNoErr(t, err, "RegisterFailure")
+
+		third := baseTime.Add(3 * time.Second)
+		locked, err := repo.RegisterFailure(ctx, "account:usuario1@exemplo.com", policy, third)
+		mustNoErr(t, err, "RegisterFailure")
+		if locked.Failures != 3 || locked.LockedUntil == nil || !locked.LockedUntil.Equal(third.Add(time.Minute)) {
+			t.Fatalf("esperava o bloqueio na terceira falha, obteve %+v", locked)
+		}
+
+		lockout, err := repo.GetLockout(ctx, "account:usuario1@exemplo.com")
+		mustNoErr(t, err, "GetLockout")
+		if lockout.Failures != 3 || lockout.LockedUntil == nil || !lockout.LockedUntil.Equal(third.Add(time.Minute)) || !lockout.UpdatedAt.Equal(third) {
+			t.Errorf("estado não gravado: %+v", lockout)
+		}
+
+		mustNoErr(t, repo.DeleteLockout(ctx, "account:usuario1@exemplo.com"), "DeleteLockout")
+		lockout, err = repo.GetLockout(ctx, "account:usuario1@exemplo.com")
+		mustNoErr(t, err, "GetLockout")
+		if lockout.Failures != 0 || lockout.LockedUntil != nil {
+			t.Errorf("esperava o estado vazio após DeleteLockout, obteve %+v", lockout)
+		}
+
+		other, err := repo.GetLockout(ctx, "ip:10.0.0.1")
+		mustNoErr(t, err, "GetLockout")
+		if other.Failures != 1 {
+			t.Errorf("outras chaves não deveriam ser afetadas: %+v", other)
+		}
+	})
+
+	t.Run("RegisterFailure esquece falhas fora da janela", func(t *testing.T) {
+		repo := newRepos(t).LoginAttempts
+		for i := 0; i < 2; i++ {
+			_, err := repo.RegisterFailure(ctx, "ip:10.0.0.1", policy, baseTime)
+			mustNoErr(t, err, "RegisterFailure")
+		}
+
+		lockout, err := repo.RegisterFailure(ctx, "ip:10.0.0.1", policy, baseTime.Add(policy.Window+time.Second))
+		mustNoErr(t, err, "RegisterFailure")
+		if lockout.Failures != 1 || lockout.LockedUntil != nil {
+			t.Errorf("esperava a contagem reiniciada, obteve %+v", lockout)
+		}
+	})
+
+	t.Run("RegisterFailure concorrente não perde falhas", func(t *testing.T) {
+		repo := newRepos(t).LoginAttempts
+		const attempts = 20
+
+		var wg sync.WaitGroup
+		errs := make(chan error, attempts)
+		for i := 0; i < attempts; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := repo.RegisterFailure(ctx, "account:usuario1@exemplo.com", policy, baseTime); err != nil {
+					errs <- err
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Fatalf("Erro em RegisterFailure concorrente: %v", err)
+		}
+
+		lockout, err := repo.GetLockout(ctx, "account:usuario1@exemplo.com")
+		mustNoErr(t, err, "GetLockout")
+		if lockout.Failures != attempts || !lockout.IsLocked(baseTime) {
+			t.Errorf("esperava %d falhas e a chave bloqueada, obteve %+v", attempts, lockout)
+		}
+	})
+}
+
+func testReactionRepository(t *testing.T, newRepos Factory) {
+	ctx := context.Background()
+
+	t.Run("DeleteByUser desconta as reações do usuário", func(t *testing.T) {
+		repo := newRepos(t).Reactions
+		for _, reaction := range []*domain.Reaction{
+			{UserID: "user-1", TargetType: domain.ReactionTargetPost, TargetID: "post-1"},
+			{UserID: "user-2", TargetType: domain.ReactionTargetPost, TargetID: "post-1"},
+			{UserID: "user-1", TargetType: domain.ReactionTargetPost, TargetID: "post-2"},
+			{UserID: "user-1", TargetType: domain.ReactionTargetComment, TargetID: "comment-1"},
+		} {
+			reaction.CreatedAt = baseTime
+			_, err := repo.Add(ctx, reaction)
+			mustNoErr(t, err, "Add")
+		}
+
+		mustNoErr(t, repo.DeleteByUser(ctx, "user-1"), "DeleteByUser")
+
+		posts, err := repo.CountByTargets(ctx, domain.ReactionTargetPost, []string{"post-1", "post-2"})
+		mustNoErr(t, err, "CountByTargets")
+		comments, err := repo.CountByTargets(ctx, domain.ReactionTargetComment, []string{"comment-1"})
+		mustNoErr(t, err, "CountByTargets")
+		if fmt.Sprint(posts, comments) != "map[post-1:1] map[]" {
+			t.Errorf("esperava apenas a curtida de user-2, obtido %v %v", posts, comments)
+		}
+	})
+
+	like := func(userID string, targetType domain.ReactionTarget, targetID string) *domain.Reaction {
+		return &domain.Reaction{UserID: userID, TargetType: targetType, TargetID: targetID, CreatedAt: baseTime}
+	}
+
+	t.Run("Add, Remove e CountByTargets", func(t *testing.T) {
+		repo := newRepos(t).Reactions
+		for _, reaction := range []*domain.Reaction{
+			like("user-1", domain.ReactionTargetPost, "target-1"),
+			like("user-2", domain.ReactionTargetPost, "target-1"),
+			like("user-1", domain.ReactionTargetPost, "target-2"),
+			like("user-1", domain.ReactionTargetComment, "target-1"),
+		} {
+			added, err := repo.Add(ctx, reaction)
+			mustNoErr(t, err, "Add")
+			if !added {
+				t.Errorf("esperava registrar a reação %+v", reaction)
+			}
+		}
+
+		added, err := repo.Add(ctx, like("user-1", domain.ReactionTargetPost, "target-1"))
+		mustNoErr(t, err, "Add")
+		if added {
+			t.Error("a mesma reação não deveria ser registrada duas vezes")
+		}
+
+		counts, err := repo.CountByTargets(ctx, domain.ReactionTargetPost, []string{"target-1", "target-2", "target-3"})
+		mustNoErr(t, err, "CountByTargets")
+		if len(counts) != 2 || counts["target-1"] != 2 || counts["target-2"] != 1 {
+			t.Errorf("esperava target-1=2 e target-2=1, obteve %v", counts)
+		}
+
+		removed, err := repo.Remove(ctx, "user-1", domain.ReactionTargetPost, "target-1")
+		mustNoErr(t, err, "Remove")
+		if !removed {
+			t.Error("esperava remover a reação existente")
+		}
+		removed, err = repo.Remove(ctx, "user-1", domain.ReactionTargetPost, "target-1")
+		mustNoErr(t, err, "Remove")
+		if removed {
+			t.Error("não deveria remover uma reação inexistente")
+		}
+
+		counts, err = repo.CountByTargets(ctx, domain.ReactionTargetPost, []string{"target-1"})
+		mustNoErr(t, err, "CountByTargets")
+		if counts["target-1"] != 1 {
+			t.Errorf("esperava target-1=1 após Remove, obteve %v", counts)
+		}
+
+		counts, err = repo.CountByTargets(ctx, domain.ReactionTargetComment, []string{"target-1"})
+		mustNoErr(t, err, "CountByTargets")
+		if counts["target-1"] != 1 {
+			t.Errorf("reações em comentários não deveriam ser afetadas: %v", counts)
+		}
+
+		counts, err = repo.CountByTargets(ctx, domain.ReactionTargetPost, nil)
+		mustNoErr(t, err, "CountByTargets")
+		if len(counts) != 0 {
+			t.Errorf("esperava um mapa vazio sem conteúdos, obteve %v", counts)
+		}
+	})
+
+	t.Run("Curtidas simultâneas contam uma vez por usuário", func(t *testing.T) {
+		repo := newRepos(t).Reactions
+		const users, attempts = 20, 3
+
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			added int
+			errs  []error
+		)
+		for i := 1; i <= users; i++ {
+			for j := 0; j < attempts; j++ {
+				wg.Add(1)
+				go func(userID string) {
+					defer wg.Done()
+					ok, err := repo.Add(ctx, like(userID, domain.ReactionTargetPost, "target-1"))
+					mu.Lock()
+					defer mu.Unlock()
+					if err != nil {
+						errs = append(errs, err)
+					}
+					if ok {
+						added++
+					}
+				}(fmt.Sprintf("user-%d", i))
+			}
+		}
+		wg.Wait()
+
+		if len(errs) > 0 {
+			t.Fatalf("Add: erro inesperado: %v", errs[0])
+		}
+		if added != users {
+			t.Errorf("esperava %d reações registradas, obteve %d", users, added)
+		}
+
+		counts, err := repo.CountByTargets(ctx, domain.ReactionTargetPost, []string{"target-1"})
+		mustNoErr(t, err, "CountByTargets")
+		if counts["target-1"] != users {
+			t.Errorf("esperava %d curtidas, obteve %d", users, counts["target-1"])
+		}
+	})
+}
+
+func assertFamilyActive(t *testing.T, repo repositories.TokenRepository, familyID string, want bool) {
+	t.Helper()
+	active, err := repo.IsFamilyActive(context.Background(), familyID)
+	mustNoErr(t, err, "IsFamilyActive")
+	if active != want {
+		t.Errorf("IsFamilyActive(%s): esperava %v, obteve %v", familyID, want, active)
+	}
+}
+
+// firstPage solicita a primeira página com o limite informado
+func firstPage(limit int) domain.PageRequest {
+	return domain.PageRequest{Limit: limit}
+}
+
+// items adapta o resultado de uma listagem paginada às asserções sobre listas
+func items[T any](page *domain.Page[T], err error) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// postID identifica um post nas descrições de páginas
+func postID(p *domain.Post) string {
+	return p.ID
+}
+
+// walkPages percorre a listagem seguindo os cursores e descreve as páginas obtidas como
+// [ids] separadas por espaço. Verifica também o total e o fim da listagem.
+func walkPages[T any](t *testing.T, name string, limit int, list func(domain.PageRequest) (*domain.Page[T], error), id func(T) string) string {
+	t.Helper()
+
+	pages := make([]string, 0)
+	req := domain.PageRequest{Limit: limit, WithTotal: true}
+	seen := 0
+	for len(pages) < 100 {
+		page, err := list(req)
+		mustNoErr(t, err, name)
+
+		ids := make([]string, len(page.Items))
+		for i, item := range page.Items {
+			ids[i] = id(item)
+		}
+		pages = append(pages, fmt.Sprint(ids))
+		seen += len(ids)
+
+		if page.Total == nil {
+			t.Fatalf("%s: total solicitado não foi preenchido", name)
+		}
+		if !page.HasMore {
+			if page.NextCursor != "" {
+				t.Errorf("%s: última página não deveria ter cursor", name)
+			}
+			if *page.Total != seen {
+				t.Errorf("%s: total esperado %d, obtido %d", name, seen, *page.Total)
+			}
+			break
+		}
+
+		req.After, err = domain.DecodeCursor(page.NextCursor)
+		mustNoErr(t, err, name+": DecodeCursor")
+	}
+
+	return strings.Join(pages, " ")
+}
+
+func assertPostIDs(t *testing.T, name string, list func() ([]*domain.Post, error), want ...string) {
+	t.Helper()
+	posts, err := list()
+	mustNoErr(t, err, name)
+	got := make([]string, len(posts))
+	for i, p := range posts {
+		got[i] = p.ID
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s: esperava %v, obteve %v", name, want, got)
+	}
+}
+
+func assertCommentIDs(t *testing.T, name string, list func() ([]*domain.Comment, error), want ...string) {
+	t.Helper()
+	comments, err := list()
+	mustNoErr(t, err, name)
+	got := make([]string, len(comments))
+	for i, c := range comments {
+		got[i] = c.ID
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("%s: esperava %v, obteve %v", name, want, got)
+	}
+}
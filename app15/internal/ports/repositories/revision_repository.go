@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// RevisionRepository define a interface para operações de persistência do histórico de revisões de posts
+type RevisionRepository interface {
+	// Create registra uma nova revisão. Falha se o post já tiver uma revisão com a mesma versão.
+	Create(ctx context.Context, revision *domain.PostRevision) error
+
+	// GetByVersion busca uma revisão de um post pelo número da versão
+	GetByVersion(ctx context.Context, postID string, version int) (*domain.PostRevision, error)
+
+	// GetLatest busca a revisão mais recente de um post
+	GetLatest(ctx context.Context, postID string) (*domain.PostRevision, error)
+
+	// ListByPost retorna uma lista paginada das revisões de um post, da mais recente para a mais antiga
+	ListByPost(ctx context.Context, postID string, page, pageSize int) ([]*domain.PostRevision, error)
+
+	// ReassignEditor transfere para toID todas as revisões editadas por fromID
+	ReassignEditor(ctx context.Context, fromID, toID string) error
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// ErrTokenAlreadyRevoked é retornado por TokenRepository.Revoke quando o token já estava revogado
+var ErrTokenAlreadyRevoked = errors.New("token já revogado")
+
+// TokenRepository define a interface para operações de persistência de tokens de renovação
+type TokenRepository interface {
+	// Create armazena um novo token de renovação
+	Create(ctx context.Context, token *domain.RefreshToken) error
+
+	// GetByHash busca um token de renovação pelo hash do seu valor
+	GetByHash(ctx context.Context, hash string) (*domain.RefreshToken, error)
+
+	// Revoke revoga um único token, registrando o token que o substituiu (se houver).
+	// Retorna ErrTokenAlreadyRevoked se o token já estava revogado.
+	Revoke(ctx context.Context, id, replacedBy string) error
+
+	// RevokeFamily revoga todos os tokens ainda ativos de uma família
+	RevokeFamily(ctx context.Context, familyID string) error
+
+	// RevokeByUser revoga todos os tokens ainda ativos do usuário, encerrando todas as suas sessões
+	RevokeByUser(ctx context.Context, userID string) error
+
+	// IsFamilyActive informa se a família ainda possui algum token não revogado
+	IsFamilyActive(ctx context.Context, familyID string) (bool, error)
+}
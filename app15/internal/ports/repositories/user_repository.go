@@ -2,10 +2,15 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
-	"app15/internal/domain"
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
 )
 
+// ErrLastAdmin é retornado por UserRepository.UpdateRole quando a alteração deixaria a
+// aplicação sem nenhum administrador
+var ErrLastAdmin = errors.New("a aplicação precisa de pelo menos um administrador")
+
 // UserRepository define a interface para operações de persistência de usuários
 type UserRepository interface {
 	// Create cria um novo usuário no repositório
@@ -14,6 +19,10 @@ type UserRepository interface {
 	// GetByID busca um usuário pelo seu ID
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	
+	// GetByIDs busca em uma única consulta os usuários com os IDs informados.
+	// IDs inexistentes são ignorados e a ordem do resultado não é garantida.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error)
+	
 	// GetByEmail busca um usuário pelo seu email
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 	
@@ -23,9 +32,14 @@ type UserRepository interface {
 	// Update atualiza os dados de um usuário existente
 	Update(ctx context.Context, user *domain.User) error
 	
+	// UpdateRole grava o papel e a data de atualização do usuário. A verificação de que
+	// resta ao menos um administrador é atômica com a gravação: retorna ErrLastAdmin se a
+	// alteração rebaixaria o último administrador.
+	UpdateRole(ctx context.Context, user *domain.User) error
+	
 	// Delete remove um usuário do repositório
 	Delete(ctx context.Context, id string) error
 	
-	// List retorna uma lista paginada de usuários
-	List(ctx context.Context, page, pageSize int) ([]*domain.User, error)
+	// List retorna uma página de usuários, do cadastro mais antigo para o mais recente
+	List(ctx context.Context, req domain.PageRequest) (*domain.Page[*domain.User], error)
 } 
\ No newline at end of file
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// accountPosts cria um post publicado, um rascunho e um post agendado do usuário
+func accountPosts(s *testServer, token string) (published, draft, scheduled post) {
+	s.t.Helper()
+	s.do(http.MethodPost, "/api/posts", token, map[string]interface{}{
+		"title": "Publicado", "content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&published)
+	s.do(http.MethodPost, "/api/posts", token, map[string]interface{}{
+		"title": "Rascunho", "content": "Conteúdo", "status": "draft",
+	}).expect(http.StatusCreated).decode(&draft)
+	s.do(http.MethodPost, "/api/posts", token, map[string]interface{}{
+		"title": "Agendado", "content": "Conteúdo", "status": "scheduled", "publish_at": time.Now().Add(time.Hour),
+	}).expect(http.StatusCreated).decode(&scheduled)
+	return published, draft, scheduled
+}
+
+// Remover a conta apaga as curtidas, as notificações e o histórico de login do usuário,
+// e o email volta a ficar livre para um novo cadastro, sem diferenciar maiúsculas
+func TestDeleteAccountScenario(t *testing.T) {
+	s := newTestServer(t)
+	ctx := context.Background()
+
+	// Bob publica um post, que Alice curte e comenta
+	bob := s.register("bob")
+	alice := s.register("alice")
+
+	var created post
+	s.do(http.MethodPost, "/api/posts", bob, map[string]interface{}{
+		"title":   "Post do Bob",
+		"content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&created)
+	s.do(http.MethodPut, "/api/posts/"+created.ID+"/like", alice, nil).expect(http.StatusOK)
+	s.do(http.MethodPost, "/api/posts/"+created.ID+"/comments", alice, map[string]string{
+		"content": "Curti!",
+	}).expect(http.StatusCreated)
+
+	// O comentário gera uma notificação para Bob, entregue em segundo plano
+	var inbox struct {
+		Notifications []domain.Notification `json:"notifications"`
+		UnreadCount   int                   `json:"unread_count"`
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for inbox.UnreadCount == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("a notificação do comentário não foi entregue a tempo")
+		}
+		time.Sleep(10 * time.Millisecond)
+		s.do(http.MethodGet, "/api/notifications", bob, nil).expect(http.StatusOK).decode(&inbox)
+	}
+
+	published, draft, scheduled := accountPosts(s, alice)
+
+	// Uma senha errada deixa uma falha registrada para a conta de Alice
+	s.do(http.MethodPost, "/api/auth/login", "", map[string]string{
+		"email":    "alice@exemplo.com",
+		"password": "senha-errada",
+	}).expectError(http.StatusUnauthorized, "invalid_credentials")
+
+	s.do(http.MethodDelete, "/api/users/me", alice, map[string]string{
+		"password": "alice-senha-secreta",
+	}).expect(http.StatusNoContent)
+
+	var fetched struct {
+		Likes int `json:"likes"`
+	}
+	s.do(http.MethodGet, "/api/posts/"+created.ID, "", nil).expect(http.StatusOK).decode(&fetched)
+	if fetched.Likes != 0 {
+		t.Errorf("esperava a curtida de Alice removida, obteve %d curtidas", fetched.Likes)
+	}
+
+	s.do(http.MethodGet, "/api/notifications", bob, nil).expect(http.StatusOK).decode(&inbox)
+	if len(inbox.Notifications) != 0 || inbox.UnreadCount != 0 {
+		t.Errorf("esperava as notificações causadas por Alice removidas, obteve %+v", inbox)
+	}
+
+	// O post publicado fica com o autor genérico; rascunho e agendado são apagados
+	kept, err := s.app.Repositories.Posts.GetByID(ctx, published.ID)
+	if err != nil || kept.AuthorID != domain.DeletedUserID {
+		t.Errorf("esperava o post publicado atribuído ao autor removido, obteve %+v, %v", kept, err)
+	}
+	for _, id := range []string{draft.ID, scheduled.ID} {
+		if _, err := s.app.Repositories.Posts.GetByID(ctx, id); err == nil {
+			t.Errorf("esperava o post %s não publicado apagado", id)
+		}
+	}
+
+	lockout, err := s.app.Repositories.LoginAttempts.GetLockout(ctx, domain.LockoutAccountKey("alice@exemplo.com"))
+	if err != nil {
+		t.Fatalf("Erro ao buscar o bloqueio: %v", err)
+	}
+	if lockout.Failures != 0 {
+		t.Errorf("esperava o bloqueio da conta zerado, obteve %+v", lockout)
+	}
+
+	// O email é liberado e comparado sem diferenciar maiúsculas
+	s.do(http.MethodPost, "/api/auth/register", "", map[string]string{
+		"username": "alice",
+		"email":    "Alice@Exemplo.com",
+		"password": "alice-senha-secreta",
+	}).expect(http.StatusCreated)
+	s.login("alice")
+}
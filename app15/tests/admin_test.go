@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// Nenhum cadastro se torna administrador: o papel é dado na inicialização à conta de ADMIN_EMAIL
+func TestAdminBootstrapScenario(t *testing.T) {
+	t.Setenv("ADMIN_EMAIL", "alice@exemplo.com")
+	s := newTestServer(t)
+
+	// Antes do cadastro, a conta configurada ainda não existe e nada acontece
+	if err := s.app.BootstrapAdmin(context.Background()); err != nil {
+		t.Fatalf("Erro ao promover o administrador: %v", err)
+	}
+
+	// O primeiro cadastro é um autor comum, sem acesso à administração
+	alice := s.register("alice")
+	s.do(http.MethodGet, "/api/admin/users", alice, nil).expectError(http.StatusForbidden, "forbidden")
+
+	// Na inicialização seguinte, a conta de ADMIN_EMAIL é promovida
+	if err := s.app.BootstrapAdmin(context.Background()); err != nil {
+		t.Fatalf("Erro ao promover o administrador: %v", err)
+	}
+	alice = s.login("alice")
+
+	var users struct {
+		Items []struct {
+			Email string `json:"email"`
+			Role  string `json:"role"`
+		} `json:"items"`
+	}
+	s.do(http.MethodGet, "/api/admin/users", alice, nil).expect(http.StatusOK).decode(&users)
+	if len(users.Items) != 1 || users.Items[0].Role != "admin" {
+		t.Fatalf("esperava alice como admin, obteve %+v", users.Items)
+	}
+
+	// A promoção fica na trilha de auditoria, em nome do sistema
+	var audit []struct {
+		ActorID string `json:"actor_id"`
+		Action  string `json:"action"`
+	}
+	s.do(http.MethodGet, "/api/admin/audit", alice, nil).expect(http.StatusOK).decode(&audit)
+	if len(audit) != 1 || audit[0].ActorID != "system" || audit[0].Action != "user.change_role" {
+		t.Fatalf("esperava a promoção na auditoria, obteve %+v", audit)
+	}
+}
+
+// O último administrador não pode ser rebaixado, nem por si mesmo
+func TestLastAdminScenario(t *testing.T) {
+	t.Setenv("ADMIN_EMAIL", "alice@exemplo.com")
+	s := newTestServer(t)
+
+	s.register("alice")
+	s.register("bob")
+	if err := s.app.BootstrapAdmin(context.Background()); err != nil {
+		t.Fatalf("Erro ao promover o administrador: %v", err)
+	}
+	alice := s.login("alice")
+
+	var users struct {
+		Items []struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		} `json:"items"`
+	}
+	s.do(http.MethodGet, "/api/admin/users", alice, nil).expect(http.StatusOK).decode(&users)
+	ids := make(map[string]string)
+	for _, user := range users.Items {
+		ids[user.Email] = user.ID
+	}
+
+	// Sozinha, alice não pode deixar de ser admin
+	aliceRole := "/api/admin/users/" + ids["alice@exemplo.com"] + "/role"
+	s.do(http.MethodPut, aliceRole, alice, map[string]string{"role": "author"}).expectError(http.StatusConflict, "last_admin")
+
+	// Com bob também admin, o rebaixamento é permitido
+	s.do(http.MethodPut, "/api/admin/users/"+ids["bob@exemplo.com"]+"/role", alice, map[string]string{"role": "admin"}).expect(http.StatusOK)
+	s.do(http.MethodPut, aliceRole, alice, map[string]string{"role": "author"}).expect(http.StatusOK)
+
+	// Agora bob é o último administrador
+	bob := s.login("bob")
+	bobRole := "/api/admin/users/" + ids["bob@exemplo.com"] + "/role"
+	s.do(http.MethodPut, bobRole, bob, map[string]string{"role": "reader"}).expectError(http.StatusConflict, "last_admin")
+
+	// Nem remover a própria conta, que continua ativa
+	s.do(http.MethodDelete, "/api/users/me", bob, map[string]string{
+		"password": "bob-senha-secreta",
+	}).expectError(http.StatusConflict, "last_admin")
+	s.do(http.MethodGet, "/api/admin/users", bob, nil).expect(http.StatusOK)
+}
@@ -0,0 +1,47 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Os comentários de um post arquivado deixam de ser visíveis para quem não pode ver o post
+func TestArchivedPostCommentsScenario(t *testing.T) {
+	s := newTestServer(t)
+
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	var created post
+	s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+		"title":   "Post da Alice",
+		"content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&created)
+
+	var reply comment
+	s.do(http.MethodPost, "/api/posts/"+created.ID+"/comments", bob, map[string]string{
+		"content": "Comentário do Bob",
+	}).expect(http.StatusCreated).decode(&reply)
+
+	s.do(http.MethodPut, "/api/posts/"+created.ID+"/status", alice, map[string]string{
+		"status": "archived",
+	}).expect(http.StatusOK)
+
+	base := "/api/posts/" + created.ID + "/comments"
+	for _, token := range []string{"", bob} {
+		s.do(http.MethodGet, base, token, nil).expectError(http.StatusNotFound, "post_not_found")
+		s.do(http.MethodGet, base+"/thread", token, nil).expectError(http.StatusNotFound, "post_not_found")
+		s.do(http.MethodGet, base+"/"+reply.ID, token, nil).expectError(http.StatusNotFound, "comment_not_found")
+	}
+
+	// A autora do post continua vendo os comentários
+	var listed struct {
+		Items []comment `json:"items"`
+	}
+	s.do(http.MethodGet, base, alice, nil).expect(http.StatusOK).decode(&listed)
+	if len(listed.Items) != 1 || listed.Items[0].ID != reply.ID {
+		t.Errorf("esperava o comentário visível para a autora, obteve %+v", listed.Items)
+	}
+	s.do(http.MethodGet, base+"/thread", alice, nil).expect(http.StatusOK)
+	s.do(http.MethodGet, base+"/"+reply.ID, alice, nil).expect(http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// getConditional busca path com If-None-Match e retorna o status, o ETag e o corpo
+func (s *testServer) getConditional(path, etag string) (int, string, string) {
+	s.t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, s.server.URL+path, nil)
+	if err != nil {
+		s.t.Fatalf("Erro ao criar a requisição: %v", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := s.server.Client().Do(req)
+	if err != nil {
+		s.t.Fatalf("Erro na requisição GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.t.Fatalf("Erro ao ler a resposta de GET %s: %v", path, err)
+	}
+	if resp.Header.Get("Last-Modified") != "" {
+		s.t.Errorf("GET %s: não esperava Last-Modified, obteve %q", path, resp.Header.Get("Last-Modified"))
+	}
+
+	return resp.StatusCode, resp.Header.Get("ETag"), string(body)
+}
+
+// Feeds e sitemap apontam para as páginas públicas dos posts e mudam de versão quando
+// um post sai do documento, mesmo sem alteração nos posts que restam
+func TestFeedScenario(t *testing.T) {
+	t.Setenv("SITE_URL", "https://api.exemplo.com")
+	t.Setenv("SITE_POST_URL", "https://blog.exemplo.com/artigos/{id}")
+	s := newTestServer(t)
+
+	alice := s.register("alice")
+	var kept, removed post
+	s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+		"title": "Mantido", "content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&kept)
+	s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+		"title": "Removido", "content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&removed)
+
+	for _, path := range []string{"/sitemap.xml", "/feed.atom", "/feed.rss", "/users/alice/feed.atom"} {
+		status, etag, body := s.getConditional(path, "")
+		if status != http.StatusOK || etag == "" {
+			t.Fatalf("%s: esperava 200 com ETag, obteve %d %q", path, status, etag)
+		}
+		if !strings.Contains(body, "https://blog.exemplo.com/artigos/"+kept.ID) || strings.Contains(body, "/api/posts") {
+			t.Errorf("%s: esperava links para as páginas públicas dos posts: %s", path, body)
+		}
+
+		if status, _, _ := s.getConditional(path, etag); status != http.StatusNotModified {
+			t.Errorf("%s: esperava 304 para a versão atual, obteve %d", path, status)
+		}
+	}
+
+	_, etag, _ := s.getConditional("/sitemap.xml", "")
+	s.do(http.MethodDelete, "/api/posts/"+removed.ID, alice, nil).expect(http.StatusNoContent)
+
+	status, _, body := s.getConditional("/sitemap.xml", etag)
+	if status != http.StatusOK || strings.Contains(body, removed.ID) || !strings.Contains(body, kept.ID) {
+		t.Errorf("esperava o sitemap atualizado sem o post removido, obteve %d: %s", status, body)
+	}
+}
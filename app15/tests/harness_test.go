@@ -0,0 +1,175 @@
+// Package tests reúne os testes de ponta a ponta: cada teste sobe a API completa, com o router
+// HTTP e os serviços reais sobre repositórios em memória, e a exercita por requisições HTTP.
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	httpadapter "github.com/caiquemiranda/go-apps/app15/internal/adapters/http"
+	"github.com/caiquemiranda/go-apps/app15/internal/app"
+	"github.com/caiquemiranda/go-apps/app15/internal/config"
+)
+
+// testServer é uma instância da API servida por httptest
+type testServer struct {
+	t      *testing.T
+	server *httptest.Server
+	app    *app.App
+}
+
+// newTestServer monta a aplicação sobre repositórios em memória, inicia as tarefas em
+// segundo plano e serve o router completo. Tudo é encerrado ao final do teste. O limite de
+// requisições fica desativado, a menos que o teste defina RATE_LIMIT_ENABLED antes.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	t.Setenv("JWT_SECRET", "segredo-de-teste")
+	if _, ok := os.LookupEnv("RATE_LIMIT_ENABLED"); !ok {
+		t.Setenv("RATE_LIMIT_ENABLED", "false")
+	}
+	cfg, err := config.Load([]string{"-env-file", writeEmptyEnvFile(t)})
+	if err != nil {
+		t.Fatalf("Erro ao carregar a configuração: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a := app.New(cfg, app.NewMemoryRepositories())
+	a.Start(ctx)
+
+	server := httptest.NewServer(httpadapter.NewRouter(a))
+	t.Cleanup(func() {
+		server.Close()
+		cancel()
+	})
+
+	return &testServer{t: t, server: server, app: a}
+}
+
+// writeEmptyEnvFile cria um .env vazio para que a configuração não dependa do diretório atual
+func writeEmptyEnvFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("Erro ao criar arquivo .env: %v", err)
+	}
+	return path
+}
+
+// testResponse é a resposta de uma requisição, com o corpo já lido
+type testResponse struct {
+	t      *testing.T
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// do envia uma requisição à API. token vazio representa um visitante anônimo; body,
+// quando informado, é enviado como JSON.
+func (s *testServer) do(method, path, token string, body interface{}) *testResponse {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("Erro ao serializar a requisição: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, s.server.URL+path, reader)
+	if err != nil {
+		s.t.Fatalf("Erro ao criar a requisição: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.server.Client().Do(req)
+	if err != nil {
+		s.t.Fatalf("Erro na requisição %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.t.Fatalf("Erro ao ler a resposta de %s %s: %v", method, path, err)
+	}
+
+	return &testResponse{t: s.t, Status: resp.StatusCode, Header: resp.Header, Body: data}
+}
+
+// expect verifica o status da resposta e interrompe o teste, com o corpo recebido, se for outro
+func (r *testResponse) expect(status int) *testResponse {
+	r.t.Helper()
+	if r.Status != status {
+		r.t.Fatalf("esperava status %d, obteve %d: %s", status, r.Status, r.Body)
+	}
+	return r
+}
+
+// decode lê o corpo JSON da resposta em v
+func (r *testResponse) decode(v interface{}) {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		r.t.Fatalf("Resposta JSON inválida: %v: %s", err, r.Body)
+	}
+}
+
+// expectError verifica o status e o código do envelope de erro da resposta
+func (r *testResponse) expectError(status int, code string) {
+	r.t.Helper()
+	r.expect(status)
+
+	var envelope struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	r.decode(&envelope)
+	if envelope.Error.Code != code {
+		r.t.Fatalf("esperava o código de erro %q, obteve %q: %s", code, envelope.Error.Code, r.Body)
+	}
+}
+
+// register cadastra um usuário com email e senha derivados do nome e retorna o token de acesso
+func (s *testServer) register(username string) string {
+	s.t.Helper()
+
+	var auth struct {
+		Token string `json:"token"`
+	}
+	s.do(http.MethodPost, "/api/auth/register", "", map[string]string{
+		"username": username,
+		"email":    username + "@exemplo.com",
+		"password": username + "-senha-secreta",
+	}).expect(http.StatusCreated).decode(&auth)
+
+	return auth.Token
+}
+
+// login autentica um usuário cadastrado por register e retorna um novo token de acesso
+func (s *testServer) login(username string) string {
+	s.t.Helper()
+
+	var auth struct {
+		Token string `json:"token"`
+	}
+	s.do(http.MethodPost, "/api/auth/login", "", map[string]string{
+		"email":    username + "@exemplo.com",
+		"password": username + "-senha-secreta",
+	}).expect(http.StatusOK).decode(&auth)
+
+	return auth.Token
+}
@@ -0,0 +1,74 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/caiquemiranda/go-apps/app15/internal/domain"
+)
+
+// Tentativas de login simultâneas com senha errada são todas contabilizadas, e a conta
+// fica bloqueada ao atingir o limite de falhas
+func TestConcurrentFailedLoginsLockAccount(t *testing.T) {
+	const attempts = 30
+
+	// Apenas o bloqueio da conta interessa: o do IP fica fora de alcance
+	t.Setenv("LOGIN_IP_MAX_FAILURES", "1000")
+	s := newTestServer(t)
+	s.register("alice")
+
+	body, err := json.Marshal(map[string]string{"email": "alice@exemplo.com", "password": "senha-errada"})
+	if err != nil {
+		t.Fatalf("Erro ao serializar a requisição: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := s.server.Client().Post(s.server.URL+"/api/auth/login", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Errorf("Erro na requisição de login: %v", err)
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	rejected := 0
+	for status := range statuses {
+		switch status {
+		case http.StatusUnauthorized:
+			rejected++
+		case http.StatusTooManyRequests:
+		default:
+			t.Fatalf("esperava 401 ou 429, obteve %d", status)
+		}
+	}
+
+	// Cada senha verificada e recusada conta uma falha, sem que as tentativas simultâneas
+	// sobrescrevam as contagens umas das outras
+	lockout, err := s.app.Repositories.LoginAttempts.GetLockout(context.Background(), domain.LockoutAccountKey("alice@exemplo.com"))
+	if err != nil {
+		t.Fatalf("Erro ao buscar o bloqueio: %v", err)
+	}
+	threshold := s.app.Config.Lockout.AccountMaxFailures
+	if lockout.Failures != rejected || rejected < threshold {
+		t.Fatalf("esperava %d falhas contabilizadas (limite %d), obteve %+v", rejected, threshold, lockout)
+	}
+
+	// Com o limite atingido, nem a senha correta é aceita
+	s.do(http.MethodPost, "/api/auth/login", "", map[string]string{
+		"email":    "alice@exemplo.com",
+		"password": "alice-senha-secreta",
+	}).expectError(http.StatusTooManyRequests, "account_locked")
+}
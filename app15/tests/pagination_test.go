@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+// Um pageSize fora do intervalo de 1 a 100 é rejeitado em vez de trocado pelo padrão
+func TestPageSizeScenario(t *testing.T) {
+	s := newTestServer(t)
+
+	alice := s.register("alice")
+	for _, title := range []string{"Primeiro", "Segundo", "Terceiro"} {
+		s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+			"title":   title,
+			"content": "Conteúdo",
+		}).expect(http.StatusCreated)
+	}
+
+	var page struct {
+		Items []post `json:"items"`
+	}
+	s.do(http.MethodGet, "/api/posts?pageSize=2", "", nil).expect(http.StatusOK).decode(&page)
+	if len(page.Items) != 2 {
+		t.Errorf("esperava 2 posts na página, obteve %d", len(page.Items))
+	}
+	s.do(http.MethodGet, "/api/posts?pageSize=100", "", nil).expect(http.StatusOK).decode(&page)
+	if len(page.Items) != 3 {
+		t.Errorf("esperava os 3 posts na página, obteve %d", len(page.Items))
+	}
+
+	for _, pageSize := range []string{"0", "-1", "101", "500", "dez"} {
+		s.do(http.MethodGet, "/api/posts?pageSize="+pageSize, "", nil).expectError(http.StatusBadRequest, "invalid_parameter")
+	}
+}
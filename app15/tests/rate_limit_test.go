@@ -0,0 +1,32 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+// A criação de comentários tem um limite próprio, mais restrito que o das demais rotas autenticadas
+func TestCommentRateLimitScenario(t *testing.T) {
+	t.Setenv("RATE_LIMIT_ENABLED", "true")
+	t.Setenv("RATE_LIMIT_COMMENT", "2/1m")
+	s := newTestServer(t)
+
+	alice := s.register("alice")
+	bob := s.register("bob")
+
+	var created post
+	s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+		"title":   "Post da Alice",
+		"content": "Conteúdo",
+	}).expect(http.StatusCreated).decode(&created)
+
+	path := "/api/posts/" + created.ID + "/comments"
+	comment := map[string]string{"content": "Comentário"}
+	s.do(http.MethodPost, path, bob, comment).expect(http.StatusCreated)
+	s.do(http.MethodPost, path, bob, comment).expect(http.StatusCreated)
+	s.do(http.MethodPost, path, bob, comment).expectError(http.StatusTooManyRequests, "rate_limited")
+
+	// As demais rotas autenticadas e os comentários de outros usuários seguem liberados
+	s.do(http.MethodPut, "/api/posts/"+created.ID+"/like", bob, nil).expect(http.StatusOK)
+	s.do(http.MethodPost, path, alice, comment).expect(http.StatusCreated)
+}
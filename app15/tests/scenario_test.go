@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+)
+
+// author é o subconjunto dos dados públicos do autor verificado pelos cenários
+type author struct {
+	Username string `json:"username"`
+}
+
+// post é o subconjunto dos campos de um post verificado pelos cenários
+type post struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Content     string  `json:"content"`
+	ContentHTML string  `json:"content_html"`
+	AuthorID    string  `json:"author_id"`
+	Author      *author `json:"author"`
+}
+
+// comment é o subconjunto dos campos de um comentário verificado pelos cenários
+type comment struct {
+	ID       string  `json:"id"`
+	PostID   string  `json:"post_id"`
+	Content  string  `json:"content"`
+	AuthorID string  `json:"author_id"`
+	Author   *author `json:"author"`
+}
+
+// Cenário completo de um post: cadastro, login, publicação, comentário de outro usuário,
+// tentativas de edição sem permissão e remoção pelo autor
+func TestPostLifecycleScenario(t *testing.T) {
+	s := newTestServer(t)
+
+	// Alice se cadastra e entra com email e senha
+	s.register("alice")
+	alice := s.login("alice")
+
+	// Alice publica um post em Markdown
+	var created post
+	s.do(http.MethodPost, "/api/posts", alice, map[string]interface{}{
+		"title":   "Primeiro post",
+		"content": "Olá, **mundo**!",
+		"tags":    []string{"go"},
+	}).expect(http.StatusCreated).decode(&created)
+	if created.ID == "" || created.ContentHTML != "<p>Olá, <strong>mundo</strong>!</p>\n" {
+		t.Fatalf("post criado inesperado: %+v", created)
+	}
+
+	// Visitantes anônimos veem o post publicado
+	var fetched post
+	s.do(http.MethodGet, "/api/posts/"+created.ID, "", nil).expect(http.StatusOK).decode(&fetched)
+	if fetched.Title != created.Title || fetched.AuthorID != created.AuthorID {
+		t.Fatalf("esperava o post %+v, obteve %+v", created, fetched)
+	}
+
+	// A listagem já traz o autor
+	var listed struct {
+		Items []post `json:"items"`
+	}
+	s.do(http.MethodGet, "/api/posts", "", nil).expect(http.StatusOK).decode(&listed)
+	if len(listed.Items) != 1 || listed.Items[0].Author == nil || listed.Items[0].Author.Username != "alice" {
+		t.Fatalf("esperava o post de alice com o autor preenchido, obteve %+v", listed.Items)
+	}
+
+	// Bob se cadastra e comenta o post
+	bob := s.register("bob")
+	var added comment
+	s.do(http.MethodPost, "/api/posts/"+created.ID+"/comments", bob, map[string]string{
+		"content": "Ótimo post!",
+	}).expect(http.StatusCreated).decode(&added)
+	if added.PostID != created.ID || added.AuthorID == created.AuthorID {
+		t.Fatalf("comentário inesperado: %+v", added)
+	}
+
+	var comments struct {
+		Items []comment `json:"items"`
+	}
+	s.do(http.MethodGet, "/api/posts/"+created.ID+"/comments", "", nil).expect(http.StatusOK).decode(&comments)
+	if len(comments.Items) != 1 || comments.Items[0].ID != added.ID || comments.Items[0].Author == nil || comments.Items[0].Author.Username != "bob" {
+		t.Fatalf("esperava apenas o comentário %s, obteve %+v", added.ID, comments.Items)
+	}
+
+	// Bob não pode editar nem remover o post de Alice, e visitantes precisam se autenticar
+	edit := map[string]string{"title": "Invadido", "content": "Conteúdo alterado"}
+	s.do(http.MethodPut, "/api/posts/"+created.ID, bob, edit).expectError(http.StatusForbidden, "forbidden")
+	s.do(http.MethodPut, "/api/posts/"+created.ID, "", edit).expectError(http.StatusUnauthorized, "unauthenticated")
+	s.do(http.MethodDelete, "/api/posts/"+created.ID, bob, nil).expectError(http.StatusForbidden, "forbidden")
+
+	s.do(http.MethodGet, "/api/posts/"+created.ID, "", nil).expect(http.StatusOK).decode(&fetched)
+	if fetched.Title != created.Title || fetched.Content != created.Content {
+		t.Fatalf("post alterado sem permissão: %+v", fetched)
+	}
+
+	// Alice remove o post, que deixa de existir para todos
+	s.do(http.MethodDelete, "/api/posts/"+created.ID, alice, nil).expect(http.StatusNoContent)
+	s.do(http.MethodGet, "/api/posts/"+created.ID, "", nil).expectError(http.StatusNotFound, "post_not_found")
+	s.do(http.MethodGet, "/api/posts/"+created.ID, alice, nil).expectError(http.StatusNotFound, "post_not_found")
+}